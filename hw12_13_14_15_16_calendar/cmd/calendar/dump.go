@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	storageInterface "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/dump"
+	memorystorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/memory"
+	sqlstorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/sql"
+)
+
+// errMemoryStorage rejects dump and restore without a database: the command would run on an
+// empty memory storage of its own, not on the one inside the running calendar.
+var errMemoryStorage = errors.New("the memory storage only lives inside the running calendar, enable system.database")
+
+// openStorage connects to the database or, when it is disabled, creates an empty memory storage.
+// closeFn releases the storage.
+func openStorage(
+	ctx context.Context, cfg *configuration.Config, logg *logger.Logger,
+) (st storageInterface.Storage, closeFn func(), err error) {
+	if cfg.System.Database.Enable {
+		db, err := sqlstorage.NewStorage(ctx, &cfg.System.Database, logg.WithModule("sqlStorage"))
+		if err != nil {
+			return nil, nil, err
+		}
+		return db, db.DB.Close, nil
+	}
+	return memorystorage.NewLocalStorage(logg.WithModule("localStorage")), func() {}, nil
+}
+
+// writeDump writes the dump next to path and renames it over, so a crash never leaves half a file.
+func writeDump(ctx context.Context, st storageInterface.Storage, path string) (dump.Stats, error) {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return dump.Stats{}, fmt.Errorf("write dump: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	stats, err := dump.Dump(ctx, st, tmp, time.Now())
+	if err != nil {
+		tmp.Close()
+		return stats, err
+	}
+	if err := tmp.Close(); err != nil {
+		return stats, fmt.Errorf("write dump: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return stats, fmt.Errorf("write dump: %w", err)
+	}
+	return stats, nil
+}
+
+func runDump(ctx context.Context, cfg *configuration.Config, path string, logg *logger.Logger) error {
+	if path == "" {
+		return errors.New("expected a file name")
+	}
+	if !cfg.System.Database.Enable {
+		return errMemoryStorage
+	}
+	st, closeFn, err := openStorage(ctx, cfg, logg)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	stats, err := writeDump(ctx, st, path)
+	if err != nil {
+		return err
+	}
+	logg.Info("dumped " + formatStats(stats))
+	return nil
+}
+
+func runRestore(ctx context.Context, cfg *configuration.Config, path string, logg *logger.Logger) error {
+	if path == "" {
+		return errors.New("expected a dump file")
+	}
+	if !cfg.System.Database.Enable {
+		return errMemoryStorage
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("restore: %w", err)
+	}
+	defer f.Close()
+
+	st, closeFn, err := openStorage(ctx, cfg, logg)
+	if err != nil {
+		return err
+	}
+	defer closeFn()
+
+	stats, err := dump.Restore(ctx, st, f, logg.WithModule("dump"))
+	if err != nil {
+		return err
+	}
+	logg.Info("restored " + formatStats(stats))
+
+	if stats.Failed > 0 {
+		return fmt.Errorf("%d records failed, see the log", stats.Failed)
+	}
+	return nil
+}
+
+func formatStats(s dump.Stats) string {
+	res := fmt.Sprintf("%d calendars, %d labels, %d events", s.Calendars, s.Labels, s.Events)
+	if s.Failed > 0 {
+		res += fmt.Sprintf(", %d failed", s.Failed)
+	}
+	return res
+}
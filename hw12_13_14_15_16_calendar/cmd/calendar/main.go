@@ -2,29 +2,38 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"os"
 	"os/signal"
+	"strings"
+	"sync/atomic"
 	"syscall"
+	// Event time zones must resolve in the alpine image, which has no zoneinfo.
+	_ "time/tzdata"
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/cmd"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/consts"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/attachment"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/controllers"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/server/grpc"
 	internalhttp "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/server/http"
-	storageInterface "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage"
-	memorystorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/memory"
 	sqlstorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/sql"
 )
 
-var configFile string
+var (
+	configFile string
+	overrides  = configuration.Overrides{}
+)
 
 func init() {
 	flag.StringVar(&configFile, "config",
 		"./configs/calendar_config.yaml",
 		"Path to configuration file")
+	flag.Var(overrides, "set", "Override a config value, e.g. -set logger.level=info (repeatable)")
 }
 
 func main() {
@@ -35,34 +44,74 @@ func main() {
 		return
 	}
 
-	cfg, err := configuration.LoadConfig(configFile)
+	cfg, err := configuration.LoadConfig(configFile, overrides)
 	if err != nil {
 		panic(err)
 	}
 
+	if cmd.IsConfigPrint() {
+		cmd.PrintConfig(cfg)
+		return
+	}
+
 	logg := logger.NewLogger(consts.AppName, cmd.Release, cfg.Logger.Level)
 
 	ctx, cancel := signal.NotifyContext(context.Background(),
-		syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+		syscall.SIGINT, syscall.SIGTERM)
 	defer cancel()
 
-	var storage storageInterface.Storage
-	if cfg.System.Database.Enable {
-		storage, err = sqlstorage.NewStorage(ctx, &cfg.System.Database, logg.WithModule("sqlStorage"))
-		if err != nil {
-			logg.Fatal("failed to connect to db")
+	switch flag.Arg(0) {
+	case "migrate":
+		if err := migrate(ctx, cfg, flag.Arg(1), logg); err != nil {
+			logg.Fatal("migrate: " + err.Error())
+		}
+		return
+	case "dump":
+		if err := runDump(ctx, cfg, flag.Arg(1), logg); err != nil {
+			logg.Fatal("dump: " + err.Error())
+		}
+		return
+	case "restore":
+		if err := runRestore(ctx, cfg, flag.Arg(1), logg); err != nil {
+			logg.Fatal("restore: " + err.Error())
+		}
+		return
+	}
+
+	storage, closeStorage, err := openStorage(ctx, cfg, logg)
+	if err != nil {
+		logg.Fatal("failed to open storage: " + err.Error())
+	}
+	defer closeStorage()
+
+	var files attachment.Store
+	if cfg.System.Attachments.Enable {
+		if files, err = attachment.NewStore(cfg.System.Attachments); err != nil {
+			logg.Fatal("failed to open attachment store: " + err.Error())
 		}
-	} else {
-		storage = memorystorage.NewLocalStorage(logg.WithModule("localStorage"))
+		sweeper := attachment.NewSweeper(storage, files, cfg.System.Attachments.SweepInterval,
+			logg.WithModule("attachments"))
+		go sweeper.Run(ctx)
 	}
-	calendar := app.New(logg.WithModule("app"), controllers.NewCalendarHandler(storage, &cfg))
+	// The handler reads the configuration per request, so a reload swaps it atomically.
+	var current atomic.Pointer[configuration.Config]
+	current.Store(cfg)
+	handler := controllers.NewCalendarHandler(storage, files, &current, logg.WithModule("controllers"))
+	calendar := app.New(logg.WithModule("app"), handler)
 
 	httpServer := internalhttp.NewHTTPServer(cfg, *logg, calendar)
 	if httpServer == nil {
 		logg.Fatal("failed to start http server")
 	}
 
-	grpcServer := grpc.NewGrpcServer(cfg, logg, calendar)
+	grpcServer, err := grpc.NewGrpcServer(cfg, logg, calendar)
+	if err != nil {
+		logg.Fatal("failed to create grpc server: " + err.Error())
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	go watchReload(ctx, hup, &current, logg)
 
 	if err := httpServer.Start(); err != nil {
 		cancel()
@@ -82,3 +131,53 @@ func main() {
 	}
 	grpcServer.Stop()
 }
+
+func migrate(ctx context.Context, cfg *configuration.Config, command string, logg *logger.Logger) error {
+	if !cfg.System.Database.Enable {
+		return errors.New("database is disabled in config")
+	}
+
+	dbCfg := cfg.System.Database
+	dbCfg.AutoMigrate = false
+	storage, err := sqlstorage.NewStorage(ctx, &dbCfg, logg.WithModule("migrate"))
+	if err != nil {
+		return err
+	}
+	defer storage.DB.Close()
+
+	return storage.Migrate(ctx, command, os.Stdout)
+}
+
+// watchReload re-reads the configuration file on every SIGHUP. Invalid files are rejected
+// and the running configuration is kept; the log level is applied immediately, while
+// listener and database settings only take effect after a restart.
+func watchReload(
+	ctx context.Context,
+	hup <-chan os.Signal,
+	cfg *atomic.Pointer[configuration.Config],
+	logg *logger.Logger,
+) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			next, err := configuration.LoadConfig(configFile, overrides)
+			if err != nil {
+				logg.Error("config reload rejected: " + err.Error())
+				continue
+			}
+
+			if keys := cfg.Load().RestartRequired(next); len(keys) > 0 {
+				logg.Warn("config reload: changes in " + strings.Join(keys, ", ") + " require a restart")
+			}
+			if err := logg.SetLevel(next.Logger.Level); err != nil {
+				logg.Error("config reload: " + err.Error())
+				continue
+			}
+
+			cfg.Store(next)
+			logg.Info("config reloaded from " + configFile)
+		}
+	}
+}
@@ -2,38 +2,56 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// Event time zones must resolve in the alpine image, which has no zoneinfo.
+	_ "time/tzdata"
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/cmd"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/consts"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/controllers"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/leader"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/rmq"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/scheduler"
 	storageInterface "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage"
 	memorystorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
 	sqlstorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/sql"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/webhook"
 )
 
-var configFile string
+var (
+	configFile string
+	overrides  = configuration.Overrides{}
+)
 
 func init() {
 	flag.StringVar(&configFile, "config", "./configs/scheduler_config.yaml", "Path to configuration file")
+	flag.Var(overrides, "set", "Override a config value, e.g. -set scheduler.interval=5s (repeatable)")
 }
 
 func main() {
 	flag.Parse()
 
-	cfg, err := configuration.LoadSchedulerConfig(configFile)
+	cfg, err := configuration.LoadSchedulerConfig(configFile, overrides)
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
+	if cmd.IsConfigPrint() {
+		cmd.PrintConfig(cfg)
+		return
+	}
+
 	logg := logger.NewLogger(consts.SchedulerAppName, cmd.Release, cfg.Logger.Level)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -43,14 +61,22 @@ func main() {
 	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
 
 	var storage storageInterface.Storage
+	var lock leader.Lock = leader.NoLock{}
 
 	if cfg.System.Database.Enable {
-		storage, err = sqlstorage.NewStorage(ctx, &cfg.System.Database, logg.WithModule("sqlStorage"))
+		db, err := sqlstorage.NewStorage(ctx, &cfg.System.Database, logg.WithModule("sqlStorage"))
 		if err != nil {
 			logg.Fatal(fmt.Sprintf("failed to connect to db: %v", err))
 		}
+		storage = db
+		if cfg.Scheduler.Leader.Enable {
+			lock = db.LeaderLock(consts.SchedulerAppName)
+		}
 	} else {
 		storage = memorystorage.NewLocalStorage(logg.WithModule("localStorage"))
+		if cfg.Scheduler.Leader.Enable {
+			lock = leader.NewFileLock(cfg.Scheduler.Leader.LockFile)
+		}
 	}
 
 	go func() {
@@ -59,11 +85,72 @@ func main() {
 		cancel()
 	}()
 
-	if err := runScheduler(ctx, cfg, storage, logg); err != nil {
+	if flag.Arg(0) == "retention" {
+		if err := runRetention(ctx, cfg, storage, logg); err != nil {
+			logg.Fatal("retention: " + err.Error())
+		}
+		return
+	}
+
+	elector := leader.NewElector(lock, cfg.Scheduler.Leader.Interval, logg.WithModule("leader"))
+	if cfg.Scheduler.HealthAddress != "" {
+		go serveHealth(ctx, cfg.Scheduler.HealthAddress, elector, logg)
+	}
+
+	err = elector.Run(ctx, func(ctx context.Context) error {
+		return runScheduler(ctx, cfg, storage, logg)
+	})
+	if err != nil {
 		logg.Fatal(fmt.Sprintf("scheduler stopped with error: %v", err))
 	}
 }
 
+// runRetention applies the retention rules once, right away and without waiting for leadership.
+// Combined with -set scheduler.retention.dry_run=true it reports what the next run would remove.
+func runRetention(
+	ctx context.Context,
+	cfg *configuration.SchedulerConfig,
+	storage storageInterface.Storage,
+	logg *logger.Logger,
+) error {
+	retention, err := scheduler.NewRetention(storage, cfg.Scheduler.Retention, logg.WithModule("retention"))
+	if err != nil {
+		return err
+	}
+	report, err := retention.Run(ctx, time.Now())
+
+	var ended, trashed int
+	for _, rr := range report.Rules {
+		ended += rr.Ended
+		trashed += rr.Trashed
+	}
+	verb := "removed"
+	if report.DryRun {
+		verb = "would remove"
+	}
+	logg.Info(fmt.Sprintf("retention %s %d finished and %d trashed events", verb, ended, trashed))
+	return err
+}
+
+// serveHealth reports on /healthz whether this replica is the leader.
+func serveHealth(ctx context.Context, address string, elector *leader.Elector, logg *logger.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("GET /healthz", elector)
+	srv := &http.Server{Addr: address, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+	}()
+
+	logg.Info("health endpoint listening on " + address)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		logg.Error(fmt.Sprintf("health endpoint: %v", err))
+	}
+}
+
 func runScheduler(
 	ctx context.Context,
 	cfg *configuration.SchedulerConfig,
@@ -81,6 +168,29 @@ func runScheduler(
 		}
 	}()
 
+	retention, err := scheduler.NewRetention(storage, cfg.Scheduler.Retention, logg.WithModule("retention"))
+	if err != nil {
+		return err
+	}
+	go retention.Schedule(ctx)
+
+	if cfg.Scheduler.Digest.Enable {
+		digest, err := scheduler.NewDigest(storage, rmqClient.PublishNotification, cfg.Scheduler.Digest,
+			logg.WithModule("digest"))
+		if err != nil {
+			return err
+		}
+		go digest.Run(ctx)
+	}
+
+	if cfg.Scheduler.Webhooks.Enable {
+		go webhook.NewDispatcher(storage, cfg.Scheduler.Webhooks, logg.WithModule("webhooks")).Run(ctx)
+	}
+
+	if cfg.Scheduler.Mode == configuration.SchedulerTimer {
+		return runTimer(ctx, cfg, storage, rmqClient, logg)
+	}
+
 	ticker := time.NewTicker(cfg.Scheduler.Interval)
 	logg.Info(fmt.Sprintf("Scheduler was started with interval %v", cfg.Scheduler.Interval))
 	defer ticker.Stop()
@@ -100,21 +210,101 @@ func runScheduler(
 				logg.Debug("no events to notify")
 			} else {
 				for _, ev := range events {
-					note := rmq.Notification{
-						EventID:  ev.ID,
-						Title:    ev.Title,
-						DateTime: ev.Date,
-						UserID:   ev.User,
-					}
-
-					if err := rmqClient.PublishNotification(ctx, note); err != nil {
+					if err := remind(ctx, storage, rmqClient, ev); err != nil {
 						logg.Error(fmt.Sprintf("publish notification: %v", err))
 					}
 				}
 			}
-			if err := storage.DeleteOldEvents(ctx, time.Now().AddDate(-1, 0, 0)); err != nil {
-				logg.Error(fmt.Sprintf("cleanup old events: %v", err))
-			}
 		}
 	}
 }
+
+// eventWatcher is implemented by storages that report changes to events, see sqlstorage.WatchEvents.
+type eventWatcher interface {
+	WatchEvents(ctx context.Context, changed func()) error
+}
+
+// runTimer fires every reminder at its due time.
+func runTimer(
+	ctx context.Context,
+	cfg *configuration.SchedulerConfig,
+	storage storageInterface.Storage,
+	publisher rmq.Publisher,
+	logg *logger.Logger,
+) error {
+	timer := scheduler.NewTimer(storage, func(ctx context.Context, ev models.Event) error {
+		return remind(ctx, storage, publisher, ev)
+	}, cfg.Scheduler.Interval, cfg.Scheduler.Horizon, logg.WithModule("timer"))
+
+	if w, ok := storage.(eventWatcher); ok {
+		go watchEvents(ctx, w, timer.Changed, cfg.Scheduler.Interval, logg)
+	}
+
+	logg.Info(fmt.Sprintf("Scheduler was started in timer mode, horizon %v, reload every %v",
+		cfg.Scheduler.Horizon, cfg.Scheduler.Interval))
+	return timer.Run(ctx)
+}
+
+// watchEvents keeps listening for changes; while the listener is down the periodic reload still applies them.
+func watchEvents(ctx context.Context, w eventWatcher, changed func(), retry time.Duration, logg *logger.Logger) {
+	for {
+		err := w.WatchEvents(ctx, changed)
+		if ctx.Err() != nil {
+			return
+		}
+		logg.Warn(fmt.Sprintf("event change notifications stopped: %v", err))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retry):
+			// Changes made while not listening are picked up by this reload.
+			changed()
+		}
+	}
+}
+
+// remind publishes the reminder of ev and queues reminder.due for the webhooks of its owner.
+// The poll mode finds a reminder again on every tick until the event starts, so the delivery
+// is keyed by the event and its notify time: each reminder is queued once, and again only when
+// the event is moved. The reminder is out already when the queueing fails, so that is only
+// logged by the storage.
+func remind(ctx context.Context, storage storageInterface.Storage, publisher rmq.Publisher, ev models.Event) error {
+	if err := publisher.PublishNotification(ctx, notification(ev)); err != nil {
+		return err
+	}
+	payload, err := webhook.NewPayload(models.WebhookReminderDue, controllers.EventToProto(&ev), time.Now())
+	if err == nil {
+		_, _ = storage.WebhookEnqueue(ctx, &models.EnqueueWebhookReq{
+			Type:    models.WebhookReminderDue,
+			Users:   []string{ev.User},
+			Payload: payload,
+			Key:     ev.ID + "@" + ev.NotifyAt().UTC().Format(time.RFC3339),
+		})
+	}
+	return nil
+}
+
+func notification(ev models.Event) rmq.Notification {
+	note := rmq.Notification{
+		EventID:  ev.ID,
+		Title:    ev.Title,
+		DateTime: ev.Date,
+		UserID:   ev.User,
+		EndTime:  ev.EndTime,
+		TimeZone: ev.TimeZone,
+		AllDay:   ev.AllDay,
+	}
+	if ev.Description != nil {
+		note.Description = *ev.Description
+	}
+	if ev.NotifyBefore != nil {
+		note.NotifyBefore = *ev.NotifyBefore
+	}
+	if ev.Location != nil {
+		note.Location = *ev.Location
+	}
+	if ev.MeetingURL != nil {
+		note.MeetingURL = *ev.MeetingURL
+	}
+	return note
+}
@@ -8,28 +8,46 @@ import (
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
+	// Notifications are rendered in the event's time zone, and the alpine image has no zoneinfo.
+	_ "time/tzdata"
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/cmd"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/notify"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/rmq"
 )
 
-var configFile string
+var (
+	configFile string
+	overrides  = configuration.Overrides{}
+)
 
 func init() {
 	flag.StringVar(&configFile, "config", "./configs/sender_config.yaml", "Path to configuration file")
+	flag.Var(overrides, "set", "Override a config value, e.g. -set rabbitmq.queue=events (repeatable)")
 }
 
 func main() {
 	flag.Parse()
 
-	cfg, err := configuration.LoadSenderConfig(configFile)
+	cfg, err := configuration.LoadSenderConfig(configFile, overrides)
 	if err != nil {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
+	if cmd.IsConfigPrint() {
+		cmd.PrintConfig(cfg)
+		return
+	}
+
 	logg := logger.NewLogger("calendar_sender", cmd.Release, cfg.Logger.Level)
+
+	templates, err := notify.NewTemplates(cfg.Sender.Templates)
+	if err != nil {
+		logg.Fatal(fmt.Sprintf("failed to load templates: %v", err))
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -41,7 +59,7 @@ func main() {
 		cancel()
 	}()
 
-	consumerRMQ, err := rmq.NewRMQClient(cfg.RabbitMQ.URI, cfg.RabbitMQ.Queue)
+	consumerRMQ, err := dialRMQ(ctx, cfg.RabbitMQ.URI, cfg.RabbitMQ.Queue, cfg.Sender.PollInterval, logg)
 	if err != nil {
 		logg.Fatal(fmt.Sprintf("failed to init consumer rmq: %v", err))
 	}
@@ -52,7 +70,7 @@ func main() {
 		}
 	}(consumerRMQ)
 
-	producerRMQ, err := rmq.NewRMQClient(cfg.RabbitMQ.URI, cfg.RabbitMQ.ForwardQueue)
+	producerRMQ, err := dialRMQ(ctx, cfg.RabbitMQ.URI, cfg.RabbitMQ.ForwardQueue, cfg.Sender.PollInterval, logg)
 	if err != nil {
 		logg.Fatal(fmt.Sprintf("failed to init producer rmq: %v", err))
 	}
@@ -65,10 +83,15 @@ func main() {
 
 	logg.Info("Consumer started")
 	err = consumerRMQ.ConsumeNotifications(ctx, func(note rmq.Notification) {
-		logg.Info(fmt.Sprintf("[NOTIFY] EventID: %s, Title: %s, DateTime: %s, UserID: %s",
-			note.EventID, note.Title, note.DateTime.Format("2006-01-02 15:04:05"), note.UserID))
+		logg.Info(fmt.Sprintf("[NOTIFY] EventID: %s, Title: %s, DateTime: %s, UserID: %s, Version: %d",
+			note.EventID, note.Title, note.DateTime.Format("2006-01-02 15:04:05"), note.UserID, note.Version))
 
-		if err := producerRMQ.PublishNotification(ctx, note); err != nil {
+		msg, err := templates.Render(note)
+		if err != nil {
+			logg.Error(fmt.Sprintf("failed to render notification: %v", err))
+			return
+		}
+		if err := producerRMQ.PublishMessage(ctx, msg); err != nil {
 			logg.Error(fmt.Sprintf("failed to forward notification: %v", err))
 		}
 	})
@@ -76,3 +99,26 @@ func main() {
 		logg.Fatal(fmt.Sprintf("failed to consume notifications: %v", err))
 	}
 }
+
+// dialRMQ keeps trying to connect every pollInterval until RabbitMQ accepts the connection
+// or ctx is cancelled, so the sender survives being started before the broker.
+func dialRMQ(
+	ctx context.Context,
+	uri, queue string,
+	pollInterval time.Duration,
+	logg *logger.Logger,
+) (*rmq.RMQClient, error) {
+	for {
+		client, err := rmq.NewRMQClient(uri, queue)
+		if err == nil {
+			return client, nil
+		}
+		logg.Warn(fmt.Sprintf("rmq is not available, retry in %s: %v", pollInterval, err))
+
+		select {
+		case <-ctx.Done():
+			return nil, err
+		case <-time.After(pollInterval):
+		}
+	}
+}
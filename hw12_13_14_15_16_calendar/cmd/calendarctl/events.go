@@ -0,0 +1,260 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// stringList collects a repeatable flag; each value may also hold several comma-separated items.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			*l = append(*l, item)
+		}
+	}
+	return nil
+}
+
+// timeFlag accepts RFC 3339 or a bare date, which means midnight UTC.
+type timeFlag struct {
+	t *time.Time
+}
+
+func (f timeFlag) String() string {
+	if f.t == nil || f.t.IsZero() {
+		return ""
+	}
+	return f.t.Format(time.RFC3339)
+}
+
+func (f timeFlag) Set(value string) error {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		if t, err = time.Parse(time.DateOnly, value); err != nil {
+			return fmt.Errorf("expected RFC 3339 time or date, got %q", value)
+		}
+	}
+	*f.t = t
+	return nil
+}
+
+// eventFlags are shared by create and edit.
+type eventFlags struct {
+	title, description, notifyBefore, calendar, user, timeZone string
+	location, meetingURL                                       string
+	start, end                                                 time.Time
+	allDay                                                     bool
+	attendees, labels                                          stringList
+}
+
+func (ef *eventFlags) register(f *flag.FlagSet) {
+	f.StringVar(&ef.title, "title", "", "event title")
+	f.Var(timeFlag{&ef.start}, "start", "start time")
+	f.Var(timeFlag{&ef.end}, "end", "end time")
+	f.StringVar(&ef.user, "user", "", "owner user ID")
+	f.StringVar(&ef.description, "description", "", "event description")
+	f.StringVar(&ef.notifyBefore, "notify-before", "", "reminder offset, e.g. 15m")
+	f.StringVar(&ef.calendar, "calendar", "", "calendar ID")
+	f.StringVar(&ef.timeZone, "time-zone", "", "IANA time zone, e.g. Europe/Moscow")
+	f.BoolVar(&ef.allDay, "all-day", false, "all-day event")
+	f.Var(&ef.attendees, "attendee", "attendee user ID (repeatable)")
+	f.Var(&ef.labels, "label", "label name (repeatable)")
+	f.StringVar(&ef.location, "location", "", "where the event takes place")
+	f.StringVar(&ef.meetingURL, "meeting-url", "", "http(s) link to join the event online")
+}
+
+func runCreate(ctx context.Context, e *env, args []string) error {
+	var ef eventFlags
+	f := flag.NewFlagSet("create", flag.ContinueOnError)
+	f.SetOutput(e.stderr)
+	ef.register(f)
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	set := visited(f)
+
+	req := &proto.CreateEventReq{
+		Title:     ef.title,
+		Date:      timestamppb.New(ef.start),
+		EndTime:   timestamppb.New(ef.end),
+		User:      ef.user,
+		AllDay:    ef.allDay,
+		Attendees: ef.attendees,
+		Labels:    ef.labels,
+	}
+	if set["description"] {
+		req.Description = &ef.description
+	}
+	if set["notify-before"] {
+		req.NotifyBefore = &ef.notifyBefore
+	}
+	if set["calendar"] {
+		req.CalendarId = &ef.calendar
+	}
+	if set["time-zone"] {
+		req.TimeZone = &ef.timeZone
+	}
+	if set["location"] {
+		req.Location = &ef.location
+	}
+	if set["meeting-url"] {
+		req.MeetingUrl = &ef.meetingURL
+	}
+
+	ev, err := e.client.CreateEvent(ctx, req)
+	if err != nil {
+		return err
+	}
+	return e.printer.event(ev)
+}
+
+// runEdit only sends the fields whose flags were given.
+func runEdit(ctx context.Context, e *env, args []string) error {
+	var ef eventFlags
+	var id string
+	f := flag.NewFlagSet("edit", flag.ContinueOnError)
+	f.SetOutput(e.stderr)
+	f.StringVar(&id, "id", "", "event ID")
+	ef.register(f)
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+	if id == "" {
+		return errors.New("edit: -id is required")
+	}
+	set := visited(f)
+
+	req := &proto.EditEventReq{Id: id}
+	if set["title"] {
+		req.Title = &ef.title
+	}
+	if set["start"] {
+		req.Date = timestamppb.New(ef.start)
+	}
+	if set["end"] {
+		req.EndTime = timestamppb.New(ef.end)
+	}
+	if set["user"] {
+		req.User = &ef.user
+	}
+	if set["description"] {
+		req.Description = &ef.description
+	}
+	if set["notify-before"] {
+		req.NotifyBefore = &ef.notifyBefore
+	}
+	if set["calendar"] {
+		req.CalendarId = &ef.calendar
+	}
+	if set["time-zone"] {
+		req.TimeZone = &ef.timeZone
+	}
+	if set["all-day"] {
+		req.AllDay = &ef.allDay
+	}
+	if set["location"] {
+		req.Location = &ef.location
+	}
+	if set["meeting-url"] {
+		req.MeetingUrl = &ef.meetingURL
+	}
+	if set["attendee"] {
+		req.Attendees = &proto.AttendeeList{Users: ef.attendees}
+	}
+	if set["label"] {
+		req.Labels = &proto.LabelList{Names: ef.labels}
+	}
+
+	ev, err := e.client.EditEvent(ctx, req)
+	if err != nil {
+		return err
+	}
+	return e.printer.event(ev)
+}
+
+func runGet(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return errors.New("get: expected an event ID")
+	}
+	ev, err := e.client.GetEvent(ctx, &proto.EventByIdReq{EventId: args[0]})
+	if err != nil {
+		return err
+	}
+	return e.printer.event(ev)
+}
+
+func runDelete(ctx context.Context, e *env, args []string) error {
+	if len(args) != 1 {
+		return errors.New("delete: expected an event ID")
+	}
+	if _, err := e.client.DeleteEvent(ctx, &proto.EventByIdReq{EventId: args[0]}); err != nil {
+		return err
+	}
+	fmt.Fprintln(e.stderr, "event "+args[0]+" moved to trash")
+	return nil
+}
+
+// listFlags select events for list and export.
+type listFlags struct {
+	start, end time.Time
+	user       string
+	labels     stringList
+}
+
+func (lf *listFlags) register(f *flag.FlagSet) {
+	f.Var(timeFlag{&lf.start}, "start", "events ending after this time")
+	f.Var(timeFlag{&lf.end}, "end", "events starting before this time")
+	f.StringVar(&lf.user, "user", "", "events the user owns or attends")
+	f.Var(&lf.labels, "label", "events carrying the label (repeatable)")
+}
+
+func (lf *listFlags) request() *proto.GetEventListReq {
+	req := &proto.GetEventListReq{Labels: lf.labels}
+	if !lf.start.IsZero() {
+		start := lf.start.Format(time.RFC3339)
+		req.Start = &start
+	}
+	if !lf.end.IsZero() {
+		end := lf.end.Format(time.RFC3339)
+		req.End = &end
+	}
+	if lf.user != "" {
+		req.User = &lf.user
+	}
+	return req
+}
+
+func runList(ctx context.Context, e *env, args []string) error {
+	var lf listFlags
+	f := flag.NewFlagSet("list", flag.ContinueOnError)
+	f.SetOutput(e.stderr)
+	lf.register(f)
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+
+	res, err := e.client.GetEventList(ctx, lf.request())
+	if err != nil {
+		return err
+	}
+	return e.printer.events(res.Data)
+}
+
+func visited(f *flag.FlagSet) map[string]bool {
+	set := make(map[string]bool)
+	f.Visit(func(fl *flag.Flag) { set[fl.Name] = true })
+	return set
+}
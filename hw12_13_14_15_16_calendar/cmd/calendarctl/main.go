@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/cmd"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+)
+
+// env is what every subcommand works with.
+type env struct {
+	client  proto.CalendarClient
+	printer *printer
+	stdin   io.Reader
+	stderr  io.Writer
+}
+
+type command struct {
+	usage string
+	run   func(ctx context.Context, e *env, args []string) error
+}
+
+var commands = map[string]command{
+	"create": {"create -title T -start TIME -end TIME -user ID [flags]", runCreate},
+	"edit":   {"edit -id ID [-title T] [-start TIME] [-end TIME] [flags]", runEdit},
+	"get":    {"get ID", runGet},
+	"delete": {"delete ID", runDelete},
+	"list":   {"list [-start TIME] [-end TIME] [-user ID] [-label NAME]...", runList},
+	"export": {"export [-start TIME] [-end TIME] [-user ID] [-f FILE]", runExport},
+	"import": {"import [-f FILE]", runImport},
+}
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	if err := run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "calendarctl: "+err.Error())
+		}
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("calendarctl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	configPath := fs.String("config", defaultConfigPath(), "path to the profiles file")
+	profileName := fs.String("profile", os.Getenv("CALENDARCTL_PROFILE"), "profile to use instead of the current one")
+	address := fs.String("address", "", "gRPC address of the calendar, overrides the profile")
+	format := fs.String("o", formatTable, "output format: table, json or yaml")
+	fs.Usage = func() { usage(fs) }
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return flag.ErrHelp
+	}
+
+	name, rest := fs.Arg(0), fs.Args()[1:]
+	switch name {
+	case "version":
+		cmd.PrintVersion()
+		return nil
+	case "profile":
+		return runProfile(*configPath, rest, stdout)
+	}
+
+	c, ok := commands[name]
+	if !ok {
+		fs.Usage()
+		return fmt.Errorf("unknown command %q", name)
+	}
+
+	p, err := loadProfile(*configPath, *profileName)
+	if err != nil {
+		return err
+	}
+	if *address != "" {
+		p.Address = *address
+	}
+
+	pr, err := newPrinter(*format, stdout)
+	if err != nil {
+		return err
+	}
+
+	conn, err := dial(p)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return c.run(ctx, &env{
+		client:  proto.NewCalendarClient(conn),
+		printer: pr,
+		stdin:   stdin,
+		stderr:  stderr,
+	}, rest)
+}
+
+func usage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintln(out, "Usage: calendarctl [global flags] <command> [flags]")
+	fmt.Fprintln(out, "\nCommands:")
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(out, "  "+commands[name].usage)
+	}
+	fmt.Fprintln(out, "  profile list | profile use NAME | profile set NAME -address ADDR [flags]")
+	fmt.Fprintln(out, "  version")
+	fmt.Fprintln(out, "\nTIME is RFC 3339 (2026-10-19T09:00:00+03:00) or a date (2026-10-19).")
+	fmt.Fprintln(out, "\nGlobal flags:")
+	fs.PrintDefaults()
+}
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/stretchr/testify/require"
+	rpcstatus "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeClient records the requests of the calls calendarctl makes.
+type fakeClient struct {
+	proto.CalendarClient
+	events  []*proto.Event
+	created []*proto.CreateEventReq
+	edited  *proto.EditEventReq
+}
+
+func (c *fakeClient) GetEventList(
+	_ context.Context, _ *proto.GetEventListReq, _ ...grpc.CallOption,
+) (*proto.GetEventListRes, error) {
+	return &proto.GetEventListRes{Data: c.events}, nil
+}
+
+func (c *fakeClient) EditEvent(
+	_ context.Context, req *proto.EditEventReq, _ ...grpc.CallOption,
+) (*proto.Event, error) {
+	c.edited = req
+	return &proto.Event{Id: req.Id}, nil
+}
+
+// BatchCreateEvents fails every item titled "busy".
+func (c *fakeClient) BatchCreateEvents(
+	_ context.Context, req *proto.BatchCreateEventsReq, _ ...grpc.CallOption,
+) (*proto.BatchEventsRes, error) {
+	res := &proto.BatchEventsRes{}
+	for _, item := range req.Items {
+		if item.Title == "busy" {
+			res.Results = append(res.Results, &proto.BatchEventResult{
+				Status: &rpcstatus.Status{Code: int32(codes.AlreadyExists), Message: "date busy"},
+			})
+			continue
+		}
+		c.created = append(c.created, item)
+		res.Results = append(res.Results, &proto.BatchEventResult{Status: &rpcstatus.Status{}})
+	}
+	return res, nil
+}
+
+func testEnv(t *testing.T, client proto.CalendarClient, format string, stdin string) (*env, *bytes.Buffer) {
+	t.Helper()
+	out := &bytes.Buffer{}
+	pr, err := newPrinter(format, out)
+	require.NoError(t, err)
+	return &env{client: client, printer: pr, stdin: strings.NewReader(stdin), stderr: &bytes.Buffer{}}, out
+}
+
+func testEvent(id, title string) *proto.Event {
+	start := time.Date(2026, 10, 19, 6, 0, 0, 0, time.UTC)
+	return &proto.Event{
+		Id:        id,
+		Title:     title,
+		Date:      timestamppb.New(start),
+		EndTime:   timestamppb.New(start.Add(time.Hour)),
+		User:      "alice",
+		TimeZone:  "Europe/Moscow",
+		Labels:    []string{"work"},
+		Attendees: []*proto.Attendee{{User: "bob", Status: proto.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED}},
+	}
+}
+
+func TestPrinter(t *testing.T) {
+	client := &fakeClient{events: []*proto.Event{testEvent("1", "standup")}}
+
+	t.Run("table", func(t *testing.T) {
+		e, out := testEnv(t, client, formatTable, "")
+		require.NoError(t, runList(context.Background(), e, nil))
+		require.Contains(t, out.String(), "ID  TITLE")
+		require.Contains(t, out.String(), "standup  2026-10-19 09:00 MSK  2026-10-19 10:00 MSK  alice  work")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		e, out := testEnv(t, client, formatJSON, "")
+		require.NoError(t, runList(context.Background(), e, nil))
+		require.Contains(t, out.String(), `"end_time": "2026-10-19T07:00:00Z"`)
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		e, out := testEnv(t, client, formatYAML, "")
+		require.NoError(t, runList(context.Background(), e, nil))
+		require.Contains(t, out.String(), "  id: \"1\"\n")
+		require.Contains(t, out.String(), "  title: standup\n")
+	})
+
+	_, err := newPrinter("xml", &bytes.Buffer{})
+	require.Error(t, err)
+}
+
+func TestEditSendsOnlyGivenFlags(t *testing.T) {
+	client := &fakeClient{}
+	e, _ := testEnv(t, client, formatJSON, "")
+
+	err := runEdit(context.Background(), e, []string{"-id", "1", "-title", "review", "-label", "a,b", "-all-day=false",
+		"-location", ""})
+	require.NoError(t, err)
+	require.Equal(t, "review", client.edited.GetTitle())
+	require.Equal(t, []string{"a", "b"}, client.edited.Labels.Names)
+	require.NotNil(t, client.edited.AllDay)
+	require.Nil(t, client.edited.Date)
+	require.Nil(t, client.edited.Description)
+	require.Nil(t, client.edited.Attendees)
+	require.NotNil(t, client.edited.Location, "an empty location is sent to remove it")
+	require.Nil(t, client.edited.MeetingUrl)
+
+	require.Error(t, runEdit(context.Background(), e, []string{"-title", "review"}))
+}
+
+func TestExportImport(t *testing.T) {
+	source := &fakeClient{events: []*proto.Event{testEvent("1", "standup"), testEvent("2", "busy")}}
+	e, out := testEnv(t, source, formatTable, "")
+	require.NoError(t, runExport(context.Background(), e, nil))
+	require.Equal(t, 2, strings.Count(out.String(), "\n"))
+
+	target := &fakeClient{}
+	e, _ = testEnv(t, target, formatTable, out.String()+"not json\n")
+	err := runImport(context.Background(), e, nil)
+	require.ErrorContains(t, err, "2 events failed")
+
+	stderr := e.stderr.(*bytes.Buffer).String()
+	require.Contains(t, stderr, "line 2: date busy")
+	require.Contains(t, stderr, "line 3: ")
+	require.Contains(t, stderr, "imported 1 events, 2 failed")
+
+	require.Len(t, target.created, 1)
+	created := target.created[0]
+	require.Equal(t, "standup", created.Title)
+	require.Equal(t, []string{"bob"}, created.Attendees)
+	require.Equal(t, "Europe/Moscow", created.GetTimeZone())
+	require.True(t, source.events[0].Date.AsTime().Equal(created.Date.AsTime()))
+}
+
+func TestImportBatches(t *testing.T) {
+	var in strings.Builder
+	for range importBatchSize + 1 {
+		line, err := jsonOptions.Marshal(testEvent("", "standup"))
+		require.NoError(t, err)
+		in.Write(line)
+		in.WriteByte('\n')
+	}
+
+	client := &fakeClient{}
+	e, _ := testEnv(t, client, formatTable, in.String())
+	require.NoError(t, runImport(context.Background(), e, nil))
+	require.Len(t, client.created, importBatchSize+1)
+}
+
+func TestProfiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendarctl", "config.yaml")
+
+	p, err := loadProfile(path, "")
+	require.NoError(t, err)
+	require.Equal(t, "localhost:8081", p.Address)
+
+	out := &bytes.Buffer{}
+	require.NoError(t, runProfile(path, []string{"set", "dev", "-address", "dev:8081"}, out))
+	require.NoError(t, runProfile(path, []string{"set", "prod", "-address", "prod:443", "-tls", "-timeout", "3s"}, out))
+	require.Error(t, runProfile(path, []string{"use", "stage"}, out))
+
+	p, err = loadProfile(path, "")
+	require.NoError(t, err)
+	require.Equal(t, "dev:8081", p.Address)
+	require.Equal(t, defaultTimeout, p.Timeout)
+
+	require.NoError(t, runProfile(path, []string{"use", "prod"}, out))
+	p, err = loadProfile(path, "")
+	require.NoError(t, err)
+	require.Equal(t, &profile{Address: "prod:443", Timeout: 3 * time.Second, TLS: true}, p)
+
+	require.NoError(t, runProfile(path, []string{"list"}, out))
+	require.Equal(t, "  dev\tdev:8081\n* prod\tprod:443\n", out.String())
+
+	_, err = loadProfile(path, "stage")
+	require.Error(t, err)
+}
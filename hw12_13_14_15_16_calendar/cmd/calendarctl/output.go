@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// jsonOptions match the field names of the REST API, so exported lines can be fed to curl as well.
+var jsonOptions = protojson.MarshalOptions{UseProtoNames: true}
+
+type printer struct {
+	format string
+	out    io.Writer
+}
+
+func newPrinter(format string, out io.Writer) (*printer, error) {
+	switch format {
+	case formatTable, formatJSON, formatYAML:
+		return &printer{format: format, out: out}, nil
+	}
+	return nil, fmt.Errorf("unknown output format %q, expected table, json or yaml", format)
+}
+
+func (p *printer) event(ev *proto.Event) error {
+	if p.format == formatTable {
+		return p.table([]*proto.Event{ev})
+	}
+	raw, err := jsonOptions.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return p.structured(json.RawMessage(raw))
+}
+
+func (p *printer) events(events []*proto.Event) error {
+	if p.format == formatTable {
+		return p.table(events)
+	}
+	list := make([]json.RawMessage, 0, len(events))
+	for _, ev := range events {
+		raw, err := jsonOptions.Marshal(ev)
+		if err != nil {
+			return err
+		}
+		list = append(list, raw)
+	}
+	return p.structured(list)
+}
+
+// structured prints v, which already holds protojson output, as indented JSON or as YAML.
+func (p *printer) structured(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return err
+	}
+
+	if p.format == formatYAML {
+		enc := yaml.NewEncoder(p.out)
+		enc.SetIndent(2)
+		if err := enc.Encode(generic); err != nil {
+			return err
+		}
+		return enc.Close()
+	}
+	enc := json.NewEncoder(p.out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(generic)
+}
+
+func (p *printer) table(events []*proto.Event) error {
+	w := tabwriter.NewWriter(p.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tTITLE\tSTART\tEND\tUSER\tLABELS")
+	for _, ev := range events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			ev.Id,
+			ev.Title,
+			formatTime(ev, ev.Date.AsTime()),
+			formatTime(ev, ev.EndTime.AsTime()),
+			ev.User,
+			strings.Join(ev.Labels, ","),
+		)
+	}
+	return w.Flush()
+}
+
+// formatTime shows t in the event's own time zone, and only the date for all-day events.
+func formatTime(ev *proto.Event, t time.Time) string {
+	if loc, err := time.LoadLocation(ev.TimeZone); err == nil && ev.TimeZone != "" {
+		t = t.In(loc)
+	}
+	if ev.AllDay {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format("2006-01-02 15:04 MST")
+}
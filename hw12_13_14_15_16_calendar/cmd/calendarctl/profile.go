@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"gopkg.in/yaml.v3"
+)
+
+const defaultTimeout = 10 * time.Second
+
+// profiles is the file keeping the servers calendarctl knows about.
+type profiles struct {
+	Current  string              `yaml:"current"`
+	Profiles map[string]*profile `yaml:"profiles"`
+}
+
+type profile struct {
+	Address string        `yaml:"address"`
+	Timeout time.Duration `yaml:"timeout,omitempty"`
+	TLS     bool          `yaml:"tls,omitempty"`
+	// CAFile verifies the server; empty uses the system roots.
+	CAFile string `yaml:"ca_file,omitempty"`
+	// CertFile and KeyFile are presented when the server requires client certificates.
+	CertFile   string `yaml:"cert_file,omitempty"`
+	KeyFile    string `yaml:"key_file,omitempty"`
+	ServerName string `yaml:"server_name,omitempty"`
+}
+
+func defaultConfigPath() string {
+	if path := os.Getenv("CALENDARCTL_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "calendarctl.yaml"
+	}
+	return filepath.Join(dir, "calendarctl", "config.yaml")
+}
+
+// readProfiles returns an empty set when the file does not exist yet.
+func readProfiles(path string) (*profiles, error) {
+	ps := &profiles{Profiles: map[string]*profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return ps, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read profiles: %w", err)
+	}
+	if err := yaml.Unmarshal(data, ps); err != nil {
+		return nil, fmt.Errorf("parse profiles %s: %w", path, err)
+	}
+	if ps.Profiles == nil {
+		ps.Profiles = map[string]*profile{}
+	}
+	return ps, nil
+}
+
+func (ps *profiles) write(path string) error {
+	data, err := yaml.Marshal(ps)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("write profiles: %w", err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// loadProfile picks name, or the current profile when name is empty. Without any profiles
+// it talks to a calendar on localhost.
+func loadProfile(path, name string) (*profile, error) {
+	ps, err := readProfiles(path)
+	if err != nil {
+		return nil, err
+	}
+	if name == "" {
+		name = ps.Current
+	}
+	if name == "" && len(ps.Profiles) == 0 {
+		return &profile{Address: "localhost:8081", Timeout: defaultTimeout}, nil
+	}
+
+	p, ok := ps.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q is not defined in %s", name, path)
+	}
+	if p.Timeout == 0 {
+		p.Timeout = defaultTimeout
+	}
+	return p, nil
+}
+
+func runProfile(path string, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("profile: expected list, use or set")
+	}
+
+	ps, err := readProfiles(path)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		names := make([]string, 0, len(ps.Profiles))
+		for name := range ps.Profiles {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			mark := " "
+			if name == ps.Current {
+				mark = "*"
+			}
+			fmt.Fprintf(out, "%s %s\t%s\n", mark, name, ps.Profiles[name].Address)
+		}
+		return nil
+
+	case "use":
+		if len(args) != 2 {
+			return errors.New("profile use: expected a profile name")
+		}
+		if _, ok := ps.Profiles[args[1]]; !ok {
+			return fmt.Errorf("profile %q is not defined", args[1])
+		}
+		ps.Current = args[1]
+		return ps.write(path)
+
+	case "set":
+		if len(args) < 2 {
+			return errors.New("profile set: expected a profile name")
+		}
+		name := args[1]
+		p, ok := ps.Profiles[name]
+		if !ok {
+			p = &profile{}
+			ps.Profiles[name] = p
+		}
+
+		f := flag.NewFlagSet("profile set", flag.ContinueOnError)
+		f.StringVar(&p.Address, "address", p.Address, "gRPC address, host:port")
+		f.DurationVar(&p.Timeout, "timeout", p.Timeout, "timeout of every call")
+		f.BoolVar(&p.TLS, "tls", p.TLS, "connect over TLS")
+		f.StringVar(&p.CAFile, "ca-file", p.CAFile, "CA bundle verifying the server")
+		f.StringVar(&p.CertFile, "cert-file", p.CertFile, "client certificate for mutual TLS")
+		f.StringVar(&p.KeyFile, "key-file", p.KeyFile, "client key for mutual TLS")
+		f.StringVar(&p.ServerName, "server-name", p.ServerName, "name expected in the server certificate")
+		if err := f.Parse(args[2:]); err != nil {
+			return err
+		}
+		if p.Address == "" {
+			return errors.New("profile set: -address is required")
+		}
+		if ps.Current == "" {
+			ps.Current = name
+		}
+		return ps.write(path)
+	}
+	return fmt.Errorf("profile: unknown subcommand %q", args[0])
+}
+
+func dial(p *profile) (*grpc.ClientConn, error) {
+	creds := insecure.NewCredentials()
+	if p.TLS {
+		tc, err := p.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		creds = credentials.NewTLS(tc)
+	}
+
+	conn, err := grpc.NewClient(p.Address,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(timeoutInterceptor(p.Timeout)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", p.Address, err)
+	}
+	return conn, nil
+}
+
+func (p *profile) tlsConfig() (*tls.Config, error) {
+	tc := &tls.Config{MinVersion: tls.VersionTLS12, ServerName: p.ServerName}
+	if p.CAFile != "" {
+		pemData, err := os.ReadFile(p.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read CA file: %w", err)
+		}
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(pemData) {
+			return nil, errors.New("no certificates found in " + p.CAFile)
+		}
+	}
+	if p.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(p.CertFile, p.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load client certificate: %w", err)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+	return tc, nil
+}
+
+// timeoutInterceptor bounds every call on its own, so long imports are not cut off as a whole.
+func timeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// importBatchSize is the largest batch the server accepts.
+const importBatchSize = 100
+
+// runExport writes the selected events as JSON lines, one event per line.
+func runExport(ctx context.Context, e *env, args []string) error {
+	var lf listFlags
+	var file string
+	f := flag.NewFlagSet("export", flag.ContinueOnError)
+	f.SetOutput(e.stderr)
+	lf.register(f)
+	f.StringVar(&file, "f", "", "output file, stdout when empty")
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+
+	res, err := e.client.GetEventList(ctx, lf.request())
+	if err != nil {
+		return err
+	}
+
+	out := e.printer.out
+	if file != "" {
+		fh, err := os.Create(file)
+		if err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+		defer fh.Close()
+		out = fh
+	}
+
+	w := bufio.NewWriter(out)
+	if err := exportEvents(w, res.Data); err != nil {
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("export: %w", err)
+	}
+	fmt.Fprintf(e.stderr, "exported %d events\n", len(res.Data))
+	return nil
+}
+
+func exportEvents(w io.Writer, events []*proto.Event) error {
+	for _, ev := range events {
+		line, err := jsonOptions.Marshal(ev)
+		if err != nil {
+			return fmt.Errorf("export event %s: %w", ev.Id, err)
+		}
+		if _, err := fmt.Fprintf(w, "%s\n", line); err != nil {
+			return fmt.Errorf("export: %w", err)
+		}
+	}
+	return nil
+}
+
+// importLine is an event read from the input with the line it came from.
+type importLine struct {
+	no  int
+	req *proto.CreateEventReq
+}
+
+// runImport creates the events of a JSON lines file as produced by export. The events get new IDs
+// and attendees are invited again, so their responses are not carried over.
+func runImport(ctx context.Context, e *env, args []string) error {
+	var file string
+	f := flag.NewFlagSet("import", flag.ContinueOnError)
+	f.SetOutput(e.stderr)
+	f.StringVar(&file, "f", "", "input file, stdin when empty")
+	if err := f.Parse(args); err != nil {
+		return err
+	}
+
+	in := e.stdin
+	if file != "" {
+		fh, err := os.Open(file)
+		if err != nil {
+			return fmt.Errorf("import: %w", err)
+		}
+		defer fh.Close()
+		in = fh
+	}
+
+	lines, failed, err := readImport(in, e.stderr)
+	if err != nil {
+		return err
+	}
+
+	created := 0
+	for start := 0; start < len(lines); start += importBatchSize {
+		batch := lines[start:min(start+importBatchSize, len(lines))]
+		items := make([]*proto.CreateEventReq, len(batch))
+		for i, l := range batch {
+			items[i] = l.req
+		}
+
+		res, err := e.client.BatchCreateEvents(ctx, &proto.BatchCreateEventsReq{
+			Items: items,
+			Mode:  proto.BatchMode_BATCH_MODE_BEST_EFFORT,
+		})
+		if err != nil {
+			return fmt.Errorf("import lines %d-%d: %w", batch[0].no, batch[len(batch)-1].no, err)
+		}
+		for i, r := range res.Results {
+			if st := r.GetStatus(); st != nil && codes.Code(st.Code) != codes.OK {
+				fmt.Fprintf(e.stderr, "line %d: %s\n", batch[i].no, st.Message)
+				failed++
+				continue
+			}
+			created++
+		}
+	}
+
+	fmt.Fprintf(e.stderr, "imported %d events, %d failed\n", created, failed)
+	if failed > 0 {
+		return fmt.Errorf("import: %d events failed", failed)
+	}
+	return nil
+}
+
+// readImport parses every line, reporting the ones that are not events instead of stopping.
+func readImport(in io.Reader, stderr io.Writer) ([]importLine, int, error) {
+	var lines []importLine
+	failed := 0
+	sc := bufio.NewScanner(in)
+	sc.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for no := 1; sc.Scan(); no++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" {
+			continue
+		}
+		var ev proto.Event
+		if err := protojson.Unmarshal([]byte(text), &ev); err != nil {
+			fmt.Fprintf(stderr, "line %d: %v\n", no, err)
+			failed++
+			continue
+		}
+		lines = append(lines, importLine{no: no, req: createRequest(&ev)})
+	}
+	if err := sc.Err(); err != nil {
+		return nil, 0, fmt.Errorf("import: %w", err)
+	}
+	if len(lines) == 0 && failed == 0 {
+		return nil, 0, errors.New("import: no events in the input")
+	}
+	return lines, failed, nil
+}
+
+func createRequest(ev *proto.Event) *proto.CreateEventReq {
+	req := &proto.CreateEventReq{
+		Title:        ev.Title,
+		Date:         ev.Date,
+		EndTime:      ev.EndTime,
+		Description:  ev.Description,
+		User:         ev.User,
+		NotifyBefore: ev.NotifyBefore,
+		CalendarId:   ev.CalendarId,
+		AllDay:       ev.AllDay,
+		Labels:       ev.Labels,
+		Location:     ev.Location,
+		MeetingUrl:   ev.MeetingUrl,
+	}
+	if ev.TimeZone != "" {
+		req.TimeZone = &ev.TimeZone
+	}
+	for _, a := range ev.Attendees {
+		req.Attendees = append(req.Attendees, a.User)
+	}
+	return req
+}
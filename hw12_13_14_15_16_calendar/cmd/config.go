@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+)
+
+// IsConfigPrint reports whether the binary was started as `<binary> config print`.
+func IsConfigPrint() bool {
+	return flag.Arg(0) == "config" && flag.Arg(1) == "print"
+}
+
+func PrintConfig(cfg any) {
+	if err := configuration.Print(os.Stdout, cfg); err != nil {
+		fmt.Printf("error while print config: %v\n", err)
+	}
+}
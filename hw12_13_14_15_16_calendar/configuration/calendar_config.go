@@ -1,62 +1,376 @@
 package configuration
 
 import (
-	"github.com/caarlos0/env/v10"
-	"github.com/spf13/viper"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+)
+
+var schemeName = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+
+// How the HTTP gateway reaches the application: by dialing the local gRPC port or by calling it directly.
+const (
+	GatewayLoopback  = "loopback"
+	GatewayInProcess = "in_process"
+)
+
+// Where attachment content is kept.
+const (
+	AttachmentsDisk = "disk"
+	AttachmentsS3   = "s3"
 )
 
 type Config struct {
 	Logger LoggerConf `mapstructure:"logger"`
 	System struct {
 		HTTP struct {
-			Address      string `mapstructure:"address" env:"HTTP_ADDRESS"`
-			WriteTimeout int    `mapstructure:"write_timeout" env:"HTTP_WRITE_TIMEOUT"`
-			ReadTimeout  int    `mapstructure:"read_timeout" env:"HTTP_READ_TIMEOUT"`
+			Address      string `mapstructure:"address" env:"HTTP_ADDRESS" default:"0.0.0.0:8080"`
+			WriteTimeout int    `mapstructure:"write_timeout" env:"HTTP_WRITE_TIMEOUT" default:"15"`
+			ReadTimeout  int    `mapstructure:"read_timeout" env:"HTTP_READ_TIMEOUT" default:"15"`
+			// Gateway is either GatewayLoopback or GatewayInProcess.
+			Gateway string `mapstructure:"gateway" env:"HTTP_GATEWAY" default:"loopback"`
+			// CalDAV serves the events of every user to CalDAV clients under /caldav/.
+			CalDAV bool `mapstructure:"caldav" env:"HTTP_CALDAV" default:"false"`
 		} `mapstructure:"http"`
 		Database DatabaseConf `mapstructure:"database"`
 		Grpc     struct {
-			Port              uint16 `mapstructure:"port" env:"GRPC_PORT"`
-			ConnectionTimeout int    `mapstructure:"connection_timeout" env:"GRPC_CONNECTION_TIMEOUT"`
+			Port              uint16 `mapstructure:"port" env:"GRPC_PORT" default:"8081"`
+			ConnectionTimeout int    `mapstructure:"connection_timeout" env:"GRPC_CONNECTION_TIMEOUT" default:"10"`
 		} `mapstructure:"grpc"`
+		RateLimit   RateLimitConf   `mapstructure:"rate_limit"`
+		TLS         TLSConf         `mapstructure:"tls"`
+		Attachments AttachmentsConf `mapstructure:"attachments"`
 	} `mapstructure:"system"`
 }
 
+// TLSConf secures both listeners and the gateway's own connection to the gRPC port.
+type TLSConf struct {
+	Enable   bool   `mapstructure:"enable" env:"TLS_ENABLE" default:"false"`
+	CertFile string `mapstructure:"cert_file" env:"TLS_CERT_FILE"`
+	KeyFile  string `mapstructure:"key_file" env:"TLS_KEY_FILE"`
+	// CAFile verifies the server certificate on the gateway connection; empty uses the system roots.
+	CAFile string `mapstructure:"ca_file" env:"TLS_CA_FILE"`
+	// ClientCAFile makes the gRPC port require client certificates signed by it.
+	ClientCAFile string `mapstructure:"client_ca_file" env:"TLS_CLIENT_CA_FILE"`
+	// ServerName is the name the gateway expects in the server certificate.
+	ServerName     string        `mapstructure:"server_name" env:"TLS_SERVER_NAME" default:"localhost"`
+	ReloadInterval time.Duration `mapstructure:"reload_interval" env:"TLS_RELOAD_INTERVAL" default:"1m"`
+}
+
+// RateLimitConf sets the token buckets shared by the HTTP and gRPC servers. A zero rate disables that limit.
+type RateLimitConf struct {
+	Enable      bool          `mapstructure:"enable" env:"RATE_LIMIT_ENABLE" default:"false"`
+	PerIP       LimitConf     `mapstructure:"per_ip"`
+	PerUser     LimitConf     `mapstructure:"per_user"`
+	UserHeader  string        `mapstructure:"user_header" env:"RATE_LIMIT_USER_HEADER" default:"X-User-Id"`
+	IdleTimeout time.Duration `mapstructure:"idle_timeout" env:"RATE_LIMIT_IDLE_TIMEOUT" default:"10m"`
+	// TrustedProxies are the addresses or CIDR ranges of the reverse proxies in front of the service,
+	// the only peers whose X-Forwarded-For is believed.
+	TrustedProxies []string `mapstructure:"trusted_proxies" env:"RATE_LIMIT_TRUSTED_PROXIES"`
+}
+
+// LimitConf allows Rate requests per second on average and bursts of up to Burst requests.
+type LimitConf struct {
+	Rate  float64 `mapstructure:"rate"`
+	Burst int     `mapstructure:"burst"`
+}
+
+// AttachmentsConf lets users attach files to events. The records live in the storage, the content
+// in a directory or an S3 bucket.
+type AttachmentsConf struct {
+	Enable bool `mapstructure:"enable" env:"ATTACHMENTS_ENABLE" default:"false"`
+	// Backend is either AttachmentsDisk or AttachmentsS3.
+	Backend string `mapstructure:"backend" env:"ATTACHMENTS_BACKEND" default:"disk"`
+	Dir     string `mapstructure:"dir" env:"ATTACHMENTS_DIR" default:"./attachments"`
+	// MaxSize is the largest file accepted, in bytes.
+	MaxSize     int64 `mapstructure:"max_size" env:"ATTACHMENTS_MAX_SIZE" default:"10485760"`
+	MaxPerEvent int   `mapstructure:"max_per_event" env:"ATTACHMENTS_MAX_PER_EVENT" default:"20"`
+	// SweepInterval is how often the content of removed attachments is deleted from the store.
+	SweepInterval time.Duration `mapstructure:"sweep_interval" env:"ATTACHMENTS_SWEEP_INTERVAL" default:"1m"`
+	S3            S3Conf        `mapstructure:"s3"`
+}
+
+// S3Conf points at a bucket of any S3-compatible service.
+type S3Conf struct {
+	// Endpoint is the base URL of the service, such as https://s3.eu-central-1.amazonaws.com.
+	Endpoint  string `mapstructure:"endpoint" env:"ATTACHMENTS_S3_ENDPOINT"`
+	Region    string `mapstructure:"region" env:"ATTACHMENTS_S3_REGION" default:"us-east-1"`
+	Bucket    string `mapstructure:"bucket" env:"ATTACHMENTS_S3_BUCKET"`
+	AccessKey string `mapstructure:"access_key" env:"ATTACHMENTS_S3_ACCESS_KEY"`
+	SecretKey string `mapstructure:"secret_key" env:"ATTACHMENTS_S3_SECRET_KEY" secret:"true"`
+	// PathStyle puts the bucket in the path rather than in the host name, as MinIO expects.
+	PathStyle bool `mapstructure:"path_style" env:"ATTACHMENTS_S3_PATH_STYLE" default:"false"`
+}
+
 type DatabaseConf struct {
-	Enable   bool   `mapstructure:"enable" env:"DB_ENABLE"`
-	Host     string `mapstructure:"host" env:"DB_HOST"`
-	Port     int    `mapstructure:"port" env:"DB_PORT"`
-	DBName   string `mapstructure:"db_name" env:"DB_NAME"`
-	Scheme   string `mapstructure:"scheme" env:"DB_SCHEME"`
-	User     string `mapstructure:"user" env:"DB_USER"`
-	Password string `mapstructure:"password" env:"DB_PASSWORD"`
-	Timeout  int    `mapstructure:"timeout" env:"DB_TIMEOUT"`
-	SSLMode  string `mapstructure:"ssl_mode" env:"DB_SSL_MODE"`
+	Enable   bool   `mapstructure:"enable" env:"DB_ENABLE" default:"false"`
+	Host     string `mapstructure:"host" env:"DB_HOST" default:"localhost"`
+	Port     int    `mapstructure:"port" env:"DB_PORT" default:"5432"`
+	DBName   string `mapstructure:"db_name" env:"DB_NAME" default:"postgres"`
+	Scheme   string `mapstructure:"scheme" env:"DB_SCHEME" default:"calendar"`
+	User     string `mapstructure:"user" env:"DB_USER" default:"postgres"`
+	Password string `mapstructure:"password" env:"DB_PASSWORD" secret:"true"`
+	Timeout  int    `mapstructure:"timeout" env:"DB_TIMEOUT" default:"5"`
+	SSLMode  string `mapstructure:"ssl_mode" env:"DB_SSL_MODE" default:"disable"`
+
+	AutoMigrate bool       `mapstructure:"auto_migrate" env:"DB_AUTO_MIGRATE" default:"false"`
+	Pool        DBPoolConf `mapstructure:"pool"`
+}
+
+// DBPoolConf tunes the pgx pool; zero values keep the pgx defaults.
+type DBPoolConf struct {
+	MaxConns          int32         `mapstructure:"max_conns" env:"DB_POOL_MAX_CONNS" default:"0"`
+	MinConns          int32         `mapstructure:"min_conns" env:"DB_POOL_MIN_CONNS" default:"0"`
+	MaxConnIdleTime   time.Duration `mapstructure:"max_conn_idle_time" env:"DB_POOL_MAX_CONN_IDLE_TIME" default:"30m"`
+	MaxConnLifetime   time.Duration `mapstructure:"max_conn_lifetime" env:"DB_POOL_MAX_CONN_LIFETIME" default:"1h"`
+	HealthCheckPeriod time.Duration `mapstructure:"health_check_period" env:"DB_POOL_HEALTH_CHECK_PERIOD" default:"1m"`
 }
 
 type LoggerConf struct {
-	Level string `mapstructure:"level" env:"LOG_LEVEL" envDefault:"debug"`
+	Level string `mapstructure:"level" env:"LOG_LEVEL" default:"info"`
 }
 
-func LoadConfig(configPath string) (*Config, error) {
+func LoadConfig(configPath string, overrides Overrides) (*Config, error) {
 	var cfg Config
-
-	viper.SetConfigFile(configPath)
-	if err := viper.ReadInConfig(); err != nil {
+	if err := Load(configPath, overrides, &cfg); err != nil {
 		return nil, err
 	}
-	if err := viper.Unmarshal(&cfg); err != nil {
-		return nil, err
+	return &cfg, nil
+}
+
+// Validate reports every invalid setting at once, so a broken file can be fixed in one pass.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if err := c.Logger.Validate(); err != nil {
+		errs = append(errs, err)
 	}
 
-	if err := env.Parse(&cfg.System.HTTP); err != nil {
-		return nil, err
+	httpPort, err := addressPort(c.System.HTTP.Address)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("system.http.address: %w", err))
 	}
-	if err := env.Parse(&cfg.System.Grpc); err != nil {
-		return nil, err
+	if c.System.HTTP.ReadTimeout < 0 {
+		errs = append(errs, fmt.Errorf("system.http.read_timeout: must not be negative, got %d", c.System.HTTP.ReadTimeout))
 	}
-	if err := env.Parse(&cfg.System.Database); err != nil {
-		return nil, err
+	if c.System.HTTP.WriteTimeout < 0 {
+		errs = append(errs, fmt.Errorf("system.http.write_timeout: must not be negative, got %d", c.System.HTTP.WriteTimeout))
+	}
+	if g := c.System.HTTP.Gateway; g != GatewayLoopback && g != GatewayInProcess {
+		errs = append(errs, fmt.Errorf("system.http.gateway: must be %q or %q, got %q", GatewayLoopback, GatewayInProcess, g))
 	}
 
-	return &cfg, nil
+	if c.System.Grpc.Port == 0 {
+		errs = append(errs, errors.New("system.grpc.port: must be set"))
+	} else if int(c.System.Grpc.Port) == httpPort {
+		errs = append(errs, fmt.Errorf("system.grpc.port: %d is already used by system.http.address", httpPort))
+	}
+	if c.System.Grpc.ConnectionTimeout < 0 {
+		errs = append(errs, fmt.Errorf("system.grpc.connection_timeout: must not be negative, got %d",
+			c.System.Grpc.ConnectionTimeout))
+	}
+
+	if err := c.System.Database.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.System.RateLimit.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.System.TLS.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.System.Attachments.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+// RestartRequired lists the settings that differ in next but can only be applied by restarting the service.
+func (c *Config) RestartRequired(next *Config) []string {
+	var keys []string
+	if c.System.HTTP != next.System.HTTP {
+		keys = append(keys, "system.http")
+	}
+	if c.System.Grpc != next.System.Grpc {
+		keys = append(keys, "system.grpc")
+	}
+	if c.System.Database != next.System.Database {
+		keys = append(keys, "system.database")
+	}
+	if !reflect.DeepEqual(c.System.RateLimit, next.System.RateLimit) {
+		keys = append(keys, "system.rate_limit")
+	}
+	if c.System.TLS != next.System.TLS {
+		keys = append(keys, "system.tls")
+	}
+	if c.System.Attachments != next.System.Attachments {
+		keys = append(keys, "system.attachments")
+	}
+	return keys
+}
+
+func (c *LoggerConf) Validate() error {
+	if _, err := logger.ParseLevel(c.Level); err != nil {
+		return fmt.Errorf("logger.level: %w", err)
+	}
+	return nil
+}
+
+func (c *DatabaseConf) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	var errs []error
+	if c.Host == "" {
+		errs = append(errs, errors.New("system.database.host: must be set"))
+	}
+	if c.Port <= 0 || c.Port > 65535 {
+		errs = append(errs, fmt.Errorf("system.database.port: must be in range 1-65535, got %d", c.Port))
+	}
+	if c.DBName == "" {
+		errs = append(errs, errors.New("system.database.db_name: must be set"))
+	}
+	if !schemeName.MatchString(c.Scheme) {
+		errs = append(errs, fmt.Errorf("system.database.scheme: %q is not a valid schema name", c.Scheme))
+	}
+	if c.Timeout < 0 {
+		errs = append(errs, fmt.Errorf("system.database.timeout: must not be negative, got %d", c.Timeout))
+	}
+	if c.Pool.MaxConns < 0 || c.Pool.MinConns < 0 {
+		errs = append(errs, errors.New("system.database.pool: connection limits must not be negative"))
+	}
+	if c.Pool.MaxConns > 0 && c.Pool.MinConns > c.Pool.MaxConns {
+		errs = append(errs, fmt.Errorf("system.database.pool.min_conns: %d exceeds max_conns %d",
+			c.Pool.MinConns, c.Pool.MaxConns))
+	}
+	if c.Pool.MaxConnIdleTime < 0 || c.Pool.MaxConnLifetime < 0 || c.Pool.HealthCheckPeriod < 0 {
+		errs = append(errs, errors.New("system.database.pool: durations must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+func (c *RateLimitConf) Validate() error {
+	// The proxies also decide the client address in the request logs, so they are checked either way.
+	_, err := c.Proxies()
+	if !c.Enable {
+		return err
+	}
+
+	errs := []error{err}
+	for _, l := range []struct {
+		name string
+		LimitConf
+	}{{"per_ip", c.PerIP}, {"per_user", c.PerUser}} {
+		if l.Rate < 0 {
+			errs = append(errs, fmt.Errorf("system.rate_limit.%s.rate: must not be negative, got %g", l.name, l.Rate))
+		}
+		if l.Rate > 0 && l.Burst < 1 {
+			errs = append(errs, fmt.Errorf("system.rate_limit.%s.burst: must be at least 1, got %d", l.name, l.Burst))
+		}
+	}
+	if c.PerUser.Rate > 0 && c.UserHeader == "" {
+		errs = append(errs, errors.New("system.rate_limit.user_header: must be set to limit users"))
+	}
+	if c.IdleTimeout < 0 {
+		errs = append(errs, fmt.Errorf("system.rate_limit.idle_timeout: must not be negative, got %s", c.IdleTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+// Proxies parses TrustedProxies; a bare address stands for a range of one.
+func (c *RateLimitConf) Proxies() ([]netip.Prefix, error) {
+	res := make([]netip.Prefix, 0, len(c.TrustedProxies))
+	for _, s := range c.TrustedProxies {
+		if prefix, err := netip.ParsePrefix(s); err == nil {
+			res = append(res, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("system.rate_limit.trusted_proxies: %q is neither an address nor a CIDR range", s)
+		}
+		addr = addr.Unmap()
+		res = append(res, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return res, nil
+}
+
+func (c *TLSConf) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	var errs []error
+	if c.CertFile == "" {
+		errs = append(errs, errors.New("system.tls.cert_file: must be set"))
+	}
+	if c.KeyFile == "" {
+		errs = append(errs, errors.New("system.tls.key_file: must be set"))
+	}
+	if c.ReloadInterval < 0 {
+		errs = append(errs, fmt.Errorf("system.tls.reload_interval: must not be negative, got %s", c.ReloadInterval))
+	}
+	return errors.Join(errs...)
+}
+
+func (c *AttachmentsConf) Validate() error {
+	if !c.Enable {
+		return nil
+	}
+
+	var errs []error
+	if c.MaxSize <= 0 {
+		errs = append(errs, fmt.Errorf("system.attachments.max_size: must be positive, got %d", c.MaxSize))
+	}
+	if c.MaxPerEvent < 0 {
+		errs = append(errs, fmt.Errorf("system.attachments.max_per_event: must not be negative, got %d", c.MaxPerEvent))
+	}
+	if c.SweepInterval <= 0 {
+		errs = append(errs, fmt.Errorf("system.attachments.sweep_interval: must be positive, got %v", c.SweepInterval))
+	}
+	switch c.Backend {
+	case AttachmentsDisk:
+		if c.Dir == "" {
+			errs = append(errs, errors.New("system.attachments.dir: must be set"))
+		}
+	case AttachmentsS3:
+		if _, err := url.ParseRequestURI(c.S3.Endpoint); err != nil || c.S3.Endpoint == "" {
+			errs = append(errs, fmt.Errorf("system.attachments.s3.endpoint: must be a URL, got %q", c.S3.Endpoint))
+		}
+		if c.S3.Region == "" {
+			errs = append(errs, errors.New("system.attachments.s3.region: must be set"))
+		}
+		if c.S3.Bucket == "" {
+			errs = append(errs, errors.New("system.attachments.s3.bucket: must be set"))
+		}
+		if c.S3.AccessKey == "" || c.S3.SecretKey == "" {
+			errs = append(errs, errors.New("system.attachments.s3: access_key and secret_key must be set"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("system.attachments.backend: must be %q or %q, got %q",
+			AttachmentsDisk, AttachmentsS3, c.Backend))
+	}
+	return errors.Join(errs...)
+}
+
+func addressPort(address string) (int, error) {
+	_, portStr, err := net.SplitHostPort(address)
+	if err != nil {
+		return 0, err
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port <= 0 || port > 65535 {
+		return 0, fmt.Errorf("port must be in range 1-65535, got %q", portStr)
+	}
+	return port, nil
 }
@@ -0,0 +1,104 @@
+package configuration
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigSample(t *testing.T) {
+	cfg, err := LoadConfig("../configs/calendar_config.yaml", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", cfg.Logger.Level)
+	assert.Equal(t, uint16(8081), cfg.System.Grpc.Port)
+}
+
+func TestLoadConfigRejectsInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "calendar_config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+logger:
+  level: trace
+system:
+  http:
+    address: "0.0.0.0:8081"
+    read_timeout: -1
+  grpc:
+    port: 8081
+  database:
+    enable: true
+    host: ""
+    port: 0
+    db_name: ""
+`), 0o600))
+
+	_, err := LoadConfig(path, nil)
+	require.Error(t, err)
+	for _, key := range []string{
+		"logger.level",
+		"system.http.read_timeout",
+		"system.grpc.port",
+		"system.database.host",
+		"system.database.port",
+		"system.database.db_name",
+	} {
+		assert.Contains(t, err.Error(), key)
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	cfg, err := LoadConfig("../configs/calendar_config.yaml", nil)
+	require.NoError(t, err)
+
+	next := *cfg
+	next.Logger.Level = "error"
+	assert.Empty(t, cfg.RestartRequired(&next))
+
+	next.System.Grpc.Port = 9090
+	next.System.Database.Timeout = 1
+	assert.Equal(t, []string{"system.grpc", "system.database"}, cfg.RestartRequired(&next))
+}
+
+func TestRateLimitValidate(t *testing.T) {
+	cfg := RateLimitConf{
+		Enable:  true,
+		PerIP:   LimitConf{Rate: -1},
+		PerUser: LimitConf{Rate: 5},
+	}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "system.rate_limit.per_ip.rate")
+	assert.Contains(t, err.Error(), "system.rate_limit.per_user.burst")
+	assert.Contains(t, err.Error(), "system.rate_limit.user_header")
+
+	cfg.Enable = false
+	assert.NoError(t, cfg.Validate())
+
+	cfg.TrustedProxies = []string{"10.0.0.0/8", "192.0.2.1", "proxy.local"}
+	assert.ErrorContains(t, cfg.Validate(), `system.rate_limit.trusted_proxies: "proxy.local"`)
+}
+
+func TestAttachmentsValidate(t *testing.T) {
+	cfg := AttachmentsConf{Enable: true, Backend: AttachmentsS3, MaxSize: 0, MaxPerEvent: -1}
+	err := cfg.Validate()
+	require.Error(t, err)
+	for _, key := range []string{
+		"system.attachments.max_size",
+		"system.attachments.max_per_event",
+		"system.attachments.sweep_interval",
+		"system.attachments.s3.endpoint",
+		"system.attachments.s3.bucket",
+		"system.attachments.s3: access_key",
+	} {
+		assert.Contains(t, err.Error(), key)
+	}
+
+	cfg = AttachmentsConf{Enable: true, Backend: "ftp", MaxSize: 1}
+	assert.ErrorContains(t, cfg.Validate(), "system.attachments.backend")
+
+	cfg = AttachmentsConf{Enable: true, Backend: AttachmentsDisk, Dir: "files", MaxSize: 1, SweepInterval: time.Minute}
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,205 @@
+package configuration
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// Every binary resolves its configuration in the same order, later sources winning:
+//
+//  1. defaults from the `default` struct tags;
+//  2. the YAML config file;
+//  3. environment variables named by the `env` struct tags;
+//  4. -set key=value command-line overrides.
+//
+// Fields tagged `secret:"true"` are fully masked by Print, fields tagged `secret:"url"`
+// only have the password of the URL redacted.
+
+const maskedValue = "******"
+
+type Validator interface {
+	Validate() error
+}
+
+// Overrides collects repeated -set key=value flags. Keys use the dotted YAML path, e.g. logger.level.
+type Overrides map[string]string
+
+func (o Overrides) String() string {
+	pairs := make([]string, 0, len(o))
+	for k, v := range o {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (o Overrides) Set(value string) error {
+	key, val, ok := strings.Cut(value, "=")
+	if !ok || key == "" {
+		return fmt.Errorf("override %q must look like key=value", value)
+	}
+	o[strings.ToLower(key)] = val
+	return nil
+}
+
+// Load fills cfg, a pointer to a configuration struct, from all sources and validates the result.
+// An empty configPath skips the file, leaving defaults, environment and overrides.
+func Load(configPath string, overrides Overrides, cfg Validator) error {
+	v := viper.New()
+
+	for _, f := range collectFields(reflect.TypeOf(cfg).Elem(), "") {
+		if f.def != "" {
+			v.SetDefault(f.key, f.def)
+		}
+		if f.env != "" {
+			if err := v.BindEnv(f.key, f.env); err != nil {
+				return fmt.Errorf("bind env %s: %w", f.env, err)
+			}
+		}
+	}
+
+	if configPath != "" {
+		v.SetConfigFile(configPath)
+		v.SetConfigType("yaml")
+		if err := v.ReadInConfig(); err != nil {
+			return fmt.Errorf("read config %s: %w", configPath, err)
+		}
+	}
+
+	for key, val := range overrides {
+		v.Set(key, val)
+	}
+
+	if err := v.Unmarshal(cfg); err != nil {
+		return fmt.Errorf("decode config: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+	return nil
+}
+
+// Print writes the effective configuration as YAML with secrets masked.
+// Each value is annotated with the environment variable that overrides it.
+func Print(w io.Writer, cfg any) error {
+	doc := &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{toNode(reflect.ValueOf(cfg).Elem())}}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("encode config: %w", err)
+	}
+	return enc.Close()
+}
+
+type field struct {
+	key string
+	env string
+	def string
+}
+
+func collectFields(t reflect.Type, prefix string) []field {
+	var res []field
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if sf.Anonymous {
+			res = append(res, collectFields(sf.Type, prefix)...)
+			continue
+		}
+		key := prefix + sf.Tag.Get("mapstructure")
+		if sf.Type.Kind() == reflect.Struct {
+			res = append(res, collectFields(sf.Type, key+".")...)
+			continue
+		}
+		res = append(res, field{key: key, env: sf.Tag.Get("env"), def: sf.Tag.Get("default")})
+	}
+	return res
+}
+
+func toNode(v reflect.Value) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.MappingNode}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		fv := v.Field(i)
+		if sf.Anonymous {
+			node.Content = append(node.Content, toNode(fv).Content...)
+			continue
+		}
+		key := &yaml.Node{Kind: yaml.ScalarNode, Value: sf.Tag.Get("mapstructure")}
+
+		var val *yaml.Node
+		switch {
+		case fv.Kind() == reflect.Struct:
+			val = toNode(fv)
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() == reflect.Struct:
+			val = &yaml.Node{Kind: yaml.SequenceNode}
+			for j := 0; j < fv.Len(); j++ {
+				val.Content = append(val.Content, toNode(fv.Index(j)))
+			}
+		case fv.Kind() == reflect.Map && fv.Type().Elem().Kind() == reflect.Struct:
+			val = &yaml.Node{Kind: yaml.MappingNode}
+			keys := fv.MapKeys()
+			sort.Slice(keys, func(a, b int) bool { return keys[a].String() < keys[b].String() })
+			for _, k := range keys {
+				val.Content = append(val.Content,
+					&yaml.Node{Kind: yaml.ScalarNode, Value: k.String()}, toNode(fv.MapIndex(k)))
+			}
+		default:
+			val = scalarNode(fv, sf.Tag.Get("secret"))
+			if env := sf.Tag.Get("env"); env != "" {
+				val.LineComment = "$" + env
+			}
+		}
+		node.Content = append(node.Content, key, val)
+	}
+	return node
+}
+
+func scalarNode(v reflect.Value, secret string) *yaml.Node {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+		}
+		v = v.Elem()
+	}
+
+	var value string
+	switch x := v.Interface().(type) {
+	case time.Duration:
+		value = x.String()
+	default:
+		value = fmt.Sprint(x)
+	}
+
+	switch {
+	case value == "":
+	case secret == "true":
+		value = maskedValue
+	case secret == "url":
+		value = maskURL(value)
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode, Value: value}
+	if v.Kind() == reflect.String {
+		node.Style = yaml.DoubleQuotedStyle
+	}
+	return node
+}
+
+func maskURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return maskedValue
+	}
+	return u.Redacted()
+}
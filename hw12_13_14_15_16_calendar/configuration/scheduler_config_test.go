@@ -0,0 +1,129 @@
+package configuration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchedulerValidate(t *testing.T) {
+	cfg, err := LoadSchedulerConfig("../configs/scheduler_config.yaml", nil)
+	require.NoError(t, err)
+	require.Equal(t, SchedulerPoll, cfg.Scheduler.Mode)
+
+	cfg.Scheduler.Mode = "cron"
+	cfg.Scheduler.Horizon = time.Second
+	err = cfg.Validate()
+	require.ErrorContains(t, err, `scheduler.mode: must be "poll" or "timer", got "cron"`)
+	require.ErrorContains(t, err, "scheduler.horizon: must not be shorter than scheduler.interval")
+
+	cfg.Scheduler.Mode = SchedulerTimer
+	cfg.Scheduler.Horizon = time.Hour
+	require.NoError(t, cfg.Validate())
+}
+
+func TestSchedulerValidateLeader(t *testing.T) {
+	cfg, err := LoadSchedulerConfig("../configs/scheduler_config.yaml", nil)
+	require.NoError(t, err)
+	require.Equal(t, 5*time.Second, cfg.Scheduler.Leader.Interval)
+
+	cfg.Scheduler.Leader.Enable = true
+	cfg.Scheduler.Leader.Interval = 0
+	cfg.Scheduler.Leader.LockFile = ""
+	cfg.Scheduler.HealthAddress = "localhost"
+	err = cfg.Validate()
+	require.ErrorContains(t, err, "scheduler.leader.interval: must be positive, got 0s")
+	require.ErrorContains(t, err, "scheduler.health_address: address localhost: missing port in address")
+	require.NotContains(t, err.Error(), "lock_file", "the database holds the lock")
+
+	cfg.System.Database.Enable = false
+	require.ErrorContains(t, cfg.Validate(), "scheduler.leader.lock_file: must be set without a database")
+
+	cfg.Scheduler.Leader.Interval = time.Second
+	cfg.Scheduler.Leader.LockFile = "/tmp/scheduler.lock"
+	cfg.Scheduler.HealthAddress = ":8090"
+	require.NoError(t, cfg.Validate())
+}
+
+func TestSchedulerValidateRetention(t *testing.T) {
+	cfg, err := LoadSchedulerConfig("../configs/scheduler_config.yaml", Overrides{
+		"scheduler.retention.schedule":   "0 25 * * *",
+		"scheduler.retention.batch_size": "0",
+	})
+	require.ErrorContains(t, err, `scheduler.retention.schedule: cron "0 25 * * *": hour: 25 out of range 0-23`)
+	require.ErrorContains(t, err, "scheduler.retention.batch_size: must be positive, got 0")
+	require.Nil(t, cfg)
+
+	cfg, err = LoadSchedulerConfig("../configs/scheduler_config.yaml", nil)
+	require.NoError(t, err)
+	require.Equal(t, 8760*time.Hour, cfg.Scheduler.Retention.Events)
+	require.Empty(t, cfg.Scheduler.Retention.Users)
+
+	archive := true
+	cfg.System.Database.Enable = false
+	cfg.Scheduler.Retention.Users = []UserRetentionConf{
+		{User: "5a1f0c8e-3d4b-4c7a-9e21-0f6b2d8c4a11", Events: time.Hour},
+		{User: "5a1f0c8e-3d4b-4c7a-9e21-0f6b2d8c4a11", Trash: -time.Hour},
+		{User: "bob", Archive: &archive},
+	}
+	err = cfg.Validate()
+	require.ErrorContains(t, err,
+		"scheduler.retention.users[1].user: 5a1f0c8e-3d4b-4c7a-9e21-0f6b2d8c4a11 is listed twice")
+	require.ErrorContains(t, err, "scheduler.retention.users[1].trash: must not be negative, got -1h0m0s")
+	require.ErrorContains(t, err, `scheduler.retention.users[2].user: must be a UUID, got "bob"`)
+	require.ErrorContains(t, err, "scheduler.retention.users[2].archive: needs the database")
+
+	cfg.Scheduler.Retention.Users = cfg.Scheduler.Retention.Users[:1]
+	require.NoError(t, cfg.Validate())
+	cfg.Scheduler.Retention.Archive = true
+	require.ErrorContains(t, cfg.Validate(), "scheduler.retention.archive: needs the database")
+}
+
+func TestSchedulerValidateDigest(t *testing.T) {
+	cfg, err := LoadSchedulerConfig("../configs/scheduler_config.yaml", Overrides{"scheduler.digest.hour": "24"})
+	require.ErrorContains(t, err, "scheduler.digest.hour: must be within 0..23, got 24")
+	require.Nil(t, cfg)
+
+	cfg, err = LoadSchedulerConfig("../configs/scheduler_config.yaml", nil)
+	require.NoError(t, err)
+	require.Equal(t, 7, cfg.Scheduler.Digest.Hour)
+	require.True(t, cfg.Scheduler.Digest.SkipEmpty)
+
+	cfg.Scheduler.Digest.Users = []DigestUserConf{
+		{User: "5a1f0c8e-3d4b-4c7a-9e21-0f6b2d8c4a11", TimeZone: "Europe/Moscow", Locale: "ru"},
+		{User: "5a1f0c8e-3d4b-4c7a-9e21-0f6b2d8c4a11"},
+		{User: "bob", TimeZone: "Mars/Olympus"},
+	}
+	err = cfg.Validate()
+	require.ErrorContains(t, err,
+		"scheduler.digest.users[1].user: 5a1f0c8e-3d4b-4c7a-9e21-0f6b2d8c4a11 is listed twice")
+	require.ErrorContains(t, err, `scheduler.digest.users[2].user: must be a UUID, got "bob"`)
+	require.ErrorContains(t, err, "scheduler.digest.users[2].time_zone: unknown time zone Mars/Olympus")
+
+	cfg.Scheduler.Digest.Users = cfg.Scheduler.Digest.Users[:1]
+	require.NoError(t, cfg.Validate())
+}
+
+func TestSchedulerValidateWebhooks(t *testing.T) {
+	cfg, err := LoadSchedulerConfig("../configs/scheduler_config.yaml", nil)
+	require.NoError(t, err)
+	require.Equal(t, 10*time.Second, cfg.Scheduler.Webhooks.Timeout)
+	require.Equal(t, 8, cfg.Scheduler.Webhooks.MaxAttempts)
+
+	cfg, err = LoadSchedulerConfig("../configs/scheduler_config.yaml", Overrides{
+		"scheduler.webhooks.backoff":      "2h",
+		"scheduler.webhooks.max_attempts": "0",
+	})
+	require.ErrorContains(t, err,
+		"scheduler.webhooks.max_backoff: must not be shorter than scheduler.webhooks.backoff, got 1h0m0s")
+	require.ErrorContains(t, err, "scheduler.webhooks.max_attempts: must be positive, got 0")
+	require.Nil(t, cfg)
+
+	cfg, err = LoadSchedulerConfig("../configs/scheduler_config.yaml", Overrides{
+		"scheduler.webhooks.enable": "true",
+		"system.database.enable":    "false",
+	})
+	require.ErrorContains(t, err, "scheduler.webhooks.enable: needs the database")
+	require.Nil(t, cfg)
+}
@@ -1,42 +1,96 @@
 package configuration
 
 import (
+	"errors"
 	"fmt"
-	"os"
-
-	"github.com/spf13/viper"
+	"maps"
+	"slices"
+	"time"
 )
 
 type SenderConfig struct {
-	Logger struct {
-		Level string `mapstructure:"level"`
-	} `mapstructure:"logger"`
+	Logger LoggerConf `mapstructure:"logger"`
 
 	RabbitMQ struct {
-		URI          string `mapstructure:"uri"`
-		Queue        string `mapstructure:"queue"`
-		ForwardQueue string `mapstructure:"forward_queue"`
+		RabbitMQConf `mapstructure:",squash"`
+		ForwardQueue string `mapstructure:"forward_queue" env:"RABBITMQ_FORWARD_QUEUE" default:"notifications"`
 	} `mapstructure:"rabbitmq"`
+
+	Sender struct {
+		PollInterval time.Duration `mapstructure:"poll_interval" env:"SENDER_POLL_INTERVAL" default:"2s"`
+		Templates    TemplatesConf `mapstructure:"templates"`
+	} `mapstructure:"sender"`
 }
 
-func LoadSenderConfig(configPath string) (*SenderConfig, error) {
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("config file not found: %s", configPath)
-	}
+// TemplatesConf holds the notification templates by locale. A message type a locale has no
+// templates for falls back from a regional tag to its language (pt-BR to pt), then to
+// DefaultLocale and then to the built-in English templates.
+type TemplatesConf struct {
+	DefaultLocale string                     `mapstructure:"default_locale" env:"SENDER_DEFAULT_LOCALE" default:"en"`
+	Locales       map[string]LocaleTemplates `mapstructure:"locales"`
+}
 
-	v := viper.New()
-	v.SetConfigFile(configPath)
-	v.SetConfigType("yaml")
-	v.AutomaticEnv()
+// LocaleTemplates are the templates of one locale by message type.
+type LocaleTemplates struct {
+	Reminder MessageTemplates `mapstructure:"reminder"`
+	Digest   MessageTemplates `mapstructure:"digest"`
+}
 
-	if err := v.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("read config: %w", err)
-	}
+// MessageTemplates are text/template sources, except HTML, which is an html/template one.
+// An empty HTML template sends text only.
+type MessageTemplates struct {
+	Subject string `mapstructure:"subject"`
+	Text    string `mapstructure:"text"`
+	HTML    string `mapstructure:"html"`
+}
+
+// IsZero reports whether no template is set, leaving the message type to the fallback.
+func (m MessageTemplates) IsZero() bool {
+	return m == MessageTemplates{}
+}
 
+func LoadSenderConfig(configPath string, overrides Overrides) (*SenderConfig, error) {
 	var cfg SenderConfig
-	if err := v.Unmarshal(&cfg); err != nil {
-		return nil, fmt.Errorf("unmarshal config: %w", err)
+	if err := Load(configPath, overrides, &cfg); err != nil {
+		return nil, err
 	}
-
 	return &cfg, nil
 }
+
+func (c *SenderConfig) Validate() error {
+	var errs []error
+
+	if err := c.Logger.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := c.RabbitMQ.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.RabbitMQ.ForwardQueue == "" {
+		errs = append(errs, errors.New("rabbitmq.forward_queue: must be set"))
+	}
+	if c.Sender.PollInterval <= 0 {
+		errs = append(errs, fmt.Errorf("sender.poll_interval: must be positive, got %s", c.Sender.PollInterval))
+	}
+	if c.Sender.Templates.DefaultLocale == "" {
+		errs = append(errs, errors.New("sender.templates.default_locale: must be set"))
+	}
+	for _, locale := range slices.Sorted(maps.Keys(c.Sender.Templates.Locales)) {
+		lt := c.Sender.Templates.Locales[locale]
+		if err := lt.Reminder.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("sender.templates.locales.%s.reminder: %w", locale, err))
+		}
+		if err := lt.Digest.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("sender.templates.locales.%s.digest: %w", locale, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (m MessageTemplates) validate() error {
+	if !m.IsZero() && (m.Subject == "" || m.Text == "") {
+		return errors.New("subject and text must be set")
+	}
+	return nil
+}
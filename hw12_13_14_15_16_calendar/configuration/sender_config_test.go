@@ -0,0 +1,32 @@
+package configuration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderTemplates(t *testing.T) {
+	cfg, err := LoadSenderConfig("../configs/sender_config.yaml", nil)
+	require.NoError(t, err)
+	require.Equal(t, "en", cfg.Sender.Templates.DefaultLocale)
+	require.Contains(t, cfg.Sender.Templates.Locales, "en")
+	require.Contains(t, cfg.Sender.Templates.Locales, "ru")
+	require.NotEmpty(t, cfg.Sender.Templates.Locales["ru"].Digest.Text)
+	require.Empty(t, cfg.Sender.Templates.Locales["ru"].Digest.HTML)
+
+	cfg.Sender.Templates.DefaultLocale = ""
+	cfg.Sender.Templates.Locales["de"] = LocaleTemplates{
+		Reminder: MessageTemplates{Subject: "Erinnerung: {{.Title}}"},
+		Digest:   MessageTemplates{Text: "{{range .Events}}{{.Title}}{{end}}"},
+	}
+	// Leaving a message type out is fine, it falls back.
+	cfg.Sender.Templates.Locales["fr"] = LocaleTemplates{
+		Reminder: MessageTemplates{Subject: "Rappel : {{.Title}}", Text: "{{.Title}}"},
+	}
+	err = cfg.Validate()
+	require.ErrorContains(t, err, "sender.templates.default_locale: must be set")
+	require.ErrorContains(t, err, "sender.templates.locales.de.reminder: subject and text must be set")
+	require.ErrorContains(t, err, "sender.templates.locales.de.digest: subject and text must be set")
+	require.NotContains(t, err.Error(), "locales.fr")
+}
@@ -2,11 +2,11 @@ package app
 
 import (
 	"context"
+	"io"
 
 	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
@@ -26,6 +26,36 @@ type Storage interface {
 	GetEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error)
 	DeleteEvent(ctx context.Context, req *proto.EventByIdReq) (*emptypb.Empty, error)
 	GetEventList(ctx context.Context, req *proto.GetEventListReq) (*proto.GetEventListRes, error)
+	SearchEvents(ctx context.Context, req *proto.SearchEventsReq) (*proto.SearchEventsRes, error)
+	ListDeletedEvents(ctx context.Context, req *proto.ListDeletedEventsReq) (*proto.GetEventListRes, error)
+	RestoreEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error)
+	RespondToEvent(ctx context.Context, req *proto.RespondToEventReq) (*proto.Event, error)
+	BatchCreateEvents(ctx context.Context, req *proto.BatchCreateEventsReq) (*proto.BatchEventsRes, error)
+	BatchEditEvents(ctx context.Context, req *proto.BatchEditEventsReq) (*proto.BatchEventsRes, error)
+	BatchDeleteEvents(ctx context.Context, req *proto.BatchDeleteEventsReq) (*proto.BatchEventsRes, error)
+	CreateCalendar(ctx context.Context, req *proto.CreateCalendarReq) (*proto.UserCalendar, error)
+	GetCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*proto.UserCalendar, error)
+	ListCalendars(ctx context.Context, req *proto.ListCalendarsReq) (*proto.ListCalendarsRes, error)
+	DeleteCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*emptypb.Empty, error)
+	ShareCalendar(ctx context.Context, req *proto.ShareCalendarReq) (*proto.UserCalendar, error)
+	UnshareCalendar(ctx context.Context, req *proto.UnshareCalendarReq) (*proto.UserCalendar, error)
+	FreeBusy(ctx context.Context, req *proto.FreeBusyReq) (*proto.FreeBusyRes, error)
+	FindSlot(ctx context.Context, req *proto.FindSlotReq) (*proto.FindSlotRes, error)
+	CreateLabel(ctx context.Context, req *proto.CreateLabelReq) (*proto.Label, error)
+	ListLabels(ctx context.Context, req *proto.ListLabelsReq) (*proto.ListLabelsRes, error)
+	DeleteLabel(ctx context.Context, req *proto.LabelByIdReq) (*emptypb.Empty, error)
+	CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error)
+	ListWebhooks(ctx context.Context, req *proto.ListWebhooksReq) (*proto.ListWebhooksRes, error)
+	DeleteWebhook(ctx context.Context, req *proto.WebhookByIdReq) (*emptypb.Empty, error)
+	ListWebhookDeliveries(
+		ctx context.Context,
+		req *proto.ListWebhookDeliveriesReq,
+	) (*proto.ListWebhookDeliveriesRes, error)
+	UploadAttachment(ctx context.Context, info *proto.UploadAttachmentInfo, content io.Reader) (*proto.Attachment, error)
+	ListAttachments(ctx context.Context, req *proto.EventByIdReq) (*proto.ListAttachmentsRes, error)
+	GetAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*proto.Attachment, error)
+	DownloadAttachment(ctx context.Context, req *proto.AttachmentByIdReq, send func(*httpbody.HttpBody) error) error
+	DeleteAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*emptypb.Empty, error)
 }
 
 func New(logger Logger, storage Storage) *App {
@@ -40,8 +70,8 @@ func (a *App) GetLiveZ(_ context.Context, _ *emptypb.Empty) (*emptypb.Empty, err
 }
 
 func (a *App) CreateEvent(ctx context.Context, req *proto.CreateEventReq) (*proto.Event, error) {
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
 	}
 
 	res, err := a.eventHandler.CreateEvent(ctx, req)
@@ -52,8 +82,8 @@ func (a *App) CreateEvent(ctx context.Context, req *proto.CreateEventReq) (*prot
 }
 
 func (a *App) EditEvent(ctx context.Context, req *proto.EditEventReq) (*proto.Event, error) {
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
 	}
 
 	res, err := a.eventHandler.EditEvent(ctx, req)
@@ -64,8 +94,8 @@ func (a *App) EditEvent(ctx context.Context, req *proto.EditEventReq) (*proto.Ev
 }
 
 func (a *App) GetEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error) {
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
 	}
 
 	res, err := a.eventHandler.GetEvent(ctx, req)
@@ -76,8 +106,8 @@ func (a *App) GetEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Eve
 }
 
 func (a *App) DeleteEvent(ctx context.Context, req *proto.EventByIdReq) (*emptypb.Empty, error) {
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
 	}
 
 	res, err := a.eventHandler.DeleteEvent(ctx, req)
@@ -88,8 +118,8 @@ func (a *App) DeleteEvent(ctx context.Context, req *proto.EventByIdReq) (*emptyp
 }
 
 func (a *App) GetEventList(ctx context.Context, req *proto.GetEventListReq) (*proto.GetEventListRes, error) {
-	if err := req.Validate(); err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "validation error: %v", err)
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
 	}
 
 	res, err := a.eventHandler.GetEventList(ctx, req)
@@ -98,3 +128,51 @@ func (a *App) GetEventList(ctx context.Context, req *proto.GetEventListReq) (*pr
 	}
 	return res, nil
 }
+
+func (a *App) SearchEvents(ctx context.Context, req *proto.SearchEventsReq) (*proto.SearchEventsRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.SearchEvents(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) ListDeletedEvents(ctx context.Context, req *proto.ListDeletedEventsReq) (*proto.GetEventListRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.ListDeletedEvents(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) RestoreEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.RestoreEvent(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) RespondToEvent(ctx context.Context, req *proto.RespondToEventReq) (*proto.Event, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.RespondToEvent(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
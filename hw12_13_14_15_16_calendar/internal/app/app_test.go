@@ -3,13 +3,18 @@ package app
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"testing"
 	"time"
 
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.openly.dev/pointy"
+	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -45,6 +50,169 @@ func (m *mockStorage) GetEventList(ctx context.Context, req *proto.GetEventListR
 	return args.Get(0).(*proto.GetEventListRes), args.Error(1)
 }
 
+func (m *mockStorage) SearchEvents(ctx context.Context, req *proto.SearchEventsReq) (*proto.SearchEventsRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.SearchEventsRes), args.Error(1)
+}
+
+func (m *mockStorage) ListDeletedEvents(
+	ctx context.Context,
+	req *proto.ListDeletedEventsReq,
+) (*proto.GetEventListRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.GetEventListRes), args.Error(1)
+}
+
+func (m *mockStorage) RestoreEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.Event), args.Error(1)
+}
+
+func (m *mockStorage) RespondToEvent(ctx context.Context, req *proto.RespondToEventReq) (*proto.Event, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.Event), args.Error(1)
+}
+
+func (m *mockStorage) CreateCalendar(ctx context.Context, req *proto.CreateCalendarReq) (*proto.UserCalendar, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.UserCalendar), args.Error(1)
+}
+
+func (m *mockStorage) GetCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*proto.UserCalendar, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.UserCalendar), args.Error(1)
+}
+
+func (m *mockStorage) ListCalendars(ctx context.Context, req *proto.ListCalendarsReq) (*proto.ListCalendarsRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.ListCalendarsRes), args.Error(1)
+}
+
+func (m *mockStorage) DeleteCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*emptypb.Empty, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *mockStorage) ShareCalendar(ctx context.Context, req *proto.ShareCalendarReq) (*proto.UserCalendar, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.UserCalendar), args.Error(1)
+}
+
+func (m *mockStorage) UnshareCalendar(ctx context.Context, req *proto.UnshareCalendarReq) (*proto.UserCalendar, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.UserCalendar), args.Error(1)
+}
+
+func (m *mockStorage) FreeBusy(ctx context.Context, req *proto.FreeBusyReq) (*proto.FreeBusyRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.FreeBusyRes), args.Error(1)
+}
+
+func (m *mockStorage) FindSlot(ctx context.Context, req *proto.FindSlotReq) (*proto.FindSlotRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.FindSlotRes), args.Error(1)
+}
+
+func (m *mockStorage) BatchCreateEvents(
+	ctx context.Context,
+	req *proto.BatchCreateEventsReq,
+) (*proto.BatchEventsRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.BatchEventsRes), args.Error(1)
+}
+
+func (m *mockStorage) BatchEditEvents(
+	ctx context.Context,
+	req *proto.BatchEditEventsReq,
+) (*proto.BatchEventsRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.BatchEventsRes), args.Error(1)
+}
+
+func (m *mockStorage) BatchDeleteEvents(
+	ctx context.Context,
+	req *proto.BatchDeleteEventsReq,
+) (*proto.BatchEventsRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.BatchEventsRes), args.Error(1)
+}
+
+func (m *mockStorage) CreateLabel(ctx context.Context, req *proto.CreateLabelReq) (*proto.Label, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.Label), args.Error(1)
+}
+
+func (m *mockStorage) ListLabels(ctx context.Context, req *proto.ListLabelsReq) (*proto.ListLabelsRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.ListLabelsRes), args.Error(1)
+}
+
+func (m *mockStorage) DeleteLabel(ctx context.Context, req *proto.LabelByIdReq) (*emptypb.Empty, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *mockStorage) CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.Webhook), args.Error(1)
+}
+
+func (m *mockStorage) ListWebhooks(ctx context.Context, req *proto.ListWebhooksReq) (*proto.ListWebhooksRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.ListWebhooksRes), args.Error(1)
+}
+
+func (m *mockStorage) DeleteWebhook(ctx context.Context, req *proto.WebhookByIdReq) (*emptypb.Empty, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
+func (m *mockStorage) ListWebhookDeliveries(
+	ctx context.Context,
+	req *proto.ListWebhookDeliveriesReq,
+) (*proto.ListWebhookDeliveriesRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.ListWebhookDeliveriesRes), args.Error(1)
+}
+
+// UploadAttachment reads the content itself, so that tests match on what arrived.
+func (m *mockStorage) UploadAttachment(
+	ctx context.Context,
+	info *proto.UploadAttachmentInfo,
+	content io.Reader,
+) (*proto.Attachment, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	args := m.Called(ctx, info, string(data))
+	return args.Get(0).(*proto.Attachment), args.Error(1)
+}
+
+func (m *mockStorage) ListAttachments(ctx context.Context, req *proto.EventByIdReq) (*proto.ListAttachmentsRes, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.ListAttachmentsRes), args.Error(1)
+}
+
+func (m *mockStorage) GetAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*proto.Attachment, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*proto.Attachment), args.Error(1)
+}
+
+func (m *mockStorage) DownloadAttachment(
+	ctx context.Context,
+	req *proto.AttachmentByIdReq,
+	send func(*httpbody.HttpBody) error,
+) error {
+	args := m.Called(ctx, req, send)
+	return args.Error(0)
+}
+
+func (m *mockStorage) DeleteAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*emptypb.Empty, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*emptypb.Empty), args.Error(1)
+}
+
 type noopLogger struct{}
 
 func (noopLogger) Error(_ string) {}
@@ -176,3 +344,81 @@ func TestGetEventList(t *testing.T) {
 		assert.Equal(t, expected, resp)
 	})
 }
+
+func TestShareCalendar(t *testing.T) {
+	t.Run("role is required", func(t *testing.T) {
+		a, _ := newTestApp()
+		_, err := a.ShareCalendar(context.Background(), &proto.ShareCalendarReq{CalendarId: "cal", User: "u"})
+		st, _ := status.FromError(err)
+		assert.Equal(t, codes.InvalidArgument, st.Code())
+	})
+
+	t.Run("access denied", func(t *testing.T) {
+		a, st := newTestApp()
+		req := &proto.CreateEventReq{
+			Date:       timestamppb.New(time.Now()),
+			User:       uuid.NewString(),
+			EndTime:    timestamppb.New(time.Now().Add(time.Hour)),
+			Title:      "test",
+			CalendarId: pointy.String("cal"),
+		}
+		st.On("CreateEvent", mock.Anything, req).
+			Return(&proto.Event{}, fmt.Errorf("event create: %w", calendarErrors.ErrCalendarAccessDenied))
+
+		_, err := a.CreateEvent(context.Background(), req)
+		stErr, _ := status.FromError(err)
+		assert.Equal(t, codes.PermissionDenied, stErr.Code())
+	})
+}
+
+func TestCreateWebhook(t *testing.T) {
+	valid := func() *proto.CreateWebhookReq {
+		return &proto.CreateWebhookReq{
+			User:   uuid.NewString(),
+			Url:    "https://example.com/hooks/calendar",
+			Secret: "0123456789abcdef",
+			Types:  []string{"event.created", "reminder.due"},
+		}
+	}
+
+	for name, broken := range map[string]func(req *proto.CreateWebhookReq){
+		"not http":      func(req *proto.CreateWebhookReq) { req.Url = "ftp://example.com" },
+		"short secret":  func(req *proto.CreateWebhookReq) { req.Secret = "secret" },
+		"no types":      func(req *proto.CreateWebhookReq) { req.Types = nil },
+		"unknown type":  func(req *proto.CreateWebhookReq) { req.Types = []string{"event.moved"} },
+		"repeated type": func(req *proto.CreateWebhookReq) { req.Types = []string{"event.created", "event.created"} },
+	} {
+		t.Run(name, func(t *testing.T) {
+			a, _ := newTestApp()
+			req := valid()
+			broken(req)
+			_, err := a.CreateWebhook(context.Background(), req)
+			st, _ := status.FromError(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code())
+		})
+	}
+
+	t.Run("success", func(t *testing.T) {
+		a, st := newTestApp()
+		req := valid()
+		expected := &proto.Webhook{Id: "1"}
+		st.On("CreateWebhook", mock.Anything, req).Return(expected, nil)
+
+		resp, err := a.CreateWebhook(context.Background(), req)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, resp)
+	})
+}
+
+func TestDeleteWebhook(t *testing.T) {
+	t.Run("not found", func(t *testing.T) {
+		a, st := newTestApp()
+		req := &proto.WebhookByIdReq{WebhookId: uuid.NewString()}
+		st.On("DeleteWebhook", mock.Anything, req).
+			Return(&emptypb.Empty{}, fmt.Errorf("webhook delete: %w", calendarErrors.ErrWebhookNotFound))
+
+		_, err := a.DeleteWebhook(context.Background(), req)
+		stErr, _ := status.FromError(err)
+		assert.Equal(t, codes.NotFound, stErr.Code())
+	})
+}
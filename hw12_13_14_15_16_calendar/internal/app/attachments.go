@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+var (
+	errUploadEmpty     = errors.New("upload: the stream has no info message")
+	errUploadInfoFirst = errors.New("upload: the info must come first, and only once")
+)
+
+// UploadAttachment reads the info from the first message and hands the chunks of the following
+// ones to the handler as they arrive.
+func (a *App) UploadAttachment(stream grpc.ClientStreamingServer[proto.UploadAttachmentReq, proto.Attachment]) error {
+	first, err := stream.Recv()
+	if errors.Is(err, io.EOF) {
+		return calendarErrors.Validation(errUploadEmpty)
+	}
+	if err != nil {
+		return err
+	}
+	if err := first.ValidateAll(); err != nil {
+		return calendarErrors.Validation(err)
+	}
+	info := first.GetInfo()
+	if info == nil {
+		return calendarErrors.Validation(errUploadInfoFirst)
+	}
+
+	res, err := a.eventHandler.UploadAttachment(stream.Context(), info, &uploadReader{stream: stream})
+	if err != nil {
+		return calendarErrors.MakeGrpcError(err)
+	}
+	return stream.SendAndClose(res)
+}
+
+// uploadReader reads the chunks of an upload stream, validating every message.
+type uploadReader struct {
+	stream grpc.ClientStreamingServer[proto.UploadAttachmentReq, proto.Attachment]
+	buf    []byte
+}
+
+func (r *uploadReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		msg, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		if err := msg.ValidateAll(); err != nil {
+			return 0, calendarErrors.Validation(err)
+		}
+		if msg.GetInfo() != nil {
+			return 0, calendarErrors.Validation(errUploadInfoFirst)
+		}
+		r.buf = msg.GetChunk()
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+func (a *App) ListAttachments(ctx context.Context, req *proto.EventByIdReq) (*proto.ListAttachmentsRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.ListAttachments(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) GetAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*proto.Attachment, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.GetAttachment(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) DownloadAttachment(
+	req *proto.AttachmentByIdReq,
+	stream grpc.ServerStreamingServer[httpbody.HttpBody],
+) error {
+	if err := req.ValidateAll(); err != nil {
+		return calendarErrors.Validation(err)
+	}
+
+	if err := a.eventHandler.DownloadAttachment(stream.Context(), req, stream.Send); err != nil {
+		return calendarErrors.MakeGrpcError(err)
+	}
+	return nil
+}
+
+func (a *App) DeleteAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*emptypb.Empty, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.DeleteAttachment(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// uploadStream replays msgs to the server and keeps what it answers.
+type uploadStream struct {
+	grpc.ServerStream
+	msgs []*proto.UploadAttachmentReq
+	res  *proto.Attachment
+}
+
+func (s *uploadStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *uploadStream) Recv() (*proto.UploadAttachmentReq, error) {
+	if len(s.msgs) == 0 {
+		return nil, io.EOF
+	}
+	msg := s.msgs[0]
+	s.msgs = s.msgs[1:]
+	return msg, nil
+}
+
+func (s *uploadStream) SendAndClose(res *proto.Attachment) error {
+	s.res = res
+	return nil
+}
+
+func infoMsg(info *proto.UploadAttachmentInfo) *proto.UploadAttachmentReq {
+	return &proto.UploadAttachmentReq{Part: &proto.UploadAttachmentReq_Info{Info: info}}
+}
+
+func chunkMsg(data string) *proto.UploadAttachmentReq {
+	return &proto.UploadAttachmentReq{Part: &proto.UploadAttachmentReq_Chunk{Chunk: []byte(data)}}
+}
+
+func TestUploadAttachment(t *testing.T) {
+	info := &proto.UploadAttachmentInfo{EventId: uuid.NewString(), User: uuid.NewString(), Name: "notes.txt"}
+
+	t.Run("success", func(t *testing.T) {
+		a, st := newTestApp()
+		expected := &proto.Attachment{Id: "1", Size: 11}
+		st.On("UploadAttachment", mock.Anything, mock.Anything, "hello world").Return(expected, nil)
+
+		stream := &uploadStream{msgs: []*proto.UploadAttachmentReq{infoMsg(info), chunkMsg("hello"), chunkMsg(" world")}}
+		require.NoError(t, a.UploadAttachment(stream))
+		assert.Equal(t, expected, stream.res)
+	})
+
+	for name, msgs := range map[string][]*proto.UploadAttachmentReq{
+		"empty":       nil,
+		"chunk first": {chunkMsg("hello"), infoMsg(info)},
+		"info twice":  {infoMsg(info), chunkMsg("hello"), infoMsg(info)},
+		"no part":     {{}},
+		"directory": {infoMsg(&proto.UploadAttachmentInfo{
+			EventId: info.EventId, User: info.User, Name: "../notes.txt",
+		})},
+	} {
+		t.Run(name, func(t *testing.T) {
+			a, _ := newTestApp()
+			err := a.UploadAttachment(&uploadStream{msgs: msgs})
+			st, _ := status.FromError(err)
+			assert.Equal(t, codes.InvalidArgument, st.Code(), err)
+		})
+	}
+}
+
+func TestGetAttachmentValidates(t *testing.T) {
+	a, _ := newTestApp()
+	_, err := a.GetAttachment(context.Background(), &proto.AttachmentByIdReq{})
+	st, _ := status.FromError(err)
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+}
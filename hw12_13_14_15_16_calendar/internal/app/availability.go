@@ -0,0 +1,32 @@
+package app
+
+import (
+	"context"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+)
+
+func (a *App) FreeBusy(ctx context.Context, req *proto.FreeBusyReq) (*proto.FreeBusyRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.FreeBusy(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) FindSlot(ctx context.Context, req *proto.FindSlotReq) (*proto.FindSlotRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.FindSlot(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"context"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+)
+
+func (a *App) BatchCreateEvents(ctx context.Context, req *proto.BatchCreateEventsReq) (*proto.BatchEventsRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.BatchCreateEvents(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) BatchEditEvents(ctx context.Context, req *proto.BatchEditEventsReq) (*proto.BatchEventsRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.BatchEditEvents(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) BatchDeleteEvents(ctx context.Context, req *proto.BatchDeleteEventsReq) (*proto.BatchEventsRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.BatchDeleteEvents(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,81 @@
+package app
+
+import (
+	"context"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (a *App) CreateCalendar(ctx context.Context, req *proto.CreateCalendarReq) (*proto.UserCalendar, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.CreateCalendar(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) GetCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*proto.UserCalendar, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.GetCalendar(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) ListCalendars(ctx context.Context, req *proto.ListCalendarsReq) (*proto.ListCalendarsRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.ListCalendars(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) DeleteCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*emptypb.Empty, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.DeleteCalendar(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) ShareCalendar(ctx context.Context, req *proto.ShareCalendarReq) (*proto.UserCalendar, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.ShareCalendar(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) UnshareCalendar(ctx context.Context, req *proto.UnshareCalendarReq) (*proto.UserCalendar, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.UnshareCalendar(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (a *App) CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.CreateWebhook(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) ListWebhooks(ctx context.Context, req *proto.ListWebhooksReq) (*proto.ListWebhooksRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.ListWebhooks(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) DeleteWebhook(ctx context.Context, req *proto.WebhookByIdReq) (*emptypb.Empty, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.DeleteWebhook(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
+
+func (a *App) ListWebhookDeliveries(
+	ctx context.Context,
+	req *proto.ListWebhookDeliveriesReq,
+) (*proto.ListWebhookDeliveriesRes, error) {
+	if err := req.ValidateAll(); err != nil {
+		return nil, calendarErrors.Validation(err)
+	}
+
+	res, err := a.eventHandler.ListWebhookDeliveries(ctx, req)
+	if err != nil {
+		return nil, calendarErrors.MakeGrpcError(err)
+	}
+	return res, nil
+}
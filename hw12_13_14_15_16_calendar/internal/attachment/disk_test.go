@@ -0,0 +1,38 @@
+package attachment
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskStore(t *testing.T) {
+	ctx := context.Background()
+	store, err := NewDiskStore(t.TempDir())
+	require.NoError(t, err)
+	key := Key("event", "file")
+
+	_, err = store.Open(ctx, key)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.Put(ctx, key, strings.NewReader("hello"), 5))
+	require.Error(t, store.Put(ctx, key, strings.NewReader("bye"), 5), "short body")
+
+	r, err := store.Open(ctx, key)
+	require.NoError(t, err)
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, "hello", string(data), "a failed put keeps the content")
+
+	require.NoError(t, store.Delete(ctx, key))
+	require.NoError(t, store.Delete(ctx, key))
+	_, err = store.Open(ctx, key)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.Error(t, store.Put(ctx, "../escape", strings.NewReader(""), 0))
+}
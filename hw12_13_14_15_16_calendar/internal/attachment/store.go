@@ -0,0 +1,45 @@
+// Package attachment keeps the content of the files attached to events. The storage only keeps
+// their records.
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+)
+
+// ErrNotFound is returned when opening a key the store does not hold.
+var ErrNotFound = errors.New("attachment content not found")
+
+// Store holds the content of attachments under keys made by Key.
+type Store interface {
+	// Put stores size bytes read from body, replacing what the key held.
+	Put(ctx context.Context, key string, body io.Reader, size int64) error
+
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete succeeds when the key is already gone.
+	Delete(ctx context.Context, key string) error
+}
+
+// Key is where the content of an attachment is kept.
+func Key(eventID, attachmentID string) string {
+	return eventID + "/" + attachmentID
+}
+
+// NewStore opens the backend cfg selects.
+func NewStore(cfg configuration.AttachmentsConf) (Store, error) {
+	switch cfg.Backend {
+	case configuration.AttachmentsDisk:
+		return NewDiskStore(cfg.Dir)
+	case configuration.AttachmentsS3:
+		return NewS3Store(cfg.S3, &http.Client{Timeout: 5 * time.Minute})
+	default:
+		return nil, fmt.Errorf("unknown attachment backend %q", cfg.Backend)
+	}
+}
@@ -0,0 +1,88 @@
+package attachment
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+// sweepBatch is how many orphans a sweep takes from the storage at once.
+const sweepBatch = 100
+
+// Orphans is the part of the storage listing the attachments whose records are gone.
+type Orphans interface {
+	AttachmentOrphans(ctx context.Context, limit int) ([]models.AttachmentOrphan, error)
+	AttachmentOrphansForget(ctx context.Context, ids []string) error
+}
+
+// Sweeper deletes the content of removed attachments from the store. DeleteAttachment deletes
+// the content of its attachment right away, so the sweeper mostly handles the attachments that
+// went with their events, in the retention, a trash purge or the deletion of a calendar, which
+// may run in another process. An orphan whose content fails to delete is tried again next time.
+type Sweeper struct {
+	orphans  Orphans
+	files    Store
+	interval time.Duration
+	logger   logger.Logger
+}
+
+func NewSweeper(orphans Orphans, files Store, interval time.Duration, logg logger.Logger) *Sweeper {
+	return &Sweeper{orphans: orphans, files: files, interval: interval, logger: logg}
+}
+
+// Run sweeps every interval until ctx is done.
+func (s *Sweeper) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Full batches mean more orphans are waiting.
+		for {
+			n, err := s.Sweep(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					s.logger.Error(fmt.Sprintf("sweep attachments: %v", err))
+				}
+				break
+			}
+			if n < sweepBatch {
+				break
+			}
+		}
+	}
+}
+
+// Sweep deletes the content of one batch of orphans and returns how many it took.
+func (s *Sweeper) Sweep(ctx context.Context) (int, error) {
+	orphans, err := s.orphans.AttachmentOrphans(ctx, sweepBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	done := make([]string, 0, len(orphans))
+	for _, o := range orphans {
+		if err := s.files.Delete(ctx, Key(o.EventID, o.ID)); err != nil {
+			s.logger.Warn(fmt.Sprintf("delete content of attachment %s: %v", o.ID, err))
+			continue
+		}
+		done = append(done, o.ID)
+	}
+	if len(done) == 0 {
+		// Nothing deleted: stop here rather than retrying the same batch at once.
+		return 0, nil
+	}
+	if err := s.orphans.AttachmentOrphansForget(ctx, done); err != nil {
+		return 0, err
+	}
+	s.logger.Debug(fmt.Sprintf("deleted the content of %d removed attachments", len(done)))
+	return len(orphans), nil
+}
@@ -0,0 +1,79 @@
+package attachment
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeOrphans struct {
+	orphans []models.AttachmentOrphan
+}
+
+func (f *fakeOrphans) AttachmentOrphans(_ context.Context, limit int) ([]models.AttachmentOrphan, error) {
+	return f.orphans[:min(limit, len(f.orphans))], nil
+}
+
+func (f *fakeOrphans) AttachmentOrphansForget(_ context.Context, ids []string) error {
+	kept := f.orphans[:0]
+	for _, o := range f.orphans {
+		if !slices.Contains(ids, o.ID) {
+			kept = append(kept, o)
+		}
+	}
+	f.orphans = kept
+	return nil
+}
+
+// failingStore fails to delete one key.
+type failingStore struct {
+	Store
+	key string
+}
+
+func (s failingStore) Delete(ctx context.Context, key string) error {
+	if key == s.key {
+		return errors.New("unavailable")
+	}
+	return s.Store.Delete(ctx, key)
+}
+
+func TestSweep(t *testing.T) {
+	ctx := context.Background()
+	disk, err := NewDiskStore(t.TempDir())
+	require.NoError(t, err)
+	for _, key := range []string{Key("e1", "a"), Key("e1", "b"), Key("e2", "c")} {
+		require.NoError(t, disk.Put(ctx, key, strings.NewReader("x"), 1))
+	}
+
+	orphans := &fakeOrphans{orphans: []models.AttachmentOrphan{
+		{ID: "a", EventID: "e1"},
+		{ID: "b", EventID: "e1"},
+		{ID: "gone", EventID: "e3"},
+	}}
+	sweeper := NewSweeper(orphans, failingStore{Store: disk, key: Key("e1", "b")}, 0,
+		*logger.NewLogger("calendar", "test", "fatal"))
+
+	n, err := sweeper.Sweep(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []models.AttachmentOrphan{{ID: "b", EventID: "e1"}}, orphans.orphans,
+		"an orphan whose content failed to delete is kept")
+
+	_, err = disk.Open(ctx, Key("e1", "a"))
+	assert.ErrorIs(t, err, ErrNotFound)
+	r, err := disk.Open(ctx, Key("e2", "c"))
+	require.NoError(t, err, "content with a record is kept")
+	require.NoError(t, r.Close())
+
+	n, err = sweeper.Sweep(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, n, "a batch that deletes nothing does not count")
+}
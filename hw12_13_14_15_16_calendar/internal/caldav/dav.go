@@ -0,0 +1,252 @@
+package caldav
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// XML namespaces of WebDAV, CalDAV and the calendarserver.org extensions clients ask for.
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsCS     = "http://calendarserver.org/ns/"
+)
+
+var prefixes = map[string]string{nsDAV: "D", nsCalDAV: "C", nsCS: "CS"}
+
+// Properties served by the resources.
+var (
+	propResourceType         = xml.Name{Space: nsDAV, Local: "resourcetype"}
+	propDisplayName          = xml.Name{Space: nsDAV, Local: "displayname"}
+	propCurrentUserPrincipal = xml.Name{Space: nsDAV, Local: "current-user-principal"}
+	propPrincipalURL         = xml.Name{Space: nsDAV, Local: "principal-URL"}
+	propOwner                = xml.Name{Space: nsDAV, Local: "owner"}
+	propPrivileges           = xml.Name{Space: nsDAV, Local: "current-user-privilege-set"}
+	propSupportedReports     = xml.Name{Space: nsDAV, Local: "supported-report-set"}
+	propETag                 = xml.Name{Space: nsDAV, Local: "getetag"}
+	propContentType          = xml.Name{Space: nsDAV, Local: "getcontenttype"}
+	propContentLength        = xml.Name{Space: nsDAV, Local: "getcontentlength"}
+	propCalendarHome         = xml.Name{Space: nsCalDAV, Local: "calendar-home-set"}
+	propUserAddresses        = xml.Name{Space: nsCalDAV, Local: "calendar-user-address-set"}
+	propComponents           = xml.Name{Space: nsCalDAV, Local: "supported-calendar-component-set"}
+	propCalendarData         = xml.Name{Space: nsCalDAV, Local: "calendar-data"}
+	propCTag                 = xml.Name{Space: nsCS, Local: "getctag"}
+)
+
+// props are the rendered values of the properties of a resource, keyed by name.
+type props map[xml.Name]string
+
+// propRequest is the set of properties a PROPFIND or REPORT asks for.
+type propRequest struct {
+	all   bool
+	names bool
+	props []xml.Name
+}
+
+type anyElement struct {
+	XMLName xml.Name
+}
+
+type propElement struct {
+	Names []anyElement `xml:",any"`
+}
+
+type propfindBody struct {
+	XMLName  xml.Name     `xml:"DAV: propfind"`
+	AllProp  *struct{}    `xml:"DAV: allprop"`
+	PropName *struct{}    `xml:"DAV: propname"`
+	Prop     *propElement `xml:"DAV: prop"`
+}
+
+type timeRange struct {
+	Start string `xml:"start,attr"`
+	End   string `xml:"end,attr"`
+}
+
+type compFilter struct {
+	Name      string       `xml:"name,attr"`
+	TimeRange *timeRange   `xml:"urn:ietf:params:xml:ns:caldav time-range"`
+	Comps     []compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+}
+
+// reportBody holds the calendar-query and calendar-multiget reports.
+type reportBody struct {
+	XMLName xml.Name
+	Prop    *propElement `xml:"DAV: prop"`
+	AllProp *struct{}    `xml:"DAV: allprop"`
+	Filter  *struct {
+		Comp compFilter `xml:"urn:ietf:params:xml:ns:caldav comp-filter"`
+	} `xml:"urn:ietf:params:xml:ns:caldav filter"`
+	Hrefs []string `xml:"DAV: href"`
+}
+
+// parsePropfind reads the body of a PROPFIND; an empty body asks for all properties.
+func parsePropfind(r io.Reader) (propRequest, error) {
+	var body propfindBody
+	if err := xml.NewDecoder(r).Decode(&body); err != nil {
+		if errors.Is(err, io.EOF) {
+			return propRequest{all: true}, nil
+		}
+		return propRequest{}, err
+	}
+	return propRequestOf(body.Prop, body.AllProp != nil, body.PropName != nil), nil
+}
+
+func propRequestOf(prop *propElement, all, names bool) propRequest {
+	req := propRequest{all: all || prop == nil && !names, names: names}
+	if prop != nil {
+		for _, n := range prop.Names {
+			req.props = append(req.props, n.XMLName)
+		}
+	}
+	return req
+}
+
+// queryRange returns the time-range of the VEVENT filter of a calendar-query, zero when unbounded,
+// and whether the query can match events at all.
+func (b *reportBody) queryRange() (start, end time.Time, events bool, err error) {
+	if b.Filter == nil {
+		return start, end, true, nil
+	}
+	if !strings.EqualFold(b.Filter.Comp.Name, "VCALENDAR") {
+		return start, end, false, nil
+	}
+	if len(b.Filter.Comp.Comps) == 0 {
+		return start, end, true, nil
+	}
+	for _, c := range b.Filter.Comp.Comps {
+		if !strings.EqualFold(c.Name, "VEVENT") {
+			continue
+		}
+		if c.TimeRange == nil {
+			return start, end, true, nil
+		}
+		if c.TimeRange.Start != "" {
+			if start, err = time.Parse(utcLayout, c.TimeRange.Start); err != nil {
+				return start, end, false, err
+			}
+		}
+		if c.TimeRange.End != "" {
+			if end, err = time.Parse(utcLayout, c.TimeRange.End); err != nil {
+				return start, end, false, err
+			}
+		}
+		return start, end, true, nil
+	}
+	return start, end, false, nil
+}
+
+// multistatus collects the responses of a PROPFIND or REPORT.
+type multistatus struct {
+	b strings.Builder
+}
+
+// add writes the response for href: the requested properties it has with 200, the others with 404.
+func (m *multistatus) add(href string, have props, req propRequest) {
+	m.b.WriteString("<D:response><D:href>")
+	xmlText(&m.b, href)
+	m.b.WriteString("</D:href>")
+
+	var found, missing []xml.Name
+	switch {
+	case req.all || req.names:
+		for name := range have {
+			found = append(found, name)
+		}
+		sort.Slice(found, func(i, j int) bool {
+			return found[i].Space+found[i].Local < found[j].Space+found[j].Local
+		})
+	default:
+		for _, name := range req.props {
+			if _, ok := have[name]; ok {
+				found = append(found, name)
+			} else {
+				missing = append(missing, name)
+			}
+		}
+	}
+
+	if len(found) > 0 {
+		m.b.WriteString("<D:propstat><D:prop>")
+		for _, name := range found {
+			value := have[name]
+			if req.names {
+				value = ""
+			}
+			element(&m.b, name, value)
+		}
+		m.b.WriteString("</D:prop><D:status>HTTP/1.1 200 OK</D:status></D:propstat>")
+	}
+	if len(missing) > 0 {
+		m.b.WriteString("<D:propstat><D:prop>")
+		for _, name := range missing {
+			element(&m.b, name, "")
+		}
+		m.b.WriteString("</D:prop><D:status>HTTP/1.1 404 Not Found</D:status></D:propstat>")
+	}
+	m.b.WriteString("</D:response>")
+}
+
+// addStatus writes a response that has a status instead of properties, e.g. a missing href of a multiget.
+func (m *multistatus) addStatus(href string, code int) {
+	m.b.WriteString("<D:response><D:href>")
+	xmlText(&m.b, href)
+	m.b.WriteString("</D:href>")
+	fmt.Fprintf(&m.b, "<D:status>HTTP/1.1 %d %s</D:status>", code, http.StatusText(code))
+	m.b.WriteString("</D:response>")
+}
+
+func (m *multistatus) write(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", `application/xml; charset="utf-8"`)
+	w.WriteHeader(http.StatusMultiStatus)
+	_, _ = io.WriteString(w, xml.Header+`<D:multistatus xmlns:D="DAV:" xmlns:C="`+nsCalDAV+`" xmlns:CS="`+nsCS+`">`)
+	_, _ = io.WriteString(w, m.b.String())
+	_, _ = io.WriteString(w, "</D:multistatus>")
+}
+
+// element writes <name>inner</name>, inner being XML already.
+func element(b *strings.Builder, name xml.Name, inner string) {
+	tag := name.Local
+	open := tag
+	if p, ok := prefixes[name.Space]; ok {
+		tag = p + ":" + name.Local
+		open = tag
+	} else if name.Space != "" {
+		open = tag + ` xmlns="` + xmlEscape(name.Space) + `"`
+	}
+	if inner == "" {
+		b.WriteString("<" + open + "/>")
+		return
+	}
+	b.WriteString("<" + open + ">" + inner + "</" + tag + ">")
+}
+
+// hrefProp renders a property holding a single href.
+func hrefProp(href string) string {
+	return "<D:href>" + xmlEscape(href) + "</D:href>"
+}
+
+func xmlText(b *strings.Builder, s string) {
+	_ = xml.EscapeText(b, []byte(s))
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	xmlText(&b, s)
+	return b.String()
+}
+
+// writeError answers with a DAV:error body naming the violated precondition.
+func writeError(w http.ResponseWriter, code int, condition xml.Name) {
+	var b strings.Builder
+	element(&b, condition, "")
+	w.Header().Set("Content-Type", `application/xml; charset="utf-8"`)
+	w.WriteHeader(code)
+	_, _ = io.WriteString(w, xml.Header+`<D:error xmlns:D="DAV:" xmlns:C="`+nsCalDAV+`">`+b.String()+"</D:error>")
+}
@@ -0,0 +1,636 @@
+// Package caldav serves the events of every user as a CalDAV (RFC 4791) calendar collection, so
+// that native clients can sync with the calendar. It calls the same application methods as the
+// REST gateway, so requests are validated and reported to webhooks alike.
+package caldav
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/google/uuid"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// Prefix is the path the handler is mounted at.
+	Prefix = "/caldav/"
+	// WellKnown is where clients look for the service first (RFC 6764).
+	WellKnown = "/.well-known/caldav"
+	// collection is the name of the calendar collection in the home of every user.
+	collection = "calendar"
+	// maxBody bounds the request bodies read.
+	maxBody = 1 << 20
+
+	calendarType = "text/calendar; charset=utf-8"
+)
+
+// Application is the part of the calendar API the handler uses.
+type Application interface {
+	CreateEvent(ctx context.Context, req *proto.CreateEventReq) (*proto.Event, error)
+	EditEvent(ctx context.Context, req *proto.EditEventReq) (*proto.Event, error)
+	GetEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error)
+	DeleteEvent(ctx context.Context, req *proto.EventByIdReq) (*emptypb.Empty, error)
+	GetEventList(ctx context.Context, req *proto.GetEventListReq) (*proto.GetEventListRes, error)
+	RespondToEvent(ctx context.Context, req *proto.RespondToEventReq) (*proto.Event, error)
+}
+
+// Logger logs the failures of the application the clients only see as 500.
+type Logger interface {
+	Error(msg string)
+}
+
+// Handler serves, under Prefix, the principal /caldav/{user}/ of every user, which is also their
+// calendar home, and in it the collection /caldav/{user}/calendar/ of the events the user owns or
+// attends, one /caldav/{user}/calendar/{id}.ics resource each.
+//
+// The calendar has no accounts: the user name of HTTP Basic authentication is taken as the user ID
+// and the password is not checked, the way the REST API trusts the user in the request.
+type Handler struct {
+	app    Application
+	logger Logger
+}
+
+func NewHandler(app Application, logger Logger) *Handler {
+	return &Handler{app: app, logger: logger}
+}
+
+type kind int
+
+const (
+	kindRoot kind = iota
+	kindPrincipal
+	kindCollection
+	kindEvent
+)
+
+// target is the resource a request is for.
+type target struct {
+	kind kind
+	user string
+	id   string
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.TrimSuffix(r.URL.Path, "/") == WellKnown {
+		http.Redirect(w, r, Prefix, http.StatusMovedPermanently)
+		return
+	}
+
+	t, ok := parsePath(r.URL.Path)
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	authUser, _, _ := r.BasicAuth()
+	switch {
+	case t.kind == kindRoot && authUser == "":
+		// Clients find their principal at the root, which needs to know who is asking.
+		w.Header().Set("WWW-Authenticate", `Basic realm="calendar"`)
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	case t.kind == kindRoot:
+		if _, err := uuid.Parse(authUser); err != nil {
+			http.Error(w, "the user name must be the user ID", http.StatusForbidden)
+			return
+		}
+		t.user = strings.ToLower(authUser)
+	case authUser != "" && !strings.EqualFold(authUser, t.user):
+		http.Error(w, "the calendar belongs to another user", http.StatusForbidden)
+		return
+	}
+
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Allow", strings.Join(allowed(t.kind), ", "))
+		w.WriteHeader(http.StatusOK)
+	case "PROPFIND":
+		h.propfind(w, r, t)
+	case "REPORT":
+		if t.kind != kindCollection {
+			h.notAllowed(w, t)
+			return
+		}
+		h.report(w, r, t)
+	case http.MethodGet, http.MethodHead:
+		if t.kind != kindEvent {
+			h.notAllowed(w, t)
+			return
+		}
+		h.get(w, r, t)
+	case http.MethodPut:
+		if t.kind != kindEvent {
+			h.notAllowed(w, t)
+			return
+		}
+		h.put(w, r, t)
+	case http.MethodDelete:
+		if t.kind != kindEvent {
+			h.notAllowed(w, t)
+			return
+		}
+		h.delete(w, r, t)
+	default:
+		h.notAllowed(w, t)
+	}
+}
+
+// parsePath maps a path under Prefix to its target.
+func parsePath(p string) (target, bool) {
+	rest, ok := strings.CutPrefix(p, Prefix)
+	if !ok {
+		return target{}, strings.TrimSuffix(p, "/") == strings.TrimSuffix(Prefix, "/")
+	}
+	parts := strings.Split(strings.Trim(rest, "/"), "/")
+	if parts[0] == "" {
+		return target{kind: kindRoot}, true
+	}
+	if _, err := uuid.Parse(parts[0]); err != nil {
+		return target{}, false
+	}
+	t := target{kind: kindPrincipal, user: strings.ToLower(parts[0])}
+	switch {
+	case len(parts) == 1:
+		return t, true
+	case parts[1] != collection:
+		return target{}, false
+	case len(parts) == 2:
+		t.kind = kindCollection
+		return t, true
+	case len(parts) == 3:
+		id, ok := strings.CutSuffix(parts[2], ".ics")
+		if _, err := uuid.Parse(id); !ok || err != nil {
+			return target{}, false
+		}
+		t.kind, t.id = kindEvent, strings.ToLower(id)
+		return t, true
+	}
+	return target{}, false
+}
+
+func allowed(k kind) []string {
+	switch k {
+	case kindCollection:
+		return []string{http.MethodOptions, "PROPFIND", "REPORT"}
+	case kindEvent:
+		return []string{http.MethodOptions, "PROPFIND", http.MethodGet, http.MethodHead, http.MethodPut,
+			http.MethodDelete}
+	}
+	return []string{http.MethodOptions, "PROPFIND"}
+}
+
+func (h *Handler) notAllowed(w http.ResponseWriter, t target) {
+	w.Header().Set("Allow", strings.Join(allowed(t.kind), ", "))
+	http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+}
+
+func principalHref(user string) string {
+	return Prefix + user + "/"
+}
+
+func collectionHref(user string) string {
+	return principalHref(user) + collection + "/"
+}
+
+func eventHref(user, id string) string {
+	return collectionHref(user) + id + ".ics"
+}
+
+func (h *Handler) propfind(w http.ResponseWriter, r *http.Request, t target) {
+	req, err := parsePropfind(io.LimitReader(r.Body, maxBody))
+	if err != nil {
+		http.Error(w, "malformed PROPFIND body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Depth infinity is answered as 1, which covers every resource below a collection.
+	deep := r.Header.Get("Depth") != "0"
+	ctx := r.Context()
+
+	var ms multistatus
+	switch t.kind {
+	case kindRoot:
+		ms.add(Prefix, rootProps(t.user), req)
+		if deep {
+			ms.add(principalHref(t.user), principalProps(t.user), req)
+		}
+	case kindPrincipal:
+		ms.add(principalHref(t.user), principalProps(t.user), req)
+		if deep {
+			events, err := h.events(ctx, t.user, time.Time{}, time.Time{})
+			if err != nil {
+				h.fail(w, err)
+				return
+			}
+			ms.add(collectionHref(t.user), collectionProps(t.user, events), req)
+		}
+	case kindCollection:
+		events, err := h.events(ctx, t.user, time.Time{}, time.Time{})
+		if err != nil {
+			h.fail(w, err)
+			return
+		}
+		ms.add(collectionHref(t.user), collectionProps(t.user, events), req)
+		if deep {
+			for _, ev := range events {
+				ms.add(eventHref(t.user, ev.Id), eventProps(t.user, ev, req), req)
+			}
+		}
+	case kindEvent:
+		ev, err := h.event(ctx, t)
+		if err != nil {
+			h.fail(w, err)
+			return
+		}
+		ms.add(eventHref(t.user, t.id), eventProps(t.user, ev, req), req)
+	}
+	ms.write(w)
+}
+
+func (h *Handler) report(w http.ResponseWriter, r *http.Request, t target) {
+	var body reportBody
+	if err := xml.NewDecoder(io.LimitReader(r.Body, maxBody)).Decode(&body); err != nil {
+		http.Error(w, "malformed REPORT body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	req := propRequestOf(body.Prop, body.AllProp != nil, false)
+	ctx := r.Context()
+
+	var ms multistatus
+	switch body.XMLName {
+	case xml.Name{Space: nsCalDAV, Local: "calendar-query"}:
+		start, end, match, err := body.queryRange()
+		if err != nil {
+			writeError(w, http.StatusForbidden, xml.Name{Space: nsCalDAV, Local: "valid-filter"})
+			return
+		}
+		if match {
+			events, err := h.events(ctx, t.user, start, end)
+			if err != nil {
+				h.fail(w, err)
+				return
+			}
+			for _, ev := range events {
+				ms.add(eventHref(t.user, ev.Id), eventProps(t.user, ev, req), req)
+			}
+		}
+	case xml.Name{Space: nsCalDAV, Local: "calendar-multiget"}:
+		for _, href := range body.Hrefs {
+			ht, ok := parseHref(href)
+			if !ok || ht.kind != kindEvent || ht.user != t.user {
+				ms.addStatus(href, http.StatusNotFound)
+				continue
+			}
+			ev, err := h.event(ctx, ht)
+			if err != nil {
+				if status.Code(err) != codes.NotFound {
+					h.fail(w, err)
+					return
+				}
+				ms.addStatus(href, http.StatusNotFound)
+				continue
+			}
+			ms.add(href, eventProps(t.user, ev, req), req)
+		}
+	default:
+		writeError(w, http.StatusForbidden, xml.Name{Space: nsDAV, Local: "supported-report"})
+		return
+	}
+	ms.write(w)
+}
+
+// parseHref reads the target of an href, which may be a path or an absolute URL.
+func parseHref(href string) (target, bool) {
+	u, err := url.Parse(strings.TrimSpace(href))
+	if err != nil {
+		return target{}, false
+	}
+	return parsePath(path.Clean(u.Path))
+}
+
+func (h *Handler) get(w http.ResponseWriter, r *http.Request, t target) {
+	ev, err := h.event(r.Context(), t)
+	if err != nil {
+		h.fail(w, err)
+		return
+	}
+	data := encodeEvent(ev)
+	tag := etag(data)
+	w.Header().Set("ETag", tag)
+	if matchETag(r.Header.Get("If-None-Match"), tag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", calendarType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(data)))
+	_, _ = w.Write(data)
+}
+
+// put creates or replaces an event. The stored event differs from what was sent, e.g. in its time
+// zone, so the response carries no ETag and clients fetch the event again.
+func (h *Handler) put(w http.ResponseWriter, r *http.Request, t target) {
+	ctx := r.Context()
+	current, err := h.event(ctx, t)
+	if err != nil && status.Code(err) != codes.NotFound {
+		h.fail(w, err)
+		return
+	}
+	if !h.preconditions(w, r, current) {
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxBody))
+	if err != nil {
+		http.Error(w, "read body: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	loc := time.UTC
+	if current != nil {
+		loc = location(current.TimeZone)
+	}
+	ev, err := parseEvent(data, loc)
+	switch {
+	case errors.Is(err, errNoEvent), errors.Is(err, errRecurrence):
+		writeError(w, http.StatusForbidden, xml.Name{Space: nsCalDAV, Local: "supported-calendar-component"})
+		return
+	case err != nil:
+		writeError(w, http.StatusForbidden, xml.Name{Space: nsCalDAV, Local: "valid-calendar-data"})
+		return
+	}
+
+	switch {
+	case current == nil:
+		_, err = h.app.CreateEvent(ctx, createRequest(t, ev))
+		if err == nil {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+	case current.User == t.user:
+		_, err = h.app.EditEvent(ctx, editRequest(current, ev))
+	default:
+		// Attendees only answer the invitation; the rest of the event is the owner's.
+		if answer, ok := ev.Attendees[t.user]; ok && answer != attendeeStatus(current, t.user) {
+			_, err = h.app.RespondToEvent(ctx, &proto.RespondToEventReq{EventId: t.id, User: t.user, Status: answer})
+		}
+	}
+	if err != nil {
+		h.fail(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// delete moves an event the user owns to the trash and declines one the user attends.
+func (h *Handler) delete(w http.ResponseWriter, r *http.Request, t target) {
+	ctx := r.Context()
+	current, err := h.event(ctx, t)
+	if err != nil {
+		h.fail(w, err)
+		return
+	}
+	if !h.preconditions(w, r, current) {
+		return
+	}
+
+	if current.User == t.user {
+		_, err = h.app.DeleteEvent(ctx, &proto.EventByIdReq{EventId: t.id})
+	} else {
+		_, err = h.app.RespondToEvent(ctx, &proto.RespondToEventReq{
+			EventId: t.id,
+			User:    t.user,
+			Status:  proto.AttendeeStatus_ATTENDEE_STATUS_DECLINED,
+		})
+	}
+	if err != nil {
+		h.fail(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// preconditions checks If-Match and If-None-Match against the current event, nil when there is none.
+func (h *Handler) preconditions(w http.ResponseWriter, r *http.Request, current *proto.Event) bool {
+	tag := ""
+	if current != nil {
+		tag = etag(encodeEvent(current))
+	}
+	if m := r.Header.Get("If-Match"); m != "" && (current == nil || !matchETag(m, tag)) {
+		http.Error(w, "the event has changed", http.StatusPreconditionFailed)
+		return false
+	}
+	if m := r.Header.Get("If-None-Match"); m != "" && current != nil && matchETag(m, tag) {
+		http.Error(w, "the event exists", http.StatusPreconditionFailed)
+		return false
+	}
+	return true
+}
+
+// event loads the event of t, which is missing for users that neither own nor attend it.
+func (h *Handler) event(ctx context.Context, t target) (*proto.Event, error) {
+	ev, err := h.app.GetEvent(ctx, &proto.EventByIdReq{EventId: t.id})
+	if err != nil {
+		return nil, err
+	}
+	if ev.User != t.user && attendeeStatus(ev, t.user) == -1 {
+		return nil, status.Error(codes.NotFound, "event not found")
+	}
+	return ev, nil
+}
+
+// events lists the events of user overlapping [start, end); zero bounds are open.
+func (h *Handler) events(ctx context.Context, user string, start, end time.Time) ([]*proto.Event, error) {
+	req := &proto.GetEventListReq{User: &user}
+	if !start.IsZero() {
+		s := start.Format(time.RFC3339)
+		req.Start = &s
+	}
+	if !end.IsZero() {
+		e := end.Format(time.RFC3339)
+		req.End = &e
+	}
+	res, err := h.app.GetEventList(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// The list also keeps events touching the bounds, which a CalDAV time-range excludes.
+	return slices.DeleteFunc(res.Data, func(ev *proto.Event) bool {
+		return !start.IsZero() && !ev.EndTime.AsTime().After(start) ||
+			!end.IsZero() && !ev.Date.AsTime().Before(end)
+	}), nil
+}
+
+// fail answers with the HTTP status of an application error.
+func (h *Handler) fail(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	code := runtime.HTTPStatusFromCode(st.Code())
+	if code >= http.StatusInternalServerError {
+		h.logger.Error("caldav: " + err.Error())
+	}
+	http.Error(w, st.Message(), code)
+}
+
+// attendeeStatus returns the answer of user to ev, or -1 when the user is not invited.
+func attendeeStatus(ev *proto.Event, user string) proto.AttendeeStatus {
+	for _, a := range ev.Attendees {
+		if a.User == user {
+			return a.Status
+		}
+	}
+	return -1
+}
+
+func createRequest(t target, ev *icalEvent) *proto.CreateEventReq {
+	req := &proto.CreateEventReq{
+		Id:        &t.id,
+		Title:     ev.Summary,
+		Date:      timestamppb.New(ev.Start),
+		EndTime:   timestamppb.New(ev.End),
+		User:      t.user,
+		Attendees: attendees(ev, t.user),
+		AllDay:    ev.AllDay,
+	}
+	if ev.Description != "" {
+		req.Description = &ev.Description
+	}
+	if ev.Location != "" {
+		req.Location = &ev.Location
+	}
+	if ev.URL != "" {
+		req.MeetingUrl = &ev.URL
+	}
+	if ev.TimeZone != "" {
+		req.TimeZone = &ev.TimeZone
+	}
+	if ev.Alarm != nil && *ev.Alarm >= 0 {
+		before := goDuration(*ev.Alarm)
+		req.NotifyBefore = &before
+	}
+	return req
+}
+
+// editRequest replaces what a client can change of current with ev. Labels are kept, and so is a
+// reminder the client dropped, as an event cannot lose its reminder.
+func editRequest(current *proto.Event, ev *icalEvent) *proto.EditEventReq {
+	req := &proto.EditEventReq{
+		Id:          current.Id,
+		Title:       &ev.Summary,
+		Date:        timestamppb.New(ev.Start),
+		EndTime:     timestamppb.New(ev.End),
+		Description: &ev.Description,
+		Attendees:   &proto.AttendeeList{Users: attendees(ev, current.User)},
+		AllDay:      &ev.AllDay,
+		Location:    &ev.Location,
+		MeetingUrl:  &ev.URL,
+	}
+	if ev.TimeZone != "" {
+		req.TimeZone = &ev.TimeZone
+	}
+	if ev.Alarm != nil && *ev.Alarm >= 0 {
+		before := goDuration(*ev.Alarm)
+		req.NotifyBefore = &before
+	}
+	return req
+}
+
+// attendees returns the users invited to ev other than its owner.
+func attendees(ev *icalEvent, owner string) []string {
+	users := make([]string, 0, len(ev.Order))
+	for _, u := range ev.Order {
+		if u != owner {
+			users = append(users, u)
+		}
+	}
+	return users
+}
+
+func etag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// matchETag reports whether the If-Match or If-None-Match header value matches tag.
+func matchETag(header, tag string) bool {
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+		if v == "*" || v == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func rootProps(user string) props {
+	return props{
+		propResourceType:         "<D:collection/>",
+		propCurrentUserPrincipal: hrefProp(principalHref(user)),
+	}
+}
+
+func principalProps(user string) props {
+	return props{
+		propResourceType:         "<D:collection/><D:principal/>",
+		propDisplayName:          xmlEscape(user),
+		propCurrentUserPrincipal: hrefProp(principalHref(user)),
+		propPrincipalURL:         hrefProp(principalHref(user)),
+		propCalendarHome:         hrefProp(principalHref(user)),
+		propUserAddresses:        hrefProp(userURN + user),
+	}
+}
+
+func collectionProps(user string, events []*proto.Event) props {
+	return props{
+		propResourceType:         "<D:collection/><C:calendar/>",
+		propDisplayName:          "Calendar",
+		propOwner:                hrefProp(principalHref(user)),
+		propCurrentUserPrincipal: hrefProp(principalHref(user)),
+		propComponents:           `<C:comp name="VEVENT"/>`,
+		propSupportedReports: "<D:supported-report><D:report><C:calendar-query/></D:report></D:supported-report>" +
+			"<D:supported-report><D:report><C:calendar-multiget/></D:report></D:supported-report>",
+		propPrivileges: "<D:privilege><D:read/></D:privilege><D:privilege><D:write/></D:privilege>" +
+			"<D:privilege><D:write-content/></D:privilege><D:privilege><D:bind/></D:privilege>" +
+			"<D:privilege><D:unbind/></D:privilege>",
+		propCTag: ctag(events),
+	}
+}
+
+// eventProps renders the properties of an event resource; calendar-data only when it is named,
+// as allprop leaves it out.
+func eventProps(user string, ev *proto.Event, req propRequest) props {
+	data := encodeEvent(ev)
+	p := props{
+		propResourceType:         "",
+		propCurrentUserPrincipal: hrefProp(principalHref(user)),
+		propETag:                 xmlEscape(etag(data)),
+		propContentType:          xmlEscape(calendarType + "; component=VEVENT"),
+		propContentLength:        fmt.Sprint(len(data)),
+	}
+	if slices.Contains(req.props, propCalendarData) {
+		p[propCalendarData] = xmlEscape(string(data))
+	}
+	return p
+}
+
+// ctag changes whenever an event of the collection is added, changed or removed.
+func ctag(events []*proto.Event) string {
+	tags := make([]string, 0, len(events))
+	for _, ev := range events {
+		tags = append(tags, ev.Id+":"+etag(encodeEvent(ev)))
+	}
+	slices.Sort(tags)
+	return xmlEscape(etag([]byte(strings.Join(tags, ","))))
+}
@@ -0,0 +1,305 @@
+package caldav
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/app"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/controllers"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	memorystorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const eventID = "0c9d4a3b-2e1f-4a6b-8c7d-5e4f3a2b1c0d"
+
+type testServer struct {
+	*httptest.Server
+	app *app.App
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	log := logger.NewLogger("calendar", "test", "fatal")
+	var cfg atomic.Pointer[configuration.Config]
+	cfg.Store(&configuration.Config{})
+	a := app.New(log, controllers.NewCalendarHandler(memorystorage.NewLocalStorage(*log), nil, &cfg, log))
+	srv := httptest.NewServer(NewHandler(a, log))
+	srv.Client().CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	t.Cleanup(srv.Close)
+	return &testServer{Server: srv, app: a}
+}
+
+func (s *testServer) do(t *testing.T, method, path, user, body string, header map[string]string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), method, s.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	if user != "" {
+		req.SetBasicAuth(user, "secret")
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	res, err := s.Client().Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+func readBody(t *testing.T, res *http.Response) string {
+	t.Helper()
+	b, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func calendarObject(summary, start, end string, lines ...string) string {
+	all := append([]string{
+		"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//test//EN", "BEGIN:VEVENT",
+		"UID:" + eventID, "DTSTAMP:20260101T000000Z",
+		"DTSTART:" + start, "DTEND:" + end, "SUMMARY:" + summary,
+	}, lines...)
+	all = append(all, "END:VEVENT", "END:VCALENDAR", "")
+	return strings.Join(all, "\r\n")
+}
+
+func eventPath(user string) string {
+	return eventHref(user, eventID)
+}
+
+func TestDiscovery(t *testing.T) {
+	s := newTestServer(t)
+
+	res := s.do(t, "PROPFIND", WellKnown, owner, "", nil)
+	assert.Equal(t, http.StatusMovedPermanently, res.StatusCode)
+	assert.Equal(t, Prefix, res.Header.Get("Location"))
+
+	res = s.do(t, "PROPFIND", Prefix, "", "", nil)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	assert.NotEmpty(t, res.Header.Get("WWW-Authenticate"))
+
+	res = s.do(t, "PROPFIND", Prefix, owner, `<?xml version="1.0"?>
+<D:propfind xmlns:D="DAV:"><D:prop><D:current-user-principal/></D:prop></D:propfind>`,
+		map[string]string{"Depth": "0"})
+	require.Equal(t, http.StatusMultiStatus, res.StatusCode)
+	assert.Contains(t, readBody(t, res), "<D:current-user-principal><D:href>/caldav/"+owner+"/</D:href>")
+
+	res = s.do(t, "PROPFIND", principalHref(owner), owner, `<?xml version="1.0"?>
+<D:propfind xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop><C:calendar-home-set/><C:calendar-user-address-set/><D:getetag/></D:prop>
+</D:propfind>`, map[string]string{"Depth": "0"})
+	require.Equal(t, http.StatusMultiStatus, res.StatusCode)
+	body := readBody(t, res)
+	assert.Contains(t, body, "<C:calendar-home-set><D:href>/caldav/"+owner+"/</D:href>")
+	assert.Contains(t, body, "<D:href>urn:uuid:"+owner+"</D:href>")
+	assert.Contains(t, body, "<D:getetag/></D:prop><D:status>HTTP/1.1 404 Not Found</D:status>")
+
+	res = s.do(t, "PROPFIND", principalHref(owner), attendee, "", nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res = s.do(t, "PROPFIND", Prefix+"not-a-user/", "", "", nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestPutAndGet(t *testing.T) {
+	s := newTestServer(t)
+
+	res := s.do(t, http.MethodGet, eventPath(owner), owner, "", nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res = s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("Standup", "20260310T093000Z", "20260310T100000Z",
+			"ORGANIZER:urn:uuid:"+owner, "ATTENDEE;PARTSTAT=NEEDS-ACTION:urn:uuid:"+attendee,
+			"BEGIN:VALARM", "ACTION:DISPLAY", "TRIGGER:-PT10M", "END:VALARM"),
+		map[string]string{"If-None-Match": "*", "Content-Type": calendarType})
+	require.Equal(t, http.StatusCreated, res.StatusCode, readBody(t, res))
+
+	ev, err := s.app.GetEvent(context.Background(), &proto.EventByIdReq{EventId: eventID})
+	require.NoError(t, err)
+	assert.Equal(t, "Standup", ev.Title)
+	assert.Equal(t, owner, ev.User)
+	assert.Equal(t, "10m", ev.GetNotifyBefore())
+	require.Len(t, ev.Attendees, 1)
+	assert.Equal(t, attendee, ev.Attendees[0].User)
+
+	res = s.do(t, http.MethodGet, eventPath(owner), owner, "", nil)
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	tag := res.Header.Get("ETag")
+	assert.NotEmpty(t, tag)
+	assert.Equal(t, calendarType, res.Header.Get("Content-Type"))
+	assert.Contains(t, readBody(t, res), "SUMMARY:Standup\r\n")
+
+	res = s.do(t, http.MethodGet, eventPath(owner), owner, "", map[string]string{"If-None-Match": tag})
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+
+	// The attendee sees the event in their own calendar, others do not.
+	res = s.do(t, http.MethodGet, eventPath(attendee), attendee, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	res = s.do(t, http.MethodGet, eventPath("5b7e2c1a-9d3f-4e8b-a6c4-2f1d0e9b8a77"), "", "", nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	// Creating it again fails, as does an edit of a stale version.
+	res = s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("Standup", "20260310T093000Z", "20260310T100000Z"), map[string]string{"If-None-Match": "*"})
+	assert.Equal(t, http.StatusPreconditionFailed, res.StatusCode)
+	res = s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("Standup", "20260310T093000Z", "20260310T100000Z"), map[string]string{"If-Match": `"stale"`})
+	assert.Equal(t, http.StatusPreconditionFailed, res.StatusCode)
+
+	res = s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("Standup moved", "20260311T093000Z", "20260311T100000Z",
+			"ATTENDEE;PARTSTAT=NEEDS-ACTION:urn:uuid:"+attendee),
+		map[string]string{"If-Match": tag})
+	require.Equal(t, http.StatusNoContent, res.StatusCode, readBody(t, res))
+
+	ev, err = s.app.GetEvent(context.Background(), &proto.EventByIdReq{EventId: eventID})
+	require.NoError(t, err)
+	assert.Equal(t, "Standup moved", ev.Title)
+	assert.Equal(t, time.Date(2026, 3, 11, 9, 30, 0, 0, time.UTC), ev.Date.AsTime())
+	assert.Equal(t, "10m", ev.GetNotifyBefore(), "a dropped alarm keeps the reminder")
+
+	res = s.do(t, http.MethodGet, eventPath(owner), owner, "", map[string]string{"If-None-Match": tag})
+	assert.Equal(t, http.StatusOK, res.StatusCode, "the ETag changes with the event")
+}
+
+func TestPutByAttendeeResponds(t *testing.T) {
+	s := newTestServer(t)
+	res := s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("Review", "20260310T093000Z", "20260310T100000Z",
+			"ATTENDEE;PARTSTAT=NEEDS-ACTION:urn:uuid:"+attendee), nil)
+	require.Equal(t, http.StatusCreated, res.StatusCode, readBody(t, res))
+
+	res = s.do(t, http.MethodPut, eventPath(attendee), attendee,
+		calendarObject("Renamed by attendee", "20260310T093000Z", "20260310T100000Z",
+			"ATTENDEE;PARTSTAT=ACCEPTED:urn:uuid:"+attendee), nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode, readBody(t, res))
+
+	ev, err := s.app.GetEvent(context.Background(), &proto.EventByIdReq{EventId: eventID})
+	require.NoError(t, err)
+	assert.Equal(t, "Review", ev.Title)
+	assert.Equal(t, proto.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED, ev.Attendees[0].Status)
+
+	// Deleting it from the attendee's calendar declines.
+	res = s.do(t, http.MethodDelete, eventPath(attendee), attendee, "", nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode)
+	ev, err = s.app.GetEvent(context.Background(), &proto.EventByIdReq{EventId: eventID})
+	require.NoError(t, err)
+	assert.Equal(t, proto.AttendeeStatus_ATTENDEE_STATUS_DECLINED, ev.Attendees[0].Status)
+}
+
+func TestPutRejectsUnsupportedData(t *testing.T) {
+	s := newTestServer(t)
+
+	res := s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("Daily", "20260310T093000Z", "20260310T100000Z", "RRULE:FREQ=DAILY"), nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.Contains(t, readBody(t, res), "<C:supported-calendar-component/>")
+
+	res = s.do(t, http.MethodPut, eventPath(owner), owner, "not a calendar", nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	res = s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("", "20260310T093000Z", "20260310T100000Z"), nil)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode, "the application validates the event")
+
+	res = s.do(t, http.MethodPut, collectionHref(owner)+"standup.ics", owner,
+		calendarObject("Standup", "20260310T093000Z", "20260310T100000Z"), nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+}
+
+func TestReports(t *testing.T) {
+	s := newTestServer(t)
+	res := s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("Standup", "20260310T093000Z", "20260310T100000Z"), nil)
+	require.Equal(t, http.StatusCreated, res.StatusCode, readBody(t, res))
+
+	query := func(start, end string) string {
+		res := s.do(t, "REPORT", collectionHref(owner), owner, `<?xml version="1.0"?>
+<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop><D:getetag/><C:calendar-data/></D:prop>
+  <C:filter><C:comp-filter name="VCALENDAR"><C:comp-filter name="VEVENT">
+    <C:time-range start="`+start+`" end="`+end+`"/>
+  </C:comp-filter></C:comp-filter></C:filter>
+</C:calendar-query>`, map[string]string{"Depth": "1"})
+		require.Equal(t, http.StatusMultiStatus, res.StatusCode)
+		return readBody(t, res)
+	}
+
+	body := query("20260310T000000Z", "20260311T000000Z")
+	assert.Contains(t, body, "<D:href>"+eventPath(owner)+"</D:href>")
+	assert.Contains(t, body, "SUMMARY:Standup")
+	assert.NotContains(t, query("20260310T100000Z", "20260311T000000Z"), "<D:response>",
+		"an event ending at the start of the range is outside it")
+	assert.NotContains(t, query("20260301T000000Z", "20260310T093000Z"), "<D:response>")
+
+	missing := collectionHref(owner) + "5b7e2c1a-9d3f-4e8b-a6c4-2f1d0e9b8a77.ics"
+	res = s.do(t, "REPORT", collectionHref(owner), owner, `<?xml version="1.0"?>
+<C:calendar-multiget xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop><D:getetag/></D:prop>
+  <D:href>`+eventPath(owner)+`</D:href>
+  <D:href>`+missing+`</D:href>
+</C:calendar-multiget>`, nil)
+	require.Equal(t, http.StatusMultiStatus, res.StatusCode)
+	body = readBody(t, res)
+	assert.Contains(t, body, "<D:href>"+eventPath(owner)+"</D:href><D:propstat><D:prop><D:getetag>")
+	assert.Contains(t, body, "<D:href>"+missing+"</D:href><D:status>HTTP/1.1 404 Not Found</D:status>")
+
+	res = s.do(t, "REPORT", collectionHref(owner), owner,
+		`<D:sync-collection xmlns:D="DAV:"><D:sync-token/></D:sync-collection>`, nil)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+	assert.Contains(t, readBody(t, res), "<D:supported-report/>")
+}
+
+func TestCollectionAndDelete(t *testing.T) {
+	s := newTestServer(t)
+	propfind := `<?xml version="1.0"?>
+<D:propfind xmlns:D="DAV:" xmlns:CS="http://calendarserver.org/ns/">
+  <D:prop><D:resourcetype/><D:getetag/><CS:getctag/></D:prop>
+</D:propfind>`
+	ctagOf := func(body string) string {
+		_, after, ok := strings.Cut(body, "<CS:getctag>")
+		require.True(t, ok, body)
+		tag, _, _ := strings.Cut(after, "<")
+		return tag
+	}
+
+	res := s.do(t, "PROPFIND", collectionHref(owner), owner, propfind, map[string]string{"Depth": "1"})
+	require.Equal(t, http.StatusMultiStatus, res.StatusCode)
+	body := readBody(t, res)
+	assert.Contains(t, body, "<D:resourcetype><D:collection/><C:calendar/></D:resourcetype>")
+	empty := ctagOf(body)
+
+	res = s.do(t, http.MethodPut, eventPath(owner), owner,
+		calendarObject("Standup", "20260310T093000Z", "20260310T100000Z"), nil)
+	require.Equal(t, http.StatusCreated, res.StatusCode, readBody(t, res))
+
+	res = s.do(t, "PROPFIND", collectionHref(owner), owner, propfind, map[string]string{"Depth": "1"})
+	body = readBody(t, res)
+	assert.Contains(t, body, "<D:href>"+eventPath(owner)+"</D:href>")
+	assert.NotEqual(t, empty, ctagOf(body))
+
+	res = s.do(t, http.MethodDelete, eventPath(owner), owner, "", map[string]string{"If-Match": `"stale"`})
+	assert.Equal(t, http.StatusPreconditionFailed, res.StatusCode)
+	res = s.do(t, http.MethodDelete, eventPath(owner), owner, "", nil)
+	assert.Equal(t, http.StatusNoContent, res.StatusCode)
+	res = s.do(t, http.MethodGet, eventPath(owner), owner, "", nil)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	res = s.do(t, "PROPFIND", collectionHref(owner), owner, propfind, map[string]string{"Depth": "1"})
+	body = readBody(t, res)
+	assert.NotContains(t, body, eventPath(owner))
+	assert.Equal(t, empty, ctagOf(body))
+
+	res = s.do(t, http.MethodOptions, collectionHref(owner), owner, "", nil)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Contains(t, res.Header.Get("DAV"), "calendar-access")
+}
@@ -0,0 +1,536 @@
+package caldav
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+)
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+	utcLayout      = "20060102T150405Z"
+	// maxLine is the longest content line in octets before it is folded.
+	maxLine = 75
+	// userURN prefixes the calendar user addresses of organizers and attendees.
+	userURN = "urn:uuid:"
+)
+
+var (
+	errNoEvent    = errors.New("no VEVENT in the calendar object")
+	errRecurrence = errors.New("recurring events are not supported")
+)
+
+var partStatToProto = map[string]proto.AttendeeStatus{
+	"NEEDS-ACTION": proto.AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION,
+	"TENTATIVE":    proto.AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION,
+	"ACCEPTED":     proto.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED,
+	"DECLINED":     proto.AttendeeStatus_ATTENDEE_STATUS_DECLINED,
+}
+
+var partStatFromProto = map[proto.AttendeeStatus]string{
+	proto.AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION: "NEEDS-ACTION",
+	proto.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED:     "ACCEPTED",
+	proto.AttendeeStatus_ATTENDEE_STATUS_DECLINED:     "DECLINED",
+}
+
+// encodeEvent renders ev as an iCalendar object (RFC 5545) holding a single VEVENT. Timed
+// events are written in UTC, so no VTIMEZONE is needed; all-day ones as dates of their zone.
+// The output depends on the event only, which keeps the ETag stable between requests.
+func encodeEvent(ev *proto.Event) []byte {
+	var w icalWriter
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", "-//calendar//caldav//EN")
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", ev.Id)
+	// The storage keeps no modification time; the start keeps DTSTAMP, and so the ETag, stable.
+	w.line("DTSTAMP", ev.Date.AsTime().UTC().Format(utcLayout))
+	if ev.AllDay {
+		loc := location(ev.TimeZone)
+		w.line("DTSTART;VALUE=DATE", ev.Date.AsTime().In(loc).Format(dateLayout))
+		w.line("DTEND;VALUE=DATE", ev.EndTime.AsTime().In(loc).Format(dateLayout))
+	} else {
+		w.line("DTSTART", ev.Date.AsTime().UTC().Format(utcLayout))
+		w.line("DTEND", ev.EndTime.AsTime().UTC().Format(utcLayout))
+	}
+	w.line("SUMMARY", escapeText(ev.Title))
+	if ev.GetDescription() != "" {
+		w.line("DESCRIPTION", escapeText(ev.GetDescription()))
+	}
+	if ev.GetLocation() != "" {
+		w.line("LOCATION", escapeText(ev.GetLocation()))
+	}
+	if ev.GetMeetingUrl() != "" {
+		w.line("URL", ev.GetMeetingUrl())
+	}
+	if len(ev.Labels) > 0 {
+		labels := make([]string, 0, len(ev.Labels))
+		for _, l := range ev.Labels {
+			labels = append(labels, escapeText(l))
+		}
+		w.line("CATEGORIES", strings.Join(labels, ","))
+	}
+	if len(ev.Attendees) > 0 {
+		w.line("ORGANIZER", userURN+ev.User)
+		for _, a := range ev.Attendees {
+			w.line("ATTENDEE;PARTSTAT="+partStatFromProto[a.Status], userURN+a.User)
+		}
+	}
+	if before, ok := notifyBefore(ev.GetNotifyBefore()); ok {
+		w.line("BEGIN", "VALARM")
+		w.line("ACTION", "DISPLAY")
+		w.line("DESCRIPTION", escapeText(ev.Title))
+		w.line("TRIGGER", "-"+formatDuration(before))
+		w.line("END", "VALARM")
+	}
+	w.line("END", "VEVENT")
+	w.line("END", "VCALENDAR")
+	return w.buf.Bytes()
+}
+
+// icalEvent is what a client sent for an event.
+type icalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Location    string
+	Start, End  time.Time
+	AllDay      bool
+	// URL is kept only when it is an http or https link, the only ones a meeting URL may be.
+	URL string
+	// TimeZone is the TZID of DTSTART, empty when the start is in UTC, floating or a date.
+	TimeZone string
+	// Alarm is how long before the start the first VALARM fires, nil without one.
+	Alarm     *time.Duration
+	Organizer string
+	// Attendees maps the users addressed as urn:uuid: to their PARTSTAT; other addresses are dropped.
+	Attendees map[string]proto.AttendeeStatus
+	// Order lists the keys of Attendees as they came.
+	Order []string
+}
+
+// parseEvent reads the single VEVENT of an iCalendar object. Times without a zone are read in
+// UTC; dates of all-day events in loc.
+func parseEvent(data []byte, loc *time.Location) (*icalEvent, error) {
+	lines, err := unfold(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var ev *icalEvent
+	var stack []string
+	var start, end, duration *contentLine
+	events := 0
+	for i := range lines {
+		l := &lines[i]
+		switch l.name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(l.value))
+			if len(stack) == 2 && stack[1] == "VEVENT" {
+				events++
+				ev = &icalEvent{Attendees: make(map[string]proto.AttendeeStatus)}
+			}
+			continue
+		case "END":
+			if len(stack) == 0 {
+				return nil, fmt.Errorf("line %d: END:%s without BEGIN", l.num, l.value)
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if ev == nil || events > 1 {
+			continue
+		}
+
+		switch {
+		case len(stack) == 2 && stack[1] == "VEVENT":
+			switch l.name {
+			case "UID":
+				ev.UID = l.value
+			case "SUMMARY":
+				ev.Summary = unescapeText(l.value)
+			case "DESCRIPTION":
+				ev.Description = unescapeText(l.value)
+			case "LOCATION":
+				ev.Location = unescapeText(l.value)
+			case "URL":
+				if u := strings.ToLower(l.value); strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://") {
+					ev.URL = l.value
+				}
+			case "DTSTART":
+				start = l
+			case "DTEND":
+				end = l
+			case "DURATION":
+				duration = l
+			case "ORGANIZER":
+				ev.Organizer = userAddress(l.value)
+			case "ATTENDEE":
+				if user := userAddress(l.value); user != "" {
+					if _, ok := ev.Attendees[user]; !ok {
+						ev.Order = append(ev.Order, user)
+					}
+					ev.Attendees[user] = partStatToProto[strings.ToUpper(l.params["PARTSTAT"])]
+				}
+			case "RRULE", "RDATE", "RECURRENCE-ID":
+				return nil, errRecurrence
+			}
+		case len(stack) == 3 && stack[1] == "VEVENT" && stack[2] == "VALARM" && l.name == "TRIGGER" &&
+			ev.Alarm == nil:
+			ev.Alarm, err = parseTrigger(l, start, loc)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(stack) != 0 {
+		return nil, fmt.Errorf("BEGIN:%s without END", stack[len(stack)-1])
+	}
+	if ev == nil {
+		return nil, errNoEvent
+	}
+	if events > 1 {
+		return nil, errRecurrence
+	}
+	if start == nil {
+		return nil, errors.New("VEVENT without DTSTART")
+	}
+
+	ev.AllDay = strings.EqualFold(start.params["VALUE"], "DATE")
+	ev.TimeZone = start.params["TZID"]
+	if ev.Start, err = parseTime(start, loc); err != nil {
+		return nil, err
+	}
+	switch {
+	case end != nil:
+		ev.End, err = parseTime(end, loc)
+	case duration != nil:
+		var d time.Duration
+		d, err = parseDuration(duration.value)
+		ev.End = ev.Start.Add(d)
+	case ev.AllDay:
+		ev.End = ev.Start.AddDate(0, 0, 1)
+	default:
+		ev.End = ev.Start
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// parseTrigger returns how long before the start of the event the alarm fires.
+func parseTrigger(l, start *contentLine, loc *time.Location) (*time.Duration, error) {
+	if strings.EqualFold(l.params["VALUE"], "DATE-TIME") {
+		if start == nil {
+			return nil, nil
+		}
+		at, err := parseTime(l, time.UTC)
+		if err != nil {
+			return nil, err
+		}
+		begin, err := parseTime(start, loc)
+		if err != nil {
+			return nil, err
+		}
+		before := begin.Sub(at)
+		return &before, nil
+	}
+	if strings.EqualFold(l.params["RELATED"], "END") {
+		// Alarms relative to the end are not kept.
+		return nil, nil
+	}
+	d, err := parseDuration(l.value)
+	if err != nil {
+		return nil, err
+	}
+	before := -d
+	return &before, nil
+}
+
+func parseTime(l *contentLine, loc *time.Location) (time.Time, error) {
+	if strings.EqualFold(l.params["VALUE"], "DATE") {
+		t, err := time.ParseInLocation(dateLayout, l.value, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%s: %w", l.name, err)
+		}
+		return t, nil
+	}
+	if strings.HasSuffix(l.value, "Z") {
+		t, err := time.Parse(utcLayout, l.value)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("%s: %w", l.name, err)
+		}
+		return t, nil
+	}
+	zone := time.UTC
+	if tzid := l.params["TZID"]; tzid != "" {
+		var err error
+		if zone, err = time.LoadLocation(tzid); err != nil {
+			return time.Time{}, fmt.Errorf("%s: unknown time zone %q", l.name, tzid)
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, l.value, zone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s: %w", l.name, err)
+	}
+	return t, nil
+}
+
+// parseDuration reads an RFC 5545 duration such as -PT15M, P1D or P1W.
+func parseDuration(s string) (time.Duration, error) {
+	in := s
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	if !strings.HasPrefix(s, "P") || len(s) < 3 {
+		return 0, fmt.Errorf("invalid duration %q", in)
+	}
+	s = s[1:]
+
+	var d time.Duration
+	inTime := false
+	for s != "" {
+		if s[0] == 'T' {
+			inTime, s = true, s[1:]
+			continue
+		}
+		i := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid duration %q", in)
+		}
+		n, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", in)
+		}
+		unit := durationUnit(inTime, s[i])
+		if unit == 0 {
+			return 0, fmt.Errorf("invalid duration %q", in)
+		}
+		d += time.Duration(n) * unit
+		s = s[i+1:]
+	}
+	return sign * d, nil
+}
+
+// durationUnit returns the length of a unit letter of a duration, or 0 if it is none.
+func durationUnit(inTime bool, c byte) time.Duration {
+	switch {
+	case !inTime && c == 'W':
+		return 7 * 24 * time.Hour
+	case !inTime && c == 'D':
+		return 24 * time.Hour
+	case inTime && c == 'H':
+		return time.Hour
+	case inTime && c == 'M':
+		return time.Minute
+	case inTime && c == 'S':
+		return time.Second
+	}
+	return 0
+}
+
+// formatDuration writes a non-negative duration the way parseDuration reads it.
+func formatDuration(d time.Duration) string {
+	var b strings.Builder
+	b.WriteString("P")
+	if days := d / (24 * time.Hour); days > 0 {
+		fmt.Fprintf(&b, "%dD", days)
+		d -= days * 24 * time.Hour
+	}
+	if d > 0 || b.Len() == 1 {
+		b.WriteString("T")
+		h, m, s := d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second
+		if h > 0 {
+			fmt.Fprintf(&b, "%dH", h)
+		}
+		if m > 0 {
+			fmt.Fprintf(&b, "%dM", m)
+		}
+		if s > 0 || h == 0 && m == 0 {
+			fmt.Fprintf(&b, "%dS", s)
+		}
+	}
+	return b.String()
+}
+
+// notifyBefore reads the notify_before of an event, which is a Go duration.
+func notifyBefore(s string) (time.Duration, bool) {
+	if s == "" {
+		return 0, false
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, false
+	}
+	return d, true
+}
+
+// goDuration writes d as notify_before, without the zero units time.Duration.String adds.
+func goDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// userAddress returns the user of a urn:uuid: calendar user address, empty for other addresses.
+func userAddress(value string) string {
+	if len(value) > len(userURN) && strings.EqualFold(value[:len(userURN)], userURN) {
+		return strings.ToLower(value[len(userURN):])
+	}
+	return ""
+}
+
+func location(tz string) *time.Location {
+	if tz == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+var textEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
+
+func unescapeText(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+type icalWriter struct {
+	buf bytes.Buffer
+}
+
+// line writes a content line, folded after maxLine octets without splitting a character.
+func (w *icalWriter) line(name, value string) {
+	s := name + ":" + value
+	width := 0
+	for len(s) > 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		if width+size > maxLine {
+			w.buf.WriteString("\r\n ")
+			width = 1
+		}
+		w.buf.WriteString(s[:size])
+		width += size
+		s = s[size:]
+	}
+	w.buf.WriteString("\r\n")
+}
+
+type contentLine struct {
+	num    int
+	name   string
+	params map[string]string
+	value  string
+}
+
+// unfold splits data into content lines, joining folded ones and parsing names and parameters.
+func unfold(data []byte) ([]contentLine, error) {
+	var raw []string
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 0, 64<<10), 1<<20)
+	for sc.Scan() {
+		text := strings.TrimSuffix(sc.Text(), "\r")
+		if len(raw) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			raw[len(raw)-1] += text[1:]
+			continue
+		}
+		if text != "" {
+			raw = append(raw, text)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+
+	lines := make([]contentLine, 0, len(raw))
+	for i, text := range raw {
+		l, err := parseLine(text)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		l.num = i + 1
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// parseLine splits NAME;PARAM=value;PARAM="quoted":value.
+func parseLine(text string) (contentLine, error) {
+	l := contentLine{params: make(map[string]string)}
+	i := strings.IndexAny(text, ";:")
+	if i <= 0 {
+		return l, fmt.Errorf("malformed content line %q", text)
+	}
+	l.name = strings.ToUpper(text[:i])
+	for text[i] == ';' {
+		text = text[i+1:]
+		eq := strings.IndexByte(text, '=')
+		if eq <= 0 {
+			return l, fmt.Errorf("malformed parameter in %s", l.name)
+		}
+		key := strings.ToUpper(text[:eq])
+		text = text[eq+1:]
+		var value string
+		if strings.HasPrefix(text, `"`) {
+			q := strings.IndexByte(text[1:], '"')
+			if q < 0 {
+				return l, fmt.Errorf("unterminated quote in %s", l.name)
+			}
+			value, text = text[1:q+1], text[q+2:]
+			i = 0
+		} else {
+			i = strings.IndexAny(text, ";:")
+			if i < 0 {
+				return l, fmt.Errorf("malformed parameter in %s", l.name)
+			}
+			value = text[:i]
+		}
+		if len(text) == i {
+			return l, fmt.Errorf("missing value of %s", l.name)
+		}
+		l.params[key] = value
+		if text[i] != ';' && text[i] != ':' {
+			return l, fmt.Errorf("malformed parameter in %s", l.name)
+		}
+	}
+	l.value = text[i+1:]
+	return l, nil
+}
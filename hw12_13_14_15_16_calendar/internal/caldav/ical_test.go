@@ -0,0 +1,185 @@
+package caldav
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	owner    = "3f2c5a9e-1d4b-4c8e-9a7f-0b6d2e1c4a55"
+	attendee = "8d1e7b42-6c3a-4f9d-b2e5-7a0c9f3d1b66"
+)
+
+func TestEncodeEventRoundTrip(t *testing.T) {
+	start := time.Date(2026, 3, 10, 9, 30, 0, 0, time.UTC)
+	before := "15m"
+	description := "Agenda; notes, and\nmore"
+	location, meeting := "Room 4, floor 2", "https://meet.example.com/standup"
+	ev := &proto.Event{
+		Id:           "0c9d4a3b-2e1f-4a6b-8c7d-5e4f3a2b1c0d",
+		Title:        "Standup, daily",
+		Date:         timestamppb.New(start),
+		EndTime:      timestamppb.New(start.Add(30 * time.Minute)),
+		Description:  &description,
+		User:         owner,
+		NotifyBefore: &before,
+		Attendees: []*proto.Attendee{
+			{User: attendee, Status: proto.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED},
+		},
+		Labels:     []string{"work"},
+		Location:   &location,
+		MeetingUrl: &meeting,
+	}
+
+	data := encodeEvent(ev)
+	assert.Contains(t, string(data), "DTSTART:20260310T093000Z\r\n")
+	assert.Contains(t, string(data), "SUMMARY:Standup\\, daily\r\n")
+	assert.Contains(t, string(data), "TRIGGER:-PT15M\r\n")
+	assert.Contains(t, string(data), "ATTENDEE;PARTSTAT=ACCEPTED:urn:uuid:"+attendee+"\r\n")
+	assert.Contains(t, string(data), "LOCATION:Room 4\\, floor 2\r\n")
+	assert.Contains(t, string(data), "URL:"+meeting+"\r\n")
+	assert.Equal(t, data, encodeEvent(ev))
+
+	got, err := parseEvent(data, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, ev.Id, got.UID)
+	assert.Equal(t, ev.Title, got.Summary)
+	assert.Equal(t, description, got.Description)
+	assert.Equal(t, location, got.Location)
+	assert.Equal(t, meeting, got.URL)
+	assert.True(t, start.Equal(got.Start))
+	assert.True(t, start.Add(30*time.Minute).Equal(got.End))
+	assert.False(t, got.AllDay)
+	assert.Empty(t, got.TimeZone)
+	require.NotNil(t, got.Alarm)
+	assert.Equal(t, 15*time.Minute, *got.Alarm)
+	assert.Equal(t, owner, got.Organizer)
+	assert.Equal(t, []string{attendee}, got.Order)
+	assert.Equal(t, proto.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED, got.Attendees[attendee])
+}
+
+func TestEncodeAllDayEvent(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	start := time.Date(2026, 7, 1, 0, 0, 0, 0, berlin)
+	ev := &proto.Event{
+		Id:       "0c9d4a3b-2e1f-4a6b-8c7d-5e4f3a2b1c0d",
+		Title:    "Holiday",
+		Date:     timestamppb.New(start),
+		EndTime:  timestamppb.New(start.AddDate(0, 0, 2)),
+		User:     owner,
+		TimeZone: "Europe/Berlin",
+		AllDay:   true,
+	}
+
+	data := string(encodeEvent(ev))
+	assert.Contains(t, data, "DTSTART;VALUE=DATE:20260701\r\n")
+	assert.Contains(t, data, "DTEND;VALUE=DATE:20260703\r\n")
+	assert.NotContains(t, data, "ORGANIZER")
+	assert.NotContains(t, data, "VALARM")
+
+	got, err := parseEvent([]byte(data), berlin)
+	require.NoError(t, err)
+	assert.True(t, got.AllDay)
+	assert.True(t, start.Equal(got.Start))
+	assert.True(t, start.AddDate(0, 0, 2).Equal(got.End))
+}
+
+func TestEncodeFoldsLongLines(t *testing.T) {
+	ev := &proto.Event{
+		Id:      "0c9d4a3b-2e1f-4a6b-8c7d-5e4f3a2b1c0d",
+		Title:   strings.Repeat("Grüße ", 40),
+		Date:    timestamppb.New(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		EndTime: timestamppb.New(time.Date(2026, 1, 1, 1, 0, 0, 0, time.UTC)),
+		User:    owner,
+	}
+
+	data := encodeEvent(ev)
+	for _, line := range strings.Split(string(data), "\r\n") {
+		assert.LessOrEqual(t, len(line), maxLine, line)
+	}
+	got, err := parseEvent(data, time.UTC)
+	require.NoError(t, err)
+	assert.Equal(t, ev.Title, got.Summary)
+}
+
+func TestParseEvent(t *testing.T) {
+	t.Run("time zone and duration", func(t *testing.T) {
+		got, err := parseEvent([]byte(strings.Join([]string{
+			"BEGIN:VCALENDAR",
+			"BEGIN:VEVENT",
+			"UID:abc",
+			"DTSTART;TZID=Europe/Berlin:20260310T093000",
+			"DURATION:PT1H30M",
+			"SUMMARY:Review",
+			"BEGIN:VALARM",
+			"TRIGGER;RELATED=START:-P1D",
+			"END:VALARM",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		}, "\r\n")), time.UTC)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Europe/Berlin", got.TimeZone)
+		assert.Equal(t, time.Date(2026, 3, 10, 8, 30, 0, 0, time.UTC), got.Start.UTC())
+		assert.Equal(t, 90*time.Minute, got.End.Sub(got.Start))
+		require.NotNil(t, got.Alarm)
+		assert.Equal(t, 24*time.Hour, *got.Alarm)
+	})
+
+	t.Run("folded line", func(t *testing.T) {
+		got, err := parseEvent([]byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20260310T093000Z\r\n"+
+			"SUMMARY:Long \r\n title\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"), time.UTC)
+		require.NoError(t, err)
+		assert.Equal(t, "Long title", got.Summary)
+		assert.Equal(t, got.Start, got.End)
+	})
+
+	t.Run("recurring", func(t *testing.T) {
+		_, err := parseEvent([]byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:20260310T093000Z\r\n"+
+			"RRULE:FREQ=DAILY\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"), time.UTC)
+		assert.ErrorIs(t, err, errRecurrence)
+	})
+
+	t.Run("no event", func(t *testing.T) {
+		_, err := parseEvent([]byte("BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nEND:VTODO\r\nEND:VCALENDAR\r\n"), time.UTC)
+		assert.ErrorIs(t, err, errNoEvent)
+	})
+
+	t.Run("no start", func(t *testing.T) {
+		_, err := parseEvent([]byte("BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n"), time.UTC)
+		assert.Error(t, err)
+	})
+}
+
+func TestDurations(t *testing.T) {
+	for _, tc := range []struct {
+		ical string
+		d    time.Duration
+		goes string
+	}{
+		{"PT15M", 15 * time.Minute, "15m"},
+		{"PT1H30M", 90 * time.Minute, "1h30m"},
+		{"PT2H", 2 * time.Hour, "2h"},
+		{"P1D", 24 * time.Hour, "24h"},
+		{"P1W", 7 * 24 * time.Hour, "168h"},
+		{"PT45S", 45 * time.Second, "45s"},
+	} {
+		d, err := parseDuration(tc.ical)
+		require.NoError(t, err, tc.ical)
+		assert.Equal(t, tc.d, d, tc.ical)
+		assert.Equal(t, tc.goes, goDuration(d), tc.ical)
+		back, err := parseDuration(formatDuration(d))
+		require.NoError(t, err, tc.ical)
+		assert.Equal(t, tc.d, back, tc.ical)
+	}
+
+	_, err := parseDuration("15M")
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/attachment"
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/api/httpbody"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultContentType = "application/octet-stream"
+	// downloadChunk is the size of the messages DownloadAttachment sends.
+	downloadChunk = 64 << 10
+)
+
+// UploadAttachment spools content to a temporary file while hashing and measuring it, so that an
+// oversized upload never reaches the store, then puts it in the store and records it.
+func (c CalendarHandler) UploadAttachment(
+	ctx context.Context,
+	info *proto.UploadAttachmentInfo,
+	content io.Reader,
+) (*proto.Attachment, error) {
+	if c.files == nil {
+		return nil, calendarErrors.ErrAttachmentsDisabled
+	}
+	cfg := c.cfg.Load().System.Attachments
+
+	// Fail early rather than after receiving the whole file; AttachmentCreate checks again.
+	existing, err := c.storage.AttachmentList(ctx, &models.ListAttachmentsReq{EventID: info.EventId})
+	if err != nil {
+		return nil, err
+	}
+	if cfg.MaxPerEvent > 0 && len(existing) >= cfg.MaxPerEvent {
+		return nil, calendarErrors.ErrTooManyAttachments
+	}
+
+	tmp, err := os.CreateTemp("", "calendar-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("spool upload: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(content, cfg.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if size > cfg.MaxSize {
+		return nil, fmt.Errorf("%w: more than %d bytes", calendarErrors.ErrAttachmentTooLarge, cfg.MaxSize)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("spool upload: %w", err)
+	}
+
+	id := uuid.NewString()
+	key := attachment.Key(info.EventId, id)
+	if err := c.files.Put(ctx, key, tmp, size); err != nil {
+		return nil, err
+	}
+
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = defaultContentType
+	}
+	res, err := c.storage.AttachmentCreate(ctx, &models.CreateAttachmentReq{
+		ID:          id,
+		EventID:     info.EventId,
+		Name:        info.Name,
+		ContentType: contentType,
+		Size:        size,
+		SHA256:      hex.EncodeToString(hash.Sum(nil)),
+		UploadedBy:  info.User,
+		MaxPerEvent: cfg.MaxPerEvent,
+	})
+	if err != nil {
+		_ = c.files.Delete(context.WithoutCancel(ctx), key)
+		return nil, err
+	}
+	return AttachmentToProto(res), nil
+}
+
+func (c CalendarHandler) ListAttachments(
+	ctx context.Context,
+	req *proto.EventByIdReq,
+) (*proto.ListAttachmentsRes, error) {
+	if c.files == nil {
+		return nil, calendarErrors.ErrAttachmentsDisabled
+	}
+	res, err := c.storage.AttachmentList(ctx, &models.ListAttachmentsReq{EventID: req.EventId})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.Attachment, 0, len(res))
+	for i := range res {
+		data = append(data, AttachmentToProto(&res[i]))
+	}
+	return &proto.ListAttachmentsRes{Data: data}, nil
+}
+
+func (c CalendarHandler) GetAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*proto.Attachment, error) {
+	if c.files == nil {
+		return nil, calendarErrors.ErrAttachmentsDisabled
+	}
+	res, err := c.storage.AttachmentGet(ctx, &models.AttachmentIDReq{ID: req.AttachmentId})
+	if err != nil {
+		return nil, err
+	}
+	return AttachmentToProto(res), nil
+}
+
+// DownloadAttachment sends the content in chunks; only the first one carries the content type.
+func (c CalendarHandler) DownloadAttachment(
+	ctx context.Context,
+	req *proto.AttachmentByIdReq,
+	send func(*httpbody.HttpBody) error,
+) error {
+	if c.files == nil {
+		return calendarErrors.ErrAttachmentsDisabled
+	}
+	a, err := c.storage.AttachmentGet(ctx, &models.AttachmentIDReq{ID: req.AttachmentId})
+	if err != nil {
+		return err
+	}
+	r, err := c.files.Open(ctx, attachment.Key(a.EventID, a.ID))
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+
+	buf := make([]byte, downloadChunk)
+	first := true
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 || first {
+			msg := &httpbody.HttpBody{Data: buf[:n]}
+			if first {
+				msg.ContentType = a.ContentType
+				first = false
+			}
+			if err := send(msg); err != nil {
+				return err
+			}
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read attachment: %w", err)
+		}
+	}
+}
+
+// DeleteAttachment drops the record before the content, so that a failure halfway leaves at worst
+// unreferenced content, which the attachment.Sweeper deletes later, rather than a record without
+// content. Like publishEvent, it does not fail a request whose change is already made.
+func (c CalendarHandler) DeleteAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*emptypb.Empty, error) {
+	if c.files == nil {
+		return nil, calendarErrors.ErrAttachmentsDisabled
+	}
+	a, err := c.storage.AttachmentGet(ctx, &models.AttachmentIDReq{ID: req.AttachmentId})
+	if err != nil {
+		return nil, err
+	}
+	if err := c.storage.AttachmentDelete(ctx, &models.AttachmentIDReq{ID: a.ID}); err != nil {
+		return nil, err
+	}
+	_ = c.files.Delete(context.WithoutCancel(ctx), attachment.Key(a.EventID, a.ID))
+	return &emptypb.Empty{}, nil
+}
+
+func AttachmentToProto(a *models.Attachment) *proto.Attachment {
+	return &proto.Attachment{
+		Id:          a.ID,
+		EventId:     a.EventID,
+		Name:        a.Name,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		Sha256:      a.SHA256,
+		UploadedBy:  a.UploadedBy,
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+	}
+}
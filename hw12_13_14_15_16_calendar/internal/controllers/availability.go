@@ -0,0 +1,136 @@
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxAvailabilityWindow bounds free/busy and slot lookups so a single request cannot scan years of events.
+const maxAvailabilityWindow = 92 * 24 * time.Hour
+
+func (c CalendarHandler) FreeBusy(ctx context.Context, req *proto.FreeBusyReq) (*proto.FreeBusyRes, error) {
+	start, end := req.Start.AsTime(), req.End.AsTime()
+	if err := checkWindow(start, end); err != nil {
+		return nil, err
+	}
+
+	res, err := c.storage.FreeBusy(ctx, &models.FreeBusyReq{
+		Users: req.Users,
+		Start: start,
+		End:   end,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.UserBusy, 0, len(res))
+	for _, ub := range res {
+		data = append(data, &proto.UserBusy{User: ub.User, Busy: intervalsToProto(ub.Busy)})
+	}
+	return &proto.FreeBusyRes{Data: data}, nil
+}
+
+func (c CalendarHandler) FindSlot(ctx context.Context, req *proto.FindSlotReq) (*proto.FindSlotRes, error) {
+	start, end := req.Start.AsTime(), req.End.AsTime()
+	if err := checkWindow(start, end); err != nil {
+		return nil, err
+	}
+
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid duration %q", req.Duration)
+	}
+
+	wh, err := workingHoursFromProto(req.WorkingHours)
+	if err != nil {
+		return nil, err
+	}
+
+	count := int(req.Count)
+	if count == 0 {
+		count = 1
+	}
+
+	res, err := c.storage.FindSlot(ctx, &models.FindSlotReq{
+		Users:        req.Users,
+		Start:        start,
+		End:          end,
+		Duration:     duration,
+		Count:        count,
+		WorkingHours: wh,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &proto.FindSlotRes{Slots: intervalsToProto(res)}, nil
+}
+
+func checkWindow(start, end time.Time) error {
+	if !start.Before(end) {
+		return status.Errorf(codes.InvalidArgument, "start time must be before end time")
+	}
+	if end.Sub(start) > maxAvailabilityWindow {
+		return status.Errorf(codes.InvalidArgument, "time window must not exceed %s", maxAvailabilityWindow)
+	}
+	return nil
+}
+
+func workingHoursFromProto(wh *proto.WorkingHours) (*models.WorkingHours, error) {
+	if wh == nil {
+		return nil, nil
+	}
+
+	from, err := clockOffset(wh.From)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid working hours start: %v", err)
+	}
+	to, err := clockOffset(wh.To)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid working hours end: %v", err)
+	}
+	if from >= to {
+		return nil, status.Errorf(codes.InvalidArgument, "working hours must start before they end")
+	}
+
+	loc := time.UTC
+	if wh.TimeZone != nil && *wh.TimeZone != "" {
+		loc, err = time.LoadLocation(*wh.TimeZone)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid time zone: %v", err)
+		}
+	}
+
+	res := &models.WorkingHours{From: from, To: to, Location: loc}
+	for _, d := range wh.Weekdays {
+		res.Weekdays = append(res.Weekdays, time.Weekday(d))
+	}
+	return res, nil
+}
+
+// clockOffset converts "HH:MM" to the time elapsed since midnight; "24:00" is the end of the day.
+func clockOffset(clock string) (time.Duration, error) {
+	if clock == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func intervalsToProto(intervals []models.Interval) []*proto.TimeRange {
+	res := make([]*proto.TimeRange, 0, len(intervals))
+	for i := range intervals {
+		res = append(res, &proto.TimeRange{
+			Start: TimestampPtr(&intervals[i].Start),
+			End:   TimestampPtr(&intervals[i].End),
+		})
+	}
+	return res
+}
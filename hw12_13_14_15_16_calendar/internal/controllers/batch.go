@@ -0,0 +1,129 @@
+package controllers
+
+import (
+	"context"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (c CalendarHandler) BatchCreateEvents(
+	ctx context.Context,
+	req *proto.BatchCreateEventsReq,
+) (*proto.BatchEventsRes, error) {
+	return runBatch(ctx, req.Mode, req.Items, func(item *proto.CreateEventReq) models.CreateEventReq {
+		return *createEventReqFromProto(item)
+	}, publishing(c, models.WebhookEventCreated, c.storage.EventBatchCreate))
+}
+
+func (c CalendarHandler) BatchEditEvents(
+	ctx context.Context,
+	req *proto.BatchEditEventsReq,
+) (*proto.BatchEventsRes, error) {
+	return runBatch(ctx, req.Mode, req.Items, func(item *proto.EditEventReq) models.EditEventReq {
+		return *editEventReqFromProto(item)
+	}, publishing(c, models.WebhookEventUpdated, c.storage.EventBatchEdit))
+}
+
+func (c CalendarHandler) BatchDeleteEvents(
+	ctx context.Context,
+	req *proto.BatchDeleteEventsReq,
+) (*proto.BatchEventsRes, error) {
+	reqs := make([]models.EventIDReq, 0, len(req.EventIds))
+	for _, id := range req.EventIds {
+		reqs = append(reqs, models.EventIDReq{ID: id})
+	}
+
+	res, err := c.storage.EventBatchDelete(ctx, reqs, req.Mode == proto.BatchMode_BATCH_MODE_ATOMIC)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*proto.BatchEventResult, 0, len(res))
+	for _, r := range res {
+		// The storage returns the trashed events for the payloads of event.deleted; the response
+		// leaves them out.
+		if r.Err == nil && r.Event != nil {
+			c.publishEvent(ctx, models.WebhookEventDeleted, r.Event)
+		}
+		r.Event = nil
+		results = append(results, batchResultToProto(r))
+	}
+	return &proto.BatchEventsRes{Results: results}, nil
+}
+
+type validatable interface {
+	ValidateAll() error
+}
+
+// runBatch validates every item on its own: an invalid item fails the whole atomic batch,
+// while in best-effort mode it only gets an InvalidArgument result and is not sent to the storage.
+func runBatch[T validatable, R any](
+	ctx context.Context,
+	mode proto.BatchMode,
+	items []T,
+	convert func(T) R,
+	exec func(ctx context.Context, reqs []R, atomic bool) ([]models.BatchResult, error),
+) (*proto.BatchEventsRes, error) {
+	atomic := mode == proto.BatchMode_BATCH_MODE_ATOMIC
+
+	results := make([]*proto.BatchEventResult, len(items))
+	valid := make([]int, 0, len(items))
+	reqs := make([]R, 0, len(items))
+	for i, item := range items {
+		if err := item.ValidateAll(); err != nil {
+			err = calendarErrors.Validation(err)
+			if atomic {
+				return nil, &models.BatchItemError{Index: i, Err: err}
+			}
+			results[i] = batchResultToProto(models.BatchResult{Err: err})
+			continue
+		}
+		valid = append(valid, i)
+		reqs = append(reqs, convert(item))
+	}
+
+	res, err := exec(ctx, reqs, atomic)
+	if err != nil {
+		return nil, err
+	}
+	for j, r := range res {
+		results[valid[j]] = batchResultToProto(r)
+	}
+	return &proto.BatchEventsRes{Results: results}, nil
+}
+
+// publishing wraps a batch storage call to publish the change typ of every event it applied.
+func publishing[R any](
+	c CalendarHandler,
+	typ string,
+	exec func(ctx context.Context, reqs []R, atomic bool) ([]models.BatchResult, error),
+) func(ctx context.Context, reqs []R, atomic bool) ([]models.BatchResult, error) {
+	return func(ctx context.Context, reqs []R, atomic bool) ([]models.BatchResult, error) {
+		res, err := exec(ctx, reqs, atomic)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range res {
+			if r.Err == nil && r.Event != nil {
+				c.publishEvent(ctx, typ, r.Event)
+			}
+		}
+		return res, nil
+	}
+}
+
+func batchResultToProto(r models.BatchResult) *proto.BatchEventResult {
+	if r.Err != nil {
+		return &proto.BatchEventResult{Status: status.Convert(calendarErrors.MakeGrpcError(r.Err)).Proto()}
+	}
+
+	res := &proto.BatchEventResult{Status: status.New(codes.OK, "").Proto()}
+	if r.Event != nil {
+		res.Event = EventToProto(r.Event)
+	}
+	return res
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (c CalendarHandler) CreateCalendar(
+	ctx context.Context,
+	req *proto.CreateCalendarReq,
+) (*proto.UserCalendar, error) {
+	res, err := c.storage.CalendarCreate(ctx, &models.CreateCalendarReq{
+		Owner:       req.Owner,
+		Title:       req.Title,
+		Description: req.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CalendarToProto(res), nil
+}
+
+func (c CalendarHandler) GetCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*proto.UserCalendar, error) {
+	res, err := c.storage.CalendarGet(ctx, &models.CalendarIDReq{ID: req.CalendarId})
+	if err != nil {
+		return nil, err
+	}
+	return CalendarToProto(res), nil
+}
+
+func (c CalendarHandler) ListCalendars(
+	ctx context.Context,
+	req *proto.ListCalendarsReq,
+) (*proto.ListCalendarsRes, error) {
+	res, err := c.storage.CalendarList(ctx, &models.ListCalendarsReq{User: req.User})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.UserCalendar, 0, len(res))
+	for i := range res {
+		data = append(data, CalendarToProto(&res[i]))
+	}
+	return &proto.ListCalendarsRes{Data: data}, nil
+}
+
+// DeleteCalendar publishes event.deleted for every event removed with the calendar; the events
+// already in the trash were announced when they went there.
+func (c CalendarHandler) DeleteCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*emptypb.Empty, error) {
+	removed, err := c.storage.CalendarDelete(ctx, &models.CalendarIDReq{ID: req.CalendarId})
+	if err != nil {
+		return nil, err
+	}
+	for i := range removed {
+		c.publishEvent(ctx, models.WebhookEventDeleted, &removed[i])
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (c CalendarHandler) ShareCalendar(ctx context.Context, req *proto.ShareCalendarReq) (*proto.UserCalendar, error) {
+	res, err := c.storage.CalendarShare(ctx, &models.ShareCalendarReq{
+		CalendarID: req.CalendarId,
+		User:       req.User,
+		Role:       calendarRoleFromProto[req.Role],
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CalendarToProto(res), nil
+}
+
+func (c CalendarHandler) UnshareCalendar(
+	ctx context.Context,
+	req *proto.UnshareCalendarReq,
+) (*proto.UserCalendar, error) {
+	res, err := c.storage.CalendarUnshare(ctx, &models.UnshareCalendarReq{
+		CalendarID: req.CalendarId,
+		User:       req.User,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return CalendarToProto(res), nil
+}
+
+func CalendarToProto(c *models.Calendar) *proto.UserCalendar {
+	res := &proto.UserCalendar{
+		Id:          c.ID,
+		Owner:       c.Owner,
+		Title:       c.Title,
+		Description: c.Description,
+	}
+	for _, sh := range c.Shares {
+		res.Shares = append(res.Shares, &proto.CalendarShare{
+			User: sh.User,
+			Role: calendarRoleToProto[sh.Role],
+		})
+	}
+	return res
+}
+
+var calendarRoleToProto = map[models.CalendarRole]proto.CalendarRole{
+	models.RoleRead:  proto.CalendarRole_CALENDAR_ROLE_READ,
+	models.RoleWrite: proto.CalendarRole_CALENDAR_ROLE_WRITE,
+}
+
+var calendarRoleFromProto = map[proto.CalendarRole]models.CalendarRole{
+	proto.CalendarRole_CALENDAR_ROLE_READ:  models.RoleRead,
+	proto.CalendarRole_CALENDAR_ROLE_WRITE: models.RoleWrite,
+}
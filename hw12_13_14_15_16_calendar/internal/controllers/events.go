@@ -6,7 +6,6 @@ import (
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
-	"go.openly.dev/pointy"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
@@ -14,50 +13,21 @@ import (
 )
 
 func (c CalendarHandler) CreateEvent(ctx context.Context, req *proto.CreateEventReq) (*proto.Event, error) {
-	res, err := c.storage.EventCreate(ctx, &models.CreateEventReq{
-		Title:        req.Title,
-		Date:         req.Date.AsTime(),
-		EndTime:      req.EndTime.AsTime(),
-		Description:  req.Description,
-		User:         req.User,
-		NotifyBefore: req.NotifyBefore,
-	})
+	res, err := c.storage.EventCreate(ctx, createEventReqFromProto(req))
 	if err != nil {
 		return nil, err
 	}
-	return &proto.Event{
-		Id:           res.ID,
-		User:         res.User,
-		NotifyBefore: res.NotifyBefore,
-		EndTime:      timestamppb.New(res.EndTime),
-		Description:  res.Description,
-		Date:         timestamppb.New(res.Date),
-		Title:        res.Title,
-	}, nil
+	c.publishEvent(ctx, models.WebhookEventCreated, res)
+	return EventToProto(res), nil
 }
 
 func (c CalendarHandler) EditEvent(ctx context.Context, req *proto.EditEventReq) (*proto.Event, error) {
-	res, err := c.storage.EventEdit(ctx, &models.EditEventReq{
-		ID:           req.Id,
-		Title:        req.Title,
-		Date:         TimePtr(req.Date),
-		EndTime:      TimePtr(req.EndTime),
-		Description:  req.Description,
-		User:         req.User,
-		NotifyBefore: req.NotifyBefore,
-	})
+	res, err := c.storage.EventEdit(ctx, editEventReqFromProto(req))
 	if err != nil {
 		return nil, err
 	}
-	return &proto.Event{
-		Id:           res.ID,
-		User:         res.User,
-		NotifyBefore: res.NotifyBefore,
-		EndTime:      TimestampPtr(&res.EndTime),
-		Description:  res.Description,
-		Date:         TimestampPtr(&res.Date),
-		Title:        res.Title,
-	}, nil
+	c.publishEvent(ctx, models.WebhookEventUpdated, res)
+	return EventToProto(res), nil
 }
 
 func (c CalendarHandler) GetEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error) {
@@ -67,51 +37,53 @@ func (c CalendarHandler) GetEvent(ctx context.Context, req *proto.EventByIdReq)
 	if err != nil {
 		return nil, err
 	}
-	return &proto.Event{
-		Id:           res.ID,
-		User:         res.User,
-		NotifyBefore: res.NotifyBefore,
-		EndTime:      TimestampPtr(&res.EndTime),
-		Description:  res.Description,
-		Date:         TimestampPtr(&res.Date),
-		Title:        res.Title,
-	}, nil
+	return EventToProto(res), nil
 }
 
 func (c CalendarHandler) DeleteEvent(ctx context.Context, req *proto.EventByIdReq) (*emptypb.Empty, error) {
-	err := c.storage.EventDelete(ctx, &models.EventIDReq{
+	// The event is read first for the payload of event.deleted.
+	ev, err := c.storage.EventGet(ctx, &models.EventIDReq{ID: req.EventId})
+	if err != nil {
+		return nil, err
+	}
+	err = c.storage.EventDelete(ctx, &models.EventIDReq{
 		ID: req.EventId,
 	})
 	if err != nil {
 		return nil, err
 	}
+	c.publishEvent(ctx, models.WebhookEventDeleted, ev)
 	return &emptypb.Empty{}, nil
 }
 
 func (c CalendarHandler) GetEventList(ctx context.Context, req *proto.GetEventListReq) (*proto.GetEventListRes, error) {
-	var start, end time.Time
-	var err error
+	// Bounds left out stay nil, so that the list is open on that side.
+	var start, end *time.Time
 
 	if req.Start != nil {
-		start, err = time.Parse(time.RFC3339, *req.Start)
+		t, err := time.Parse(time.RFC3339, *req.Start)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid start time: %v", err)
 		}
+		start = &t
 	}
 
 	if req.End != nil {
-		end, err = time.Parse(time.RFC3339, *req.End)
+		t, err := time.Parse(time.RFC3339, *req.End)
 		if err != nil {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid end time: %v", err)
 		}
+		end = &t
 	}
 
-	if !start.IsZero() && !end.IsZero() && start.After(end) {
+	if start != nil && end != nil && start.After(*end) {
 		return nil, status.Errorf(codes.InvalidArgument, "start time must not be after end time")
 	}
 	res, err := c.storage.EventGetList(ctx, &models.GetEventListReq{
-		Start: pointy.Pointer(start),
-		End:   pointy.Pointer(end),
+		Start:  start,
+		End:    end,
+		Labels: req.Labels,
+		User:   req.User,
 	})
 	if err != nil {
 		return nil, err
@@ -119,22 +91,176 @@ func (c CalendarHandler) GetEventList(ctx context.Context, req *proto.GetEventLi
 
 	data := make([]*proto.Event, 0, len(res.Data))
 
-	for _, e := range res.Data {
-		data = append(data, &proto.Event{
-			Id:           e.ID,
-			User:         e.User,
-			NotifyBefore: e.NotifyBefore,
-			EndTime:      TimestampPtr(&e.EndTime),
-			Description:  e.Description,
-			Date:         TimestampPtr(&e.Date),
-			Title:        e.Title,
-		})
+	for i := range res.Data {
+		data = append(data, EventToProto(&res.Data[i]))
 	}
 	return &proto.GetEventListRes{
 		Data: data,
 	}, nil
 }
 
+// defaultSearchLimit caps SearchEvents results when the request does not set a limit.
+const defaultSearchLimit = 20
+
+func (c CalendarHandler) SearchEvents(ctx context.Context, req *proto.SearchEventsReq) (*proto.SearchEventsRes, error) {
+	start, end := TimePtr(req.Start), TimePtr(req.End)
+	if start != nil && end != nil && start.After(*end) {
+		return nil, status.Errorf(codes.InvalidArgument, "start time must not be after end time")
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	res, err := c.storage.EventSearch(ctx, &models.SearchEventsReq{
+		Query: req.Query,
+		User:  req.User,
+		Start: start,
+		End:   end,
+		Limit: limit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.SearchHit, 0, len(res))
+	for i := range res {
+		data = append(data, &proto.SearchHit{Event: EventToProto(&res[i].Event), Rank: res[i].Rank})
+	}
+	return &proto.SearchEventsRes{Data: data}, nil
+}
+
+func (c CalendarHandler) ListDeletedEvents(
+	ctx context.Context,
+	req *proto.ListDeletedEventsReq,
+) (*proto.GetEventListRes, error) {
+	res, err := c.storage.EventListDeleted(ctx, &models.ListDeletedEventsReq{User: req.User})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.Event, 0, len(res))
+	for i := range res {
+		data = append(data, EventToProto(&res[i]))
+	}
+	return &proto.GetEventListRes{Data: data}, nil
+}
+
+func (c CalendarHandler) RestoreEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error) {
+	res, err := c.storage.EventRestore(ctx, &models.EventIDReq{ID: req.EventId})
+	if err != nil {
+		return nil, err
+	}
+	c.publishEvent(ctx, models.WebhookEventCreated, res)
+	return EventToProto(res), nil
+}
+
+func (c CalendarHandler) RespondToEvent(ctx context.Context, req *proto.RespondToEventReq) (*proto.Event, error) {
+	res, err := c.storage.EventRespond(ctx, &models.RespondEventReq{
+		EventID: req.EventId,
+		User:    req.User,
+		Status:  attendeeStatusFromProto[req.Status],
+	})
+	if err != nil {
+		return nil, err
+	}
+	c.publishEvent(ctx, models.WebhookEventUpdated, res)
+	return EventToProto(res), nil
+}
+
+func createEventReqFromProto(req *proto.CreateEventReq) *models.CreateEventReq {
+	return &models.CreateEventReq{
+		ID:           req.GetId(),
+		Title:        req.Title,
+		Date:         req.Date.AsTime(),
+		EndTime:      req.EndTime.AsTime(),
+		Description:  req.Description,
+		User:         req.User,
+		NotifyBefore: req.NotifyBefore,
+		CalendarID:   req.CalendarId,
+		Attendees:    req.Attendees,
+		TimeZone:     req.GetTimeZone(),
+		AllDay:       req.AllDay,
+		Labels:       req.Labels,
+		Location:     req.Location,
+		MeetingURL:   req.MeetingUrl,
+	}
+}
+
+func editEventReqFromProto(req *proto.EditEventReq) *models.EditEventReq {
+	return &models.EditEventReq{
+		ID:           req.Id,
+		Title:        req.Title,
+		Date:         TimePtr(req.Date),
+		EndTime:      TimePtr(req.EndTime),
+		Description:  req.Description,
+		User:         req.User,
+		NotifyBefore: req.NotifyBefore,
+		CalendarID:   req.CalendarId,
+		Attendees:    attendeeUsers(req.Attendees),
+		TimeZone:     req.TimeZone,
+		AllDay:       req.AllDay,
+		Labels:       labelNames(req.Labels),
+		Location:     req.Location,
+		MeetingURL:   req.MeetingUrl,
+	}
+}
+
+func EventToProto(e *models.Event) *proto.Event {
+	res := &proto.Event{
+		Id:           e.ID,
+		User:         e.User,
+		NotifyBefore: e.NotifyBefore,
+		EndTime:      TimestampPtr(&e.EndTime),
+		Description:  e.Description,
+		Date:         TimestampPtr(&e.Date),
+		Title:        e.Title,
+		CalendarId:   e.CalendarID,
+		DeletedAt:    TimestampPtr(e.DeletedAt),
+		TimeZone:     e.TimeZone,
+		AllDay:       e.AllDay,
+		Labels:       e.Labels,
+		Location:     e.Location,
+		MeetingUrl:   e.MeetingURL,
+	}
+	for _, a := range e.Attendees {
+		res.Attendees = append(res.Attendees, &proto.Attendee{
+			User:   a.User,
+			Status: attendeeStatusToProto[a.Status],
+		})
+	}
+	return res
+}
+
+var attendeeStatusToProto = map[models.AttendeeStatus]proto.AttendeeStatus{
+	models.AttendeeNeedsAction: proto.AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION,
+	models.AttendeeAccepted:    proto.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED,
+	models.AttendeeDeclined:    proto.AttendeeStatus_ATTENDEE_STATUS_DECLINED,
+}
+
+var attendeeStatusFromProto = map[proto.AttendeeStatus]models.AttendeeStatus{
+	proto.AttendeeStatus_ATTENDEE_STATUS_NEEDS_ACTION: models.AttendeeNeedsAction,
+	proto.AttendeeStatus_ATTENDEE_STATUS_ACCEPTED:     models.AttendeeAccepted,
+	proto.AttendeeStatus_ATTENDEE_STATUS_DECLINED:     models.AttendeeDeclined,
+}
+
+func attendeeUsers(list *proto.AttendeeList) *[]string {
+	if list == nil {
+		return nil
+	}
+	users := list.GetUsers()
+	return &users
+}
+
+func labelNames(list *proto.LabelList) *[]string {
+	if list == nil {
+		return nil
+	}
+	names := list.GetNames()
+	return &names
+}
+
 func TimePtr(ts *timestamppb.Timestamp) *time.Time {
 	if ts == nil {
 		return nil
@@ -1,15 +1,30 @@
 package controllers
 
 import (
+	"sync/atomic"
+
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/attachment"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage"
 )
 
 type CalendarHandler struct {
 	storage storage.Storage
-	cfg     **configuration.Config
+	// files is nil while attachments are disabled.
+	files  attachment.Store
+	cfg    *atomic.Pointer[configuration.Config]
+	logger Logger
+}
+
+type Logger interface {
+	Error(msg string)
 }
 
-func NewCalendarHandler(storage storage.Storage, cfg **configuration.Config) *CalendarHandler {
-	return &CalendarHandler{storage: storage, cfg: cfg}
+func NewCalendarHandler(
+	storage storage.Storage,
+	files attachment.Store,
+	cfg *atomic.Pointer[configuration.Config],
+	logger Logger,
+) *CalendarHandler {
+	return &CalendarHandler{storage: storage, files: files, cfg: cfg, logger: logger}
 }
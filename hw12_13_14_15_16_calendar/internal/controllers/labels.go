@@ -0,0 +1,50 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (c CalendarHandler) CreateLabel(ctx context.Context, req *proto.CreateLabelReq) (*proto.Label, error) {
+	res, err := c.storage.LabelCreate(ctx, &models.CreateLabelReq{
+		Owner: req.User,
+		Name:  req.Name,
+		Color: req.Color,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return LabelToProto(res), nil
+}
+
+func (c CalendarHandler) ListLabels(ctx context.Context, req *proto.ListLabelsReq) (*proto.ListLabelsRes, error) {
+	res, err := c.storage.LabelList(ctx, &models.ListLabelsReq{User: req.User})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.Label, 0, len(res))
+	for i := range res {
+		data = append(data, LabelToProto(&res[i]))
+	}
+	return &proto.ListLabelsRes{Data: data}, nil
+}
+
+func (c CalendarHandler) DeleteLabel(ctx context.Context, req *proto.LabelByIdReq) (*emptypb.Empty, error) {
+	if err := c.storage.LabelDelete(ctx, &models.LabelIDReq{ID: req.LabelId}); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func LabelToProto(l *models.Label) *proto.Label {
+	return &proto.Label{
+		Id:    l.ID,
+		Owner: l.Owner,
+		Name:  l.Name,
+		Color: l.Color,
+	}
+}
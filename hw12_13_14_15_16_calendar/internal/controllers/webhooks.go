@@ -0,0 +1,130 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/webhook"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (c CalendarHandler) CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error) {
+	if err := webhook.CheckURL(req.Url); err != nil {
+		return nil, err
+	}
+	res, err := c.storage.WebhookCreate(ctx, &models.CreateWebhookReq{
+		Owner:  req.User,
+		URL:    req.Url,
+		Secret: req.Secret,
+		Types:  req.Types,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return WebhookToProto(res), nil
+}
+
+func (c CalendarHandler) ListWebhooks(ctx context.Context, req *proto.ListWebhooksReq) (*proto.ListWebhooksRes, error) {
+	res, err := c.storage.WebhookList(ctx, &models.ListWebhooksReq{User: req.User})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.Webhook, 0, len(res))
+	for i := range res {
+		data = append(data, WebhookToProto(&res[i]))
+	}
+	return &proto.ListWebhooksRes{Data: data}, nil
+}
+
+func (c CalendarHandler) DeleteWebhook(ctx context.Context, req *proto.WebhookByIdReq) (*emptypb.Empty, error) {
+	if err := c.storage.WebhookDelete(ctx, &models.WebhookIDReq{ID: req.WebhookId}); err != nil {
+		return nil, err
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// defaultDeliveriesLimit is the number of log entries returned when the request sets none.
+const defaultDeliveriesLimit = 50
+
+func (c CalendarHandler) ListWebhookDeliveries(
+	ctx context.Context,
+	req *proto.ListWebhookDeliveriesReq,
+) (*proto.ListWebhookDeliveriesRes, error) {
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultDeliveriesLimit
+	}
+	res, err := c.storage.WebhookDeliveries(ctx, &models.ListDeliveriesReq{WebhookID: req.WebhookId, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]*proto.WebhookDelivery, 0, len(res))
+	for i := range res {
+		data = append(data, DeliveryToProto(&res[i]))
+	}
+	return &proto.ListWebhookDeliveriesRes{Data: data}, nil
+}
+
+// publishEvent queues a delivery of the change typ of ev for the webhooks of everyone involved
+// in it. The change is already made, so a failure to queue is logged with the event and does not
+// fail the request; the queueing also outlives a request cancelled meanwhile.
+func (c CalendarHandler) publishEvent(ctx context.Context, typ string, ev *models.Event) {
+	payload, err := webhook.NewPayload(typ, EventToProto(ev), time.Now())
+	if err == nil {
+		_, err = c.storage.WebhookEnqueue(context.WithoutCancel(ctx), &models.EnqueueWebhookReq{
+			Type:    typ,
+			Users:   ev.InvolvedUsers(),
+			Payload: payload,
+		})
+	}
+	if err != nil {
+		c.logger.Error(fmt.Sprintf("webhook %s of event %s not queued: %v", typ, ev.ID, err))
+	}
+}
+
+func WebhookToProto(h *models.Webhook) *proto.Webhook {
+	return &proto.Webhook{
+		Id:        h.ID,
+		Owner:     h.Owner,
+		Url:       h.URL,
+		Types:     h.Types,
+		CreatedAt: timestamppb.New(h.CreatedAt),
+	}
+}
+
+func DeliveryToProto(d *models.WebhookDelivery) *proto.WebhookDelivery {
+	return &proto.WebhookDelivery{
+		Id:            d.ID,
+		WebhookId:     d.WebhookID,
+		Type:          d.Type,
+		Payload:       string(d.Payload),
+		Status:        deliveryStatusToProto[d.Status],
+		Attempts:      uint32(d.Attempts),
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+		NextAttemptAt: nextAttemptToProto(d),
+		LastAttemptAt: TimestampPtr(d.LastAttemptAt),
+		ResponseCode:  int32(d.ResponseCode),
+		LastError:     d.LastError,
+		DeliveredAt:   TimestampPtr(d.DeliveredAt),
+	}
+}
+
+// nextAttemptToProto leaves next_attempt_at out once the delivery is settled.
+func nextAttemptToProto(d *models.WebhookDelivery) *timestamppb.Timestamp {
+	if d.Status != models.DeliveryPending {
+		return nil
+	}
+	return timestamppb.New(d.NextAttemptAt)
+}
+
+var deliveryStatusToProto = map[models.DeliveryStatus]proto.DeliveryStatus{
+	models.DeliveryPending:   proto.DeliveryStatus_DELIVERY_STATUS_PENDING,
+	models.DeliveryDelivered: proto.DeliveryStatus_DELIVERY_STATUS_DELIVERED,
+	models.DeliveryFailed:    proto.DeliveryStatus_DELIVERY_STATUS_FAILED,
+}
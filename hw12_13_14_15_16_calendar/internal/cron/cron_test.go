@@ -0,0 +1,70 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNext(t *testing.T) {
+	// 2026-10-19 is a Monday.
+	from := time.Date(2026, 10, 19, 10, 17, 42, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, 10, 19, 10, 18, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC)},
+		{"0 3 * * *", time.Date(2026, 10, 20, 3, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		{"@hourly", time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC)},
+		{"30 8-18/2 * * *", time.Date(2026, 10, 19, 10, 30, 0, 0, time.UTC)},
+		{"0 0 * * 6,7", time.Date(2026, 10, 24, 0, 0, 0, 0, time.UTC)},
+		{"0 0 * * 0", time.Date(2026, 10, 25, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * *", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Both day fields restricted: the 1st of the month or any Friday.
+		{"0 12 1 * 5", time.Date(2026, 10, 23, 12, 0, 0, 0, time.UTC)},
+		{"@yearly", time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			s, err := Parse(tt.expr)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestNextKeepsLocation(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	s, err := Parse("0 3 * * *")
+	require.NoError(t, err)
+
+	next := s.Next(time.Date(2026, 10, 19, 3, 0, 0, 0, loc))
+	require.Equal(t, time.Date(2026, 10, 20, 3, 0, 0, 0, loc), next)
+	require.Equal(t, loc, next.Location())
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"":             "expected 5 fields, got 0",
+		"0 3 * *":      "expected 5 fields, got 4",
+		"60 * * * *":   "minute: 60 out of range 0-59",
+		"0 24 * * *":   "hour: 24 out of range 0-23",
+		"0 0 0 * *":    "day of month: 0 out of range 1-31",
+		"0 0 * 13 *":   "month: 13 out of range 1-12",
+		"0 0 * * 8":    "day of week: 8 out of range 0-7",
+		"*/0 * * * *":  `minute: invalid step "0"`,
+		"10-5 * * * *": `minute: empty range "10-5"`,
+		"a * * * *":    `minute: invalid value "a"`,
+		"0 0 30 2 *":   "never matches",
+	}
+	for expr, want := range tests {
+		_, err := Parse(expr)
+		require.ErrorContains(t, err, want, expr)
+	}
+}
@@ -2,22 +2,195 @@ package errors
 
 import (
 	"errors"
+	"fmt"
+	"strings"
+	"unicode"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
+// Domain is the ErrorInfo domain of every error the calendar reports.
+const Domain = "calendar"
+
 var (
-	ErrDateBusy      = errors.New("event already exists at that time for the user")
-	ErrEventNotFound = errors.New("event not found")
+	ErrDateBusy             = errors.New("event already exists at that time for the user")
+	ErrEventNotFound        = errors.New("event not found")
+	ErrEventExists          = errors.New("event with this id already exists")
+	ErrCalendarNotFound     = errors.New("calendar not found")
+	ErrAttendeeNotFound     = errors.New("user is not invited to the event")
+	ErrCalendarAccessDenied = errors.New("user has no write access to the calendar")
+	ErrInvalidTimeZone      = errors.New("unknown time zone")
+	ErrLabelNotFound        = errors.New("label not found")
+	ErrLabelExists          = errors.New("label with this name already exists")
+	ErrWebhookNotFound      = errors.New("webhook not found")
+	ErrWebhookURLNotAllowed = errors.New("webhook URL must use https and point to a public host")
+	ErrAttachmentNotFound   = errors.New("attachment not found")
+	ErrTooManyAttachments   = errors.New("the event has too many attachments")
+	ErrAttachmentTooLarge   = errors.New("attachment is too large")
+	ErrAttachmentsDisabled  = errors.New("attachments are disabled")
 )
 
+// entry describes how a domain error is reported to API clients. Reasons are part of the API
+// and must not change once released.
+type entry struct {
+	err      error
+	code     codes.Code
+	reason   string
+	resource string
+}
+
+var catalogue = []entry{
+	{ErrEventNotFound, codes.NotFound, "EVENT_NOT_FOUND", "event"},
+	{ErrCalendarNotFound, codes.NotFound, "CALENDAR_NOT_FOUND", "calendar"},
+	{ErrAttendeeNotFound, codes.NotFound, "ATTENDEE_NOT_FOUND", "attendee"},
+	{ErrLabelNotFound, codes.NotFound, "LABEL_NOT_FOUND", "label"},
+	{ErrWebhookNotFound, codes.NotFound, "WEBHOOK_NOT_FOUND", "webhook"},
+	{ErrAttachmentNotFound, codes.NotFound, "ATTACHMENT_NOT_FOUND", "attachment"},
+	{ErrCalendarAccessDenied, codes.PermissionDenied, "CALENDAR_ACCESS_DENIED", "calendar"},
+	{ErrLabelExists, codes.AlreadyExists, "LABEL_EXISTS", "label"},
+	{ErrEventExists, codes.AlreadyExists, "EVENT_EXISTS", "event"},
+	{ErrInvalidTimeZone, codes.InvalidArgument, "INVALID_TIME_ZONE", ""},
+	{ErrWebhookURLNotAllowed, codes.InvalidArgument, "WEBHOOK_URL_NOT_ALLOWED", ""},
+	{ErrDateBusy, codes.AlreadyExists, "DATE_BUSY", "event"},
+	{ErrTooManyAttachments, codes.FailedPrecondition, "TOO_MANY_ATTACHMENTS", "event"},
+	{ErrAttachmentTooLarge, codes.InvalidArgument, "ATTACHMENT_TOO_LARGE", ""},
+	{ErrAttachmentsDisabled, codes.Unimplemented, "ATTACHMENTS_DISABLED", ""},
+}
+
+// NotFoundError names the missing resource of one of the not-found errors.
+type NotFoundError struct {
+	Err error
+	ID  string
+}
+
+func NotFound(err error, id string) error {
+	return &NotFoundError{Err: err, ID: id}
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%v: %s", e.Err, e.ID)
+}
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// ConflictError is an ErrDateBusy naming the event that overlaps.
+type ConflictError struct {
+	EventID string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: overlaps event %s", ErrDateBusy, e.EventID)
+}
+
+func (e *ConflictError) Unwrap() error {
+	return ErrDateBusy
+}
+
+// MakeGrpcError turns a domain error into a status with an ErrorInfo detail carrying its reason,
+// a ResourceInfo naming the missing or conflicting resource where known, and Internal for the rest.
 func MakeGrpcError(err error) error {
-	if errors.Is(err, ErrEventNotFound) {
-		return status.Errorf(codes.NotFound, "event not found: %v", err)
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+
+	for _, e := range catalogue {
+		if !errors.Is(err, e.err) {
+			continue
+		}
+		info := &errdetails.ErrorInfo{Reason: e.reason, Domain: Domain}
+		details := []protoadapt.MessageV1{info}
+
+		var notFound *NotFoundError
+		var conflict *ConflictError
+		switch {
+		case errors.As(err, &notFound) && e.code == codes.NotFound:
+			details = append(details, &errdetails.ResourceInfo{
+				ResourceType: e.resource,
+				ResourceName: notFound.ID,
+				Description:  e.err.Error(),
+			})
+		case errors.As(err, &conflict):
+			info.Metadata = map[string]string{"conflicting_event_id": conflict.EventID}
+			details = append(details, &errdetails.ResourceInfo{
+				ResourceType: e.resource,
+				ResourceName: conflict.EventID,
+				Description:  "overlapping event",
+			})
+		}
+		return withDetails(status.New(e.code, err.Error()), details...)
+	}
+
+	return status.Errorf(codes.Internal, "internal error: %v", err)
+}
+
+// fieldError is implemented by the validation errors generated by protoc-gen-validate.
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// Validation reports a failed request validation as InvalidArgument with a BadRequest detail
+// listing every violated field by its JSON path.
+func Validation(err error) error {
+	st := status.New(codes.InvalidArgument, "validation error: "+err.Error())
+	return withDetails(st, &errdetails.BadRequest{FieldViolations: fieldViolations("", err)})
+}
+
+func fieldViolations(prefix string, err error) []*errdetails.BadRequest_FieldViolation {
+	var multi interface{ AllErrors() []error }
+	if errors.As(err, &multi) {
+		var res []*errdetails.BadRequest_FieldViolation
+		for _, e := range multi.AllErrors() {
+			res = append(res, fieldViolations(prefix, e)...)
+		}
+		return res
 	}
-	if errors.Is(err, ErrDateBusy) {
-		return status.Errorf(codes.AlreadyExists, "date busy: %v", err)
+
+	var fe fieldError
+	if !errors.As(err, &fe) {
+		return []*errdetails.BadRequest_FieldViolation{{Field: prefix, Description: err.Error()}}
+	}
+
+	path := fieldPath(fe.Field())
+	if prefix != "" {
+		path = prefix + "." + path
+	}
+	// Embedded messages report their own violations as the cause.
+	if fe.Cause() != nil {
+		if nested := fieldViolations(path, fe.Cause()); len(nested) > 0 {
+			return nested
+		}
+	}
+	return []*errdetails.BadRequest_FieldViolation{{Field: path, Description: fe.Reason()}}
+}
+
+// fieldPath converts a generated Go field name such as EventIds[1] to its JSON name event_ids[1].
+func fieldPath(field string) string {
+	var b strings.Builder
+	prev := rune(0)
+	for _, r := range field {
+		if unicode.IsUpper(r) {
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+		prev = r
+	}
+	return b.String()
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
 	}
-	return status.Errorf(codes.Internal, "failed to create event: %v", err)
+	return withDetails.Err()
 }
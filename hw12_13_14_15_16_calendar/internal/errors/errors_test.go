@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func details[T any](st *status.Status) []T {
+	var res []T
+	for _, d := range st.Details() {
+		if v, ok := d.(T); ok {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func TestMakeGrpcErrorNotFound(t *testing.T) {
+	err := fmt.Errorf("event get: %w", NotFound(ErrEventNotFound, "42"))
+	st := status.Convert(MakeGrpcError(err))
+
+	assert.Equal(t, codes.NotFound, st.Code())
+	info := details[*errdetails.ErrorInfo](st)
+	require.Len(t, info, 1)
+	assert.Equal(t, "EVENT_NOT_FOUND", info[0].Reason)
+	assert.Equal(t, Domain, info[0].Domain)
+
+	res := details[*errdetails.ResourceInfo](st)
+	require.Len(t, res, 1)
+	assert.Equal(t, "event", res[0].ResourceType)
+	assert.Equal(t, "42", res[0].ResourceName)
+}
+
+func TestMakeGrpcErrorConflict(t *testing.T) {
+	err := fmt.Errorf("event create: %w", &ConflictError{EventID: "7"})
+	require.ErrorIs(t, err, ErrDateBusy)
+
+	st := status.Convert(MakeGrpcError(err))
+	assert.Equal(t, codes.AlreadyExists, st.Code())
+	assert.Contains(t, st.Message(), ErrDateBusy.Error())
+
+	info := details[*errdetails.ErrorInfo](st)
+	require.Len(t, info, 1)
+	assert.Equal(t, "DATE_BUSY", info[0].Reason)
+	assert.Equal(t, "7", info[0].Metadata["conflicting_event_id"])
+
+	res := details[*errdetails.ResourceInfo](st)
+	require.Len(t, res, 1)
+	assert.Equal(t, "7", res[0].ResourceName)
+}
+
+func TestMakeGrpcErrorUnknown(t *testing.T) {
+	st := status.Convert(MakeGrpcError(fmt.Errorf("boom")))
+	assert.Equal(t, codes.Internal, st.Code())
+	assert.Empty(t, st.Details())
+
+	passed := status.Error(codes.Unavailable, "later")
+	assert.Equal(t, codes.Unavailable, status.Code(MakeGrpcError(passed)))
+}
+
+func TestValidation(t *testing.T) {
+	req := &proto.BatchDeleteEventsReq{EventIds: []string{""}, Mode: proto.BatchMode(42)}
+	st := status.Convert(Validation(req.ValidateAll()))
+
+	assert.Equal(t, codes.InvalidArgument, st.Code())
+	assert.Contains(t, st.Message(), "validation error")
+
+	bad := details[*errdetails.BadRequest](st)
+	require.Len(t, bad, 1)
+	fields := make([]string, 0, len(bad[0].FieldViolations))
+	for _, v := range bad[0].FieldViolations {
+		fields = append(fields, v.Field)
+		assert.NotEmpty(t, v.Description)
+	}
+	assert.ElementsMatch(t, []string{"event_ids[0]", "mode"}, fields)
+}
+
+func TestFieldPath(t *testing.T) {
+	assert.Equal(t, "end_time", fieldPath("EndTime"))
+	assert.Equal(t, "event_ids[1]", fieldPath("EventIds[1]"))
+	assert.Equal(t, "title", fieldPath("Title"))
+}
@@ -0,0 +1,70 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// FileLock is an flock on a file, for replicas sharing a host or a volume. The kernel drops it
+// when the holder exits.
+type FileLock struct {
+	path string
+	f    *os.File
+}
+
+func NewFileLock(path string) *FileLock {
+	return &FileLock{path: path}
+}
+
+func (l *FileLock) TryAcquire(context.Context) (bool, error) {
+	f, err := os.OpenFile(l.path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return false, fmt.Errorf("open lock file: %w", err)
+	}
+	if err := syscall.Flock(int(f.Fd()), syscall.LOCK_EX|syscall.LOCK_NB); err != nil {
+		f.Close()
+		if errors.Is(err, syscall.EWOULDBLOCK) {
+			return false, nil
+		}
+		return false, fmt.Errorf("lock %s: %w", l.path, err)
+	}
+
+	// The holder is written for people looking at the file; it plays no part in locking.
+	if err := f.Truncate(0); err == nil {
+		_, _ = f.WriteAt([]byte(Identity()+"\n"), 0)
+	}
+	l.f = f
+	return true, nil
+}
+
+// Check fails when the file was removed or replaced, as a new file can be locked by someone else.
+func (l *FileLock) Check(context.Context) error {
+	if l.f == nil {
+		return errors.New("lock is not held")
+	}
+	held, err := l.f.Stat()
+	if err != nil {
+		return fmt.Errorf("stat lock file: %w", err)
+	}
+	current, err := os.Stat(l.path)
+	if err != nil {
+		return fmt.Errorf("stat lock file: %w", err)
+	}
+	if !os.SameFile(held, current) {
+		return fmt.Errorf("lock file %s was replaced", l.path)
+	}
+	return nil
+}
+
+func (l *FileLock) Release(context.Context) error {
+	if l.f == nil {
+		return nil
+	}
+	f := l.f
+	l.f = nil
+	_ = syscall.Flock(int(f.Fd()), syscall.LOCK_UN)
+	return f.Close()
+}
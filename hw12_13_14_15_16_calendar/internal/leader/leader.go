@@ -0,0 +1,157 @@
+// Package leader elects one of several replicas to run a job.
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+)
+
+// Lock is held by the leader. It must be released by the holder's death, so a crashed leader
+// never blocks the others for good.
+type Lock interface {
+	// TryAcquire takes the lock if it is free and reports whether it did.
+	TryAcquire(ctx context.Context) (bool, error)
+	// Check fails once the held lock may have been lost.
+	Check(ctx context.Context) error
+	Release(ctx context.Context) error
+}
+
+// Elector runs a job on the replica holding the lock. Followers try to take the lock every
+// interval and the leader checks it as often, so leadership fails over within about an interval.
+type Elector struct {
+	lock     Lock
+	interval time.Duration
+	identity string
+	logger   logger.Logger
+
+	mu     sync.Mutex
+	leader bool
+	since  time.Time
+}
+
+func NewElector(lock Lock, interval time.Duration, logg logger.Logger) *Elector {
+	return &Elector{lock: lock, interval: interval, identity: Identity(), logger: logg, since: time.Now()}
+}
+
+// Identity names this replica in logs and health output.
+func Identity() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s/%d", host, os.Getpid())
+}
+
+// Run campaigns until ctx is done. While this replica leads, job runs with a context that is
+// cancelled when leadership is lost. A job returning early makes the replica step down, so
+// another one can take over.
+func (e *Elector) Run(ctx context.Context, job func(ctx context.Context) error) error {
+	e.logger.Info(fmt.Sprintf("campaigning for leadership as %s", e.identity))
+	for {
+		ok, err := e.lock.TryAcquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			e.logger.Warn(fmt.Sprintf("leader lock unavailable: %v", err))
+		}
+		if ok {
+			e.lead(ctx, job)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+func (e *Elector) lead(ctx context.Context, job func(ctx context.Context) error) {
+	e.setLeader(true)
+	e.logger.Info(fmt.Sprintf("%s became the leader", e.identity))
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	done := make(chan error, 1)
+	go func() { done <- job(jobCtx) }()
+
+	check := time.NewTicker(e.interval)
+	defer check.Stop()
+
+loop:
+	for {
+		select {
+		case err := <-done:
+			if ctx.Err() == nil {
+				e.logger.Error(fmt.Sprintf("leader job stopped, stepping down: %v", err))
+			}
+			break loop
+		case <-check.C:
+			if err := e.lock.Check(ctx); err != nil {
+				if ctx.Err() == nil {
+					e.logger.Error(fmt.Sprintf("leadership lost: %v", err))
+				}
+				cancel()
+				<-done
+				break loop
+			}
+		case <-ctx.Done():
+			cancel()
+			<-done
+			break loop
+		}
+	}
+	cancel()
+
+	releaseCtx, cancelRelease := context.WithTimeout(context.Background(), e.interval)
+	defer cancelRelease()
+	if err := e.lock.Release(releaseCtx); err != nil {
+		e.logger.Warn(fmt.Sprintf("release leader lock: %v", err))
+	}
+	e.setLeader(false)
+	e.logger.Info(fmt.Sprintf("%s stepped down", e.identity))
+}
+
+func (e *Elector) setLeader(leader bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.leader = leader
+	e.since = time.Now()
+}
+
+// Status is what health checks report about the election.
+type Status struct {
+	Identity string    `json:"identity"`
+	Role     string    `json:"role"`
+	Since    time.Time `json:"since"`
+}
+
+func (e *Elector) Status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	role := "follower"
+	if e.leader {
+		role = "leader"
+	}
+	return Status{Identity: e.identity, Role: role, Since: e.since}
+}
+
+// ServeHTTP answers health checks with the Status. Followers are healthy too.
+func (e *Elector) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(struct {
+		Health string `json:"status"`
+		Status
+	}{Health: "ok", Status: e.Status()})
+}
+
+// NoLock is always free; a single replica uses it to lead without coordination.
+type NoLock struct{}
+
+func (NoLock) TryAcquire(context.Context) (bool, error) { return true, nil }
+func (NoLock) Check(context.Context) error              { return nil }
+func (NoLock) Release(context.Context) error            { return nil }
@@ -0,0 +1,153 @@
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/stretchr/testify/require"
+)
+
+const interval = 20 * time.Millisecond
+
+func testLogger() logger.Logger {
+	return *logger.NewLogger("scheduler", "test", "fatal")
+}
+
+// replica runs an elector whose job counts how often it is running.
+type replica struct {
+	elector *Elector
+	running atomic.Int32
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+func startReplica(t *testing.T, lock Lock) *replica {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &replica{elector: NewElector(lock, interval, testLogger()), cancel: cancel, done: make(chan struct{})}
+	go func() {
+		defer close(r.done)
+		_ = r.elector.Run(ctx, func(ctx context.Context) error {
+			r.running.Add(1)
+			defer r.running.Add(-1)
+			<-ctx.Done()
+			return nil
+		})
+	}()
+	t.Cleanup(r.stop)
+	return r
+}
+
+func (r *replica) stop() {
+	r.cancel()
+	<-r.done
+}
+
+func (r *replica) leads() bool {
+	return r.elector.Status().Role == "leader" && r.running.Load() == 1
+}
+
+func TestFileLockFailover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.lock")
+	first := startReplica(t, NewFileLock(path))
+	require.Eventually(t, first.leads, time.Second, interval/4)
+
+	second := startReplica(t, NewFileLock(path))
+	time.Sleep(3 * interval)
+	require.Equal(t, "follower", second.elector.Status().Role)
+	require.Zero(t, second.running.Load(), "only the leader runs the job")
+
+	first.stop()
+	require.Zero(t, first.running.Load())
+	require.Equal(t, "follower", first.elector.Status().Role)
+	require.Eventually(t, second.leads, time.Second, interval/4)
+}
+
+func TestFileLockReplaced(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scheduler.lock")
+	lock := NewFileLock(path)
+	ok, err := lock.TryAcquire(context.Background())
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NoError(t, lock.Check(context.Background()))
+
+	ok, err = NewFileLock(path).TryAcquire(context.Background())
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.NoError(t, os.Remove(path))
+	require.Error(t, lock.Check(context.Background()))
+	require.NoError(t, lock.Release(context.Background()))
+}
+
+// flakyLock is acquired once and then reports itself lost.
+type flakyLock struct {
+	lost     atomic.Bool
+	released atomic.Bool
+}
+
+func (l *flakyLock) TryAcquire(context.Context) (bool, error) {
+	return !l.lost.Load(), nil
+}
+
+func (l *flakyLock) Check(context.Context) error {
+	if l.lost.Load() {
+		return errors.New("connection reset")
+	}
+	return nil
+}
+
+func (l *flakyLock) Release(context.Context) error {
+	l.released.Store(true)
+	return nil
+}
+
+func TestStepDownWhenLockIsLost(t *testing.T) {
+	lock := &flakyLock{}
+	r := startReplica(t, lock)
+	require.Eventually(t, r.leads, time.Second, interval/4)
+
+	lock.lost.Store(true)
+	require.Eventually(t, func() bool {
+		return r.running.Load() == 0 && r.elector.Status().Role == "follower"
+	}, time.Second, interval/4)
+	require.True(t, lock.released.Load())
+}
+
+func TestStepDownWhenJobFails(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var runs atomic.Int32
+	e := NewElector(NoLock{}, interval, testLogger())
+	go func() {
+		_ = e.Run(ctx, func(context.Context) error {
+			runs.Add(1)
+			return errors.New("broker unavailable")
+		})
+	}()
+	require.Eventually(t, func() bool { return runs.Load() >= 2 }, time.Second, interval/4)
+}
+
+func TestHealth(t *testing.T) {
+	r := startReplica(t, NoLock{})
+	require.Eventually(t, r.leads, time.Second, interval/4)
+
+	rec := httptest.NewRecorder()
+	r.elector.ServeHTTP(rec, httptest.NewRequest("GET", "/healthz", nil))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Equal(t, "ok", body["status"])
+	require.Equal(t, "leader", body["role"])
+	require.Equal(t, Identity(), body["identity"])
+	require.NotEmpty(t, body["since"])
+}
@@ -2,8 +2,10 @@ package logger
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,7 +33,7 @@ type Logger struct {
 	appName    string
 	appVersion string
 	module     string
-	level      Level
+	level      *atomic.Int32
 	cid        string
 }
 
@@ -46,17 +48,37 @@ type logEntry struct {
 }
 
 func NewLogger(appName, appVersion, level string) *Logger {
-	lvl, ok := levelNames[strings.ToLower(level)]
-	if !ok {
+	lvl, err := ParseLevel(level)
+	if err != nil {
 		lvl = LevelInfo
 	}
-	return &Logger{
+	l := &Logger{
 		appName:    appName,
 		appVersion: appVersion,
 		module:     "core",
-		level:      lvl,
+		level:      new(atomic.Int32),
 		cid:        uuid.New().String(),
 	}
+	l.level.Store(int32(lvl))
+	return l
+}
+
+func ParseLevel(level string) (Level, error) {
+	lvl, ok := levelNames[strings.ToLower(level)]
+	if !ok {
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+	return lvl, nil
+}
+
+// SetLevel changes the level of the logger and of every module logger derived from it.
+func (l Logger) SetLevel(level string) error {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	l.level.Store(int32(lvl))
+	return nil
 }
 
 func (l Logger) WithModule(module string) Logger {
@@ -65,7 +87,7 @@ func (l Logger) WithModule(module string) Logger {
 }
 
 func (l Logger) log(msgLevel Level, levelStr, msg string) {
-	if msgLevel > l.level {
+	if msgLevel > Level(l.level.Load()) {
 		return
 	}
 
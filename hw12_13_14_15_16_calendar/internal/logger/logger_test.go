@@ -1,6 +1,31 @@
 package logger
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
 
 func TestLogger(_ *testing.T) {
 }
+
+func TestParseLevel(t *testing.T) {
+	lvl, err := ParseLevel("WARN")
+	require.NoError(t, err)
+	assert.Equal(t, LevelWarn, lvl)
+
+	_, err = ParseLevel("trace")
+	assert.Error(t, err)
+}
+
+func TestSetLevelAffectsModules(t *testing.T) {
+	root := NewLogger("calendar", "test", "info")
+	module := root.WithModule("app")
+
+	require.NoError(t, root.SetLevel("debug"))
+	assert.Equal(t, LevelDebug, Level(module.level.Load()))
+
+	assert.Error(t, module.SetLevel("verbose"))
+	assert.Equal(t, LevelDebug, Level(root.level.Load()))
+}
@@ -0,0 +1,337 @@
+// Package notify renders notifications into the messages sent to users.
+package notify
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	htmltemplate "html/template"
+	"maps"
+	"slices"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/rmq"
+)
+
+// builtin are the English templates used for the message types the default locale has none configured for.
+var builtin = configuration.LocaleTemplates{
+	Reminder: configuration.MessageTemplates{
+		Subject: `Reminder: {{.Title}}`,
+		Text: `{{.Title}} starts {{if .AllDay}}on {{.Start.Format "Monday, 2 January 2006"}}` +
+			`{{else}}at {{.Start.Format "15:04 Monday, 2 January 2006"}} ({{.TimeZone}}){{end}}.
+{{- with .Location}}
+Where: {{.}}{{end}}
+{{- with .MeetingURL}}
+Join: {{.}}{{end}}
+{{- with .Description}}
+
+{{.}}{{end}}`,
+		HTML: `<p><strong>{{.Title}}</strong> starts {{if .AllDay}}on {{.Start.Format "Monday, 2 January 2006"}}` +
+			`{{else}}at {{.Start.Format "15:04 Monday, 2 January 2006"}} ({{.TimeZone}}){{end}}.</p>
+{{- with .Location}}
+<p>Where: {{.}}</p>{{end}}
+{{- with .MeetingURL}}
+<p><a href="{{.}}">Join the meeting</a></p>{{end}}
+{{- with .Description}}
+<p>{{.}}</p>{{end}}`,
+	},
+	Digest: configuration.MessageTemplates{
+		Subject: `Your agenda for {{.Date.Format "Monday, 2 January"}}`,
+		Text: `Your agenda for {{.Date.Format "Monday, 2 January 2006"}} ({{.TimeZone}}):
+{{range .Events}}
+{{if .AllDay}}all day    {{else}}{{.Start.Format "15:04"}}-{{.End.Format "15:04"}}{{end}}  {{.Title}}
+{{- else}}
+Nothing planned.
+{{- end}}`,
+		HTML: `<p>Your agenda for {{.Date.Format "Monday, 2 January 2006"}} ({{.TimeZone}}):</p>
+<ul>
+{{- range .Events}}
+<li>{{if .AllDay}}all day{{else}}{{.Start.Format "15:04"}}&ndash;{{.End.Format "15:04"}}{{end}} ` +
+			`<strong>{{.Title}}</strong></li>
+{{- else}}
+<li>Nothing planned.</li>
+{{- end}}
+</ul>`,
+	},
+}
+
+// ReminderData is what reminder templates are executed with. Start and End are in the event's time zone.
+type ReminderData struct {
+	EventID      string
+	UserID       string
+	Title        string
+	Description  string
+	Start        time.Time
+	End          time.Time
+	AllDay       bool
+	TimeZone     string
+	NotifyBefore string
+	Locale       string
+	Location     string
+	MeetingURL   string
+}
+
+// DigestData is what digest templates are executed with. Date and the event times are in the user's
+// time zone.
+type DigestData struct {
+	UserID   string
+	Date     time.Time
+	TimeZone string
+	Events   []DigestEvent
+	Locale   string
+}
+
+type DigestEvent struct {
+	EventID     string
+	Title       string
+	Description string
+	Start       time.Time
+	End         time.Time
+	AllDay      bool
+	Location    string
+	MeetingURL  string
+}
+
+// Templates render notifications in the language of their recipient.
+type Templates struct {
+	// sets holds the parsed templates by locale and then by message type.
+	sets          map[string]map[string]*messageTemplates
+	defaultLocale string
+}
+
+type messageTemplates struct {
+	subject, text *texttemplate.Template
+	html          *htmltemplate.Template
+}
+
+// NewTemplates parses the configured templates and renders each of them once with sample data, so
+// references to fields the data does not have are reported at start rather than on the first message.
+func NewTemplates(cfg configuration.TemplatesConf) (*Templates, error) {
+	sources := make(map[string]configuration.LocaleTemplates, len(cfg.Locales)+1)
+	for locale, src := range cfg.Locales {
+		sources[strings.ToLower(locale)] = src
+	}
+	defaultLocale := strings.ToLower(cfg.DefaultLocale)
+	// The default locale is the last resort, so it has templates for every message type.
+	def := sources[defaultLocale]
+	if def.Reminder.IsZero() {
+		def.Reminder = builtin.Reminder
+	}
+	if def.Digest.IsZero() {
+		def.Digest = builtin.Digest
+	}
+	sources[defaultLocale] = def
+
+	t := &Templates{sets: make(map[string]map[string]*messageTemplates, len(sources)), defaultLocale: defaultLocale}
+	for _, locale := range slices.Sorted(maps.Keys(sources)) {
+		src := sources[locale]
+		sets := make(map[string]*messageTemplates, 2)
+		for typ, mt := range map[string]configuration.MessageTemplates{
+			rmq.TypeReminder: src.Reminder,
+			rmq.TypeDigest:   src.Digest,
+		} {
+			if mt.IsZero() {
+				continue
+			}
+			parsed, err := parse(locale+"."+typ, mt)
+			if err == nil {
+				_, err = parsed.render(sampleData(typ, locale))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("%s templates of locale %s: %w", typ, locale, err)
+			}
+			sets[typ] = parsed
+		}
+		t.sets[locale] = sets
+	}
+	return t, nil
+}
+
+func parse(name string, src configuration.MessageTemplates) (*messageTemplates, error) {
+	var (
+		mt  messageTemplates
+		err error
+	)
+	if mt.subject, err = texttemplate.New(name + ".subject").Parse(src.Subject); err == nil {
+		mt.text, err = texttemplate.New(name + ".text").Parse(src.Text)
+	}
+	if err == nil && src.HTML != "" {
+		mt.html, err = htmltemplate.New(name + ".html").Parse(src.HTML)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &mt, nil
+}
+
+// Render renders note in its locale, or the closest one with templates for its type.
+func (t *Templates) Render(note rmq.Notification) (rmq.Message, error) {
+	typ := cmp.Or(note.Type, rmq.TypeReminder)
+	locale := t.resolve(note.Locale, typ)
+	mt, ok := t.sets[locale][typ]
+	if !ok {
+		return rmq.Message{}, fmt.Errorf("render notification for %s: unknown type %q", note.UserID, note.Type)
+	}
+
+	var (
+		data any
+		err  error
+	)
+	if typ == rmq.TypeDigest {
+		data, err = digestData(note, locale)
+	} else {
+		data, err = reminderData(note, locale)
+	}
+	if err != nil {
+		return rmq.Message{}, err
+	}
+
+	msg, err := mt.render(data)
+	if err != nil {
+		return rmq.Message{}, fmt.Errorf("render %s for %s in %s: %w", typ, note.UserID, locale, err)
+	}
+	msg.Notification = note
+	msg.Locale = locale
+	return msg, nil
+}
+
+// resolve picks the locale to render a message of type typ in: the tag itself, its language or the default.
+func (t *Templates) resolve(tag, typ string) string {
+	tag = strings.ToLower(strings.ReplaceAll(tag, "_", "-"))
+	if _, ok := t.sets[tag][typ]; ok {
+		return tag
+	}
+	if lang, _, ok := strings.Cut(tag, "-"); ok {
+		if _, ok := t.sets[lang][typ]; ok {
+			return lang
+		}
+	}
+	return t.defaultLocale
+}
+
+func (mt *messageTemplates) render(data any) (rmq.Message, error) {
+	var msg rmq.Message
+	var buf bytes.Buffer
+	if err := mt.subject.Execute(&buf, data); err != nil {
+		return msg, err
+	}
+	// A subject is a single line whatever the template produced.
+	msg.Subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	if err := mt.text.Execute(&buf, data); err != nil {
+		return msg, err
+	}
+	msg.Text = buf.String()
+
+	if mt.html != nil {
+		buf.Reset()
+		if err := mt.html.Execute(&buf, data); err != nil {
+			return msg, err
+		}
+		msg.HTML = buf.String()
+	}
+	return msg, nil
+}
+
+func location(note rmq.Notification) (*time.Location, error) {
+	if note.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(note.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("notification for %s: %w", note.UserID, err)
+	}
+	return loc, nil
+}
+
+func reminderData(note rmq.Notification, locale string) (ReminderData, error) {
+	loc, err := location(note)
+	if err != nil {
+		return ReminderData{}, err
+	}
+	data := ReminderData{
+		EventID:      note.EventID,
+		UserID:       note.UserID,
+		Title:        note.Title,
+		Description:  note.Description,
+		Start:        note.DateTime.In(loc),
+		AllDay:       note.AllDay,
+		TimeZone:     loc.String(),
+		NotifyBefore: note.NotifyBefore,
+		Locale:       locale,
+		Location:     note.Location,
+		MeetingURL:   note.MeetingURL,
+	}
+	// Version 1 notifications carry no end time.
+	if !note.EndTime.IsZero() {
+		data.End = note.EndTime.In(loc)
+	}
+	return data, nil
+}
+
+func digestData(note rmq.Notification, locale string) (DigestData, error) {
+	loc, err := location(note)
+	if err != nil {
+		return DigestData{}, err
+	}
+	data := DigestData{
+		UserID:   note.UserID,
+		Date:     note.DateTime.In(loc),
+		TimeZone: loc.String(),
+		Locale:   locale,
+	}
+	if note.Digest != nil {
+		for _, ev := range note.Digest.Events {
+			data.Events = append(data.Events, DigestEvent{
+				EventID:     ev.EventID,
+				Title:       ev.Title,
+				Description: ev.Description,
+				Start:       ev.Start.In(loc),
+				End:         ev.End.In(loc),
+				AllDay:      ev.AllDay,
+				Location:    ev.Location,
+				MeetingURL:  ev.MeetingURL,
+			})
+		}
+	}
+	return data, nil
+}
+
+func sampleData(typ, locale string) any {
+	const id = "00000000-0000-0000-0000-000000000000"
+	start := time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC)
+	if typ == rmq.TypeDigest {
+		return DigestData{
+			UserID:   id,
+			Date:     start.Truncate(24 * time.Hour),
+			TimeZone: "UTC",
+			Events: []DigestEvent{{
+				EventID:     id,
+				Title:       "Sample",
+				Description: "Sample description",
+				Start:       start,
+				End:         start.Add(time.Hour),
+				Location:    "Sample room",
+				MeetingURL:  "https://meet.example.com/sample",
+			}},
+			Locale: locale,
+		}
+	}
+	return ReminderData{
+		EventID:      id,
+		UserID:       id,
+		Title:        "Sample",
+		Description:  "Sample description",
+		Start:        start,
+		End:          start.Add(time.Hour),
+		TimeZone:     "UTC",
+		NotifyBefore: "15m",
+		Locale:       locale,
+		Location:     "Sample room",
+		MeetingURL:   "https://meet.example.com/sample",
+	}
+}
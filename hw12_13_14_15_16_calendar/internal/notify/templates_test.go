@@ -0,0 +1,235 @@
+package notify
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/rmq"
+	"github.com/stretchr/testify/require"
+)
+
+func note() rmq.Notification {
+	return rmq.Notification{
+		Version:      rmq.NotificationVersion,
+		EventID:      "e1",
+		UserID:       "u1",
+		Title:        "Standup",
+		Description:  "Room <b>4</b>",
+		DateTime:     time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC),
+		EndTime:      time.Date(2026, 10, 19, 7, 15, 0, 0, time.UTC),
+		TimeZone:     "Europe/Moscow",
+		NotifyBefore: "15m",
+	}
+}
+
+func TestBuiltinTemplates(t *testing.T) {
+	templates, err := NewTemplates(configuration.TemplatesConf{DefaultLocale: "en"})
+	require.NoError(t, err)
+
+	msg, err := templates.Render(note())
+	require.NoError(t, err)
+	require.Equal(t, "en", msg.Locale)
+	require.Equal(t, "Reminder: Standup", msg.Subject)
+	require.Equal(t, "Standup starts at 10:00 Monday, 19 October 2026 (Europe/Moscow).\n\nRoom <b>4</b>", msg.Text)
+	require.Equal(t, "<p><strong>Standup</strong> starts at 10:00 Monday, 19 October 2026 (Europe/Moscow).</p>\n"+
+		"<p>Room &lt;b&gt;4&lt;/b&gt;</p>", msg.HTML)
+
+	allDay := note()
+	allDay.AllDay = true
+	allDay.Description = ""
+	msg, err = templates.Render(allDay)
+	require.NoError(t, err)
+	require.Equal(t, "Standup starts on Monday, 19 October 2026.", msg.Text)
+
+	online := note()
+	online.Description = ""
+	online.Location = "Room 4"
+	online.MeetingURL = "https://meet.example.com/standup?a=1&b=2"
+	msg, err = templates.Render(online)
+	require.NoError(t, err)
+	require.Equal(t, "Standup starts at 10:00 Monday, 19 October 2026 (Europe/Moscow).\n"+
+		"Where: Room 4\nJoin: https://meet.example.com/standup?a=1&b=2", msg.Text)
+	require.Equal(t, "<p><strong>Standup</strong> starts at 10:00 Monday, 19 October 2026 (Europe/Moscow).</p>\n"+
+		"<p>Where: Room 4</p>\n"+
+		"<p><a href=\"https://meet.example.com/standup?a=1&amp;b=2\">Join the meeting</a></p>", msg.HTML)
+}
+
+func TestLocales(t *testing.T) {
+	templates, err := NewTemplates(configuration.TemplatesConf{
+		DefaultLocale: "en",
+		Locales: map[string]configuration.LocaleTemplates{
+			"ru": {Reminder: configuration.MessageTemplates{
+				Subject: "Напоминание:\n{{.Title}}",
+				Text:    `{{.Title}} в {{.Start.Format "15:04"}}, до {{.End.Format "15:04"}}`,
+			}},
+			"pt-BR": {Reminder: configuration.MessageTemplates{
+				Subject: "Lembrete: {{.Title}}", Text: "{{.Title}} ({{.Locale}})",
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		locale, want, subject, text string
+	}{
+		{"ru", "ru", "Напоминание: Standup", "Standup в 10:00, до 10:15"},
+		{"ru_RU", "ru", "Напоминание: Standup", "Standup в 10:00, до 10:15"},
+		{"pt-BR", "pt-br", "Lembrete: Standup", "Standup (pt-br)"},
+		{"pt", "en", "Reminder: Standup", ""},
+		{"", "en", "Reminder: Standup", ""},
+	}
+	for _, tt := range tests {
+		n := note()
+		n.Locale = tt.locale
+		msg, err := templates.Render(n)
+		require.NoError(t, err, tt.locale)
+		require.Equal(t, tt.want, msg.Locale, tt.locale)
+		require.Equal(t, tt.subject, msg.Subject, tt.locale)
+		if tt.text != "" {
+			require.Equal(t, tt.text, msg.Text, tt.locale)
+			require.Empty(t, msg.HTML, "no html template for %s", tt.locale)
+		}
+		require.Equal(t, n, msg.Notification)
+	}
+}
+
+func TestTemplateErrors(t *testing.T) {
+	_, err := NewTemplates(configuration.TemplatesConf{
+		DefaultLocale: "en",
+		Locales: map[string]configuration.LocaleTemplates{
+			"de": {Reminder: configuration.MessageTemplates{Subject: "{{.Title", Text: "x"}},
+		},
+	})
+	require.ErrorContains(t, err,
+		"reminder templates of locale de: template: de.reminder.subject:1: unclosed action")
+
+	_, err = NewTemplates(configuration.TemplatesConf{
+		DefaultLocale: "en",
+		Locales: map[string]configuration.LocaleTemplates{
+			"de": {Reminder: configuration.MessageTemplates{Subject: "{{.Name}}", Text: "x"}},
+		},
+	})
+	require.ErrorContains(t, err, "can't evaluate field Name")
+
+	// Digest templates are checked against the digest data.
+	_, err = NewTemplates(configuration.TemplatesConf{
+		DefaultLocale: "en",
+		Locales: map[string]configuration.LocaleTemplates{
+			"de": {Digest: configuration.MessageTemplates{Subject: "x", Text: "{{.Title}}"}},
+		},
+	})
+	require.ErrorContains(t, err, "digest templates of locale de")
+	require.ErrorContains(t, err, "can't evaluate field Title")
+}
+
+func TestVersionOneNotification(t *testing.T) {
+	note, err := rmq.DecodeNotification([]byte(
+		`{"eventId":"e1","title":"Old","datetime":"2026-10-19T07:00:00Z","userId":"u1"}`))
+	require.NoError(t, err)
+	require.Equal(t, 1, note.Version)
+	require.Equal(t, rmq.TypeReminder, note.Type)
+
+	templates, err := NewTemplates(configuration.TemplatesConf{
+		DefaultLocale: "en",
+		Locales: map[string]configuration.LocaleTemplates{
+			"en": {Reminder: configuration.MessageTemplates{
+				Subject: "{{.Title}}", Text: `{{if .End.IsZero}}no end{{else}}{{.End}}{{end}}`,
+			}},
+		},
+	})
+	require.NoError(t, err)
+	msg, err := templates.Render(note)
+	require.NoError(t, err)
+	require.Equal(t, "no end", msg.Text)
+
+	// The forwarded message keeps the fields of the notification next to the rendered ones.
+	body, err := json.Marshal(msg)
+	require.NoError(t, err)
+	var fields map[string]any
+	require.NoError(t, json.Unmarshal(body, &fields))
+	require.Equal(t, "e1", fields["eventId"])
+	require.Equal(t, "u1", fields["userId"])
+	require.Equal(t, "en", fields["locale"])
+	require.Equal(t, "Old", fields["subject"])
+}
+
+func TestShippedTemplates(t *testing.T) {
+	cfg, err := configuration.LoadSenderConfig("../../configs/sender_config.yaml", nil)
+	require.NoError(t, err)
+	templates, err := NewTemplates(cfg.Sender.Templates)
+	require.NoError(t, err)
+
+	n := note()
+	n.Locale = "ru-RU"
+	msg, err := templates.Render(n)
+	require.NoError(t, err)
+	require.Equal(t, "Напоминание: Standup", msg.Subject)
+	require.Equal(t, "Standup начинается в 10:00 19.10.2026 (Europe/Moscow).\n\nRoom <b>4</b>", msg.Text)
+
+	d := digest()
+	d.Locale = "ru"
+	msg, err = templates.Render(d)
+	require.NoError(t, err)
+	require.Equal(t, "Планы на 19.10.2026", msg.Subject)
+	require.Equal(t, "Планы на 19.10.2026 (Europe/Moscow):\n\n"+
+		"весь день    Conference\n10:00-10:15  Standup <daily>", msg.Text)
+
+	// The shipped English templates are the built-in ones.
+	require.Equal(t, builtin, cfg.Sender.Templates.Locales["en"])
+}
+
+func digest() rmq.Notification {
+	day := time.Date(2026, 10, 18, 21, 0, 0, 0, time.UTC) // midnight in Moscow
+	return rmq.Notification{
+		Version:  rmq.NotificationVersion,
+		Type:     rmq.TypeDigest,
+		UserID:   "u1",
+		DateTime: day,
+		TimeZone: "Europe/Moscow",
+		Digest: &rmq.Digest{
+			Date: "2026-10-19",
+			Events: []rmq.DigestEvent{
+				{EventID: "e2", Title: "Conference", Start: day, End: day.Add(48 * time.Hour), AllDay: true},
+				{
+					EventID: "e1", Title: "Standup <daily>",
+					Start: time.Date(2026, 10, 19, 7, 0, 0, 0, time.UTC),
+					End:   time.Date(2026, 10, 19, 7, 15, 0, 0, time.UTC),
+				},
+			},
+		},
+	}
+}
+
+func TestDigest(t *testing.T) {
+	templates, err := NewTemplates(configuration.TemplatesConf{
+		DefaultLocale: "en",
+		Locales: map[string]configuration.LocaleTemplates{
+			// Only reminders are translated, so digests fall back to the default locale.
+			"de": {Reminder: configuration.MessageTemplates{Subject: "Erinnerung: {{.Title}}", Text: "{{.Title}}"}},
+		},
+	})
+	require.NoError(t, err)
+
+	n := digest()
+	n.Locale = "de-AT"
+	msg, err := templates.Render(n)
+	require.NoError(t, err)
+	require.Equal(t, "en", msg.Locale)
+	require.Equal(t, "Your agenda for Monday, 19 October", msg.Subject)
+	require.Equal(t, "Your agenda for Monday, 19 October 2026 (Europe/Moscow):\n\n"+
+		"all day      Conference\n10:00-10:15  Standup <daily>", msg.Text)
+	require.Equal(t, "<p>Your agenda for Monday, 19 October 2026 (Europe/Moscow):</p>\n<ul>\n"+
+		"<li>all day <strong>Conference</strong></li>\n"+
+		"<li>10:00&ndash;10:15 <strong>Standup &lt;daily&gt;</strong></li>\n</ul>", msg.HTML)
+
+	n.Digest.Events = nil
+	msg, err = templates.Render(n)
+	require.NoError(t, err)
+	require.Equal(t, "Your agenda for Monday, 19 October 2026 (Europe/Moscow):\n\nNothing planned.", msg.Text)
+
+	n.Type = "weekly"
+	_, err = templates.Render(n)
+	require.ErrorContains(t, err, `unknown type "weekly"`)
+}
@@ -0,0 +1,126 @@
+package ratelimit
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+)
+
+// Limiter keeps a token bucket per key. Each bucket refills at rate tokens per second up to burst.
+type Limiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     float64
+	idle      time.Duration
+	buckets   map[string]*bucket
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter returns nil for a zero rate; a nil Limiter allows everything.
+func NewLimiter(rate float64, burst int, idle time.Duration) *Limiter {
+	if rate <= 0 {
+		return nil
+	}
+	// A bucket can only be forgotten once it is full again, otherwise dropping it would hand out extra tokens.
+	if refill := time.Duration(float64(burst) / rate * float64(time.Second)); idle < refill {
+		idle = refill
+	}
+	return &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		idle:    idle,
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow takes a token from the bucket of key. When the bucket is empty it reports how long
+// the caller has to wait for the next token.
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+	return false, time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+}
+
+// sweep drops the buckets nobody used for the idle timeout. It must be called with l.mu held.
+func (l *Limiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < l.idle {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.last) >= l.idle {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// Quota combines the per-IP and per-user limits; a request has to pass both.
+type Quota struct {
+	ip   *Limiter
+	user *Limiter
+}
+
+// NewQuota returns nil when rate limiting is disabled; a nil Quota allows everything.
+func NewQuota(cfg configuration.RateLimitConf) *Quota {
+	if !cfg.Enable {
+		return nil
+	}
+	return &Quota{
+		ip:   NewLimiter(cfg.PerIP.Rate, cfg.PerIP.Burst, cfg.IdleTimeout),
+		user: NewLimiter(cfg.PerUser.Rate, cfg.PerUser.Burst, cfg.IdleTimeout),
+	}
+}
+
+// Allow charges one request to ip and, when known, to user.
+func (q *Quota) Allow(ip, user string) (bool, time.Duration) {
+	if q == nil {
+		return true, 0
+	}
+	if ok, wait := q.ip.Allow(ip); !ok {
+		return false, wait
+	}
+	if user == "" {
+		return true, 0
+	}
+	return q.user.Allow(user)
+}
+
+// RetryAfter formats wait as the whole number of seconds used by the Retry-After header.
+func RetryAfter(wait time.Duration) string {
+	secs := int64(math.Ceil(wait.Seconds()))
+	if secs < 1 {
+		secs = 1
+	}
+	return strconv.FormatInt(secs, 10)
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiterBurstAndRefill(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(2, 3, time.Minute)
+	l.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		ok, _ := l.Allow("1.2.3.4")
+		require.True(t, ok, "request %d", i)
+	}
+	ok, wait := l.Allow("1.2.3.4")
+	assert.False(t, ok)
+	assert.Equal(t, 500*time.Millisecond, wait)
+
+	ok, _ = l.Allow("5.6.7.8")
+	assert.True(t, ok, "other keys have their own bucket")
+
+	now = now.Add(500 * time.Millisecond)
+	ok, _ = l.Allow("1.2.3.4")
+	assert.True(t, ok)
+	ok, _ = l.Allow("1.2.3.4")
+	assert.False(t, ok)
+}
+
+func TestLimiterSweepsIdleBuckets(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	l := NewLimiter(1, 1, time.Minute)
+	l.now = func() time.Time { return now }
+
+	l.Allow("a")
+	l.Allow("b")
+	require.Len(t, l.buckets, 2)
+
+	now = now.Add(2 * time.Minute)
+	l.Allow("a")
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestQuota(t *testing.T) {
+	assert.Nil(t, NewQuota(configuration.RateLimitConf{}))
+	var disabled *Quota
+	ok, _ := disabled.Allow("ip", "user")
+	assert.True(t, ok)
+
+	q := NewQuota(configuration.RateLimitConf{
+		Enable:  true,
+		PerIP:   configuration.LimitConf{Rate: 1, Burst: 10},
+		PerUser: configuration.LimitConf{Rate: 1, Burst: 1},
+	})
+	ok, _ = q.Allow("1.2.3.4", "alice")
+	assert.True(t, ok)
+	ok, _ = q.Allow("5.6.7.8", "alice")
+	assert.False(t, ok, "the user quota follows alice across addresses")
+	ok, _ = q.Allow("1.2.3.4", "")
+	assert.True(t, ok, "anonymous calls are only limited per IP")
+}
+
+func TestRetryAfter(t *testing.T) {
+	assert.Equal(t, "1", RetryAfter(0))
+	assert.Equal(t, "1", RetryAfter(200*time.Millisecond))
+	assert.Equal(t, "3", RetryAfter(2100*time.Millisecond))
+}
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+)
+
+// Proxies are the reverse proxies trusted to report the client address in X-Forwarded-For.
+// A nil Proxies trusts nobody.
+type Proxies []netip.Prefix
+
+// NewProxies reads system.rate_limit.trusted_proxies, which the config validation already checked.
+func NewProxies(cfg configuration.RateLimitConf) Proxies {
+	prefixes, _ := cfg.Proxies()
+	return prefixes
+}
+
+// ClientIP is the address of a request from remote carrying the X-Forwarded-For value forwarded:
+// remote itself unless it is a trusted proxy, otherwise the client the proxies report.
+func (p Proxies) ClientIP(remote, forwarded string) string {
+	if !p.trusts(remote) {
+		return remote
+	}
+	return p.Forwarded(forwarded, remote)
+}
+
+// Forwarded is the right-most hop of forwarded that is not a trusted proxy. Every proxy appends
+// the peer it got the request from, so the hops left of that one were written by the client and
+// can be anything. When forwarded is empty the request came straight from proxy.
+func (p Proxies) Forwarded(forwarded, proxy string) string {
+	if strings.TrimSpace(forwarded) == "" {
+		return proxy
+	}
+	hops := strings.Split(forwarded, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		if hop := strings.TrimSpace(hops[i]); !p.trusts(hop) {
+			return hop
+		}
+	}
+	// Only proxies on the way: the left-most one is the closest to the client.
+	return strings.TrimSpace(hops[0])
+}
+
+func (p Proxies) trusts(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, prefix := range p {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
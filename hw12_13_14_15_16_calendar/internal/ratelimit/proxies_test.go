@@ -0,0 +1,24 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProxiesClientIP(t *testing.T) {
+	p := NewProxies(configuration.RateLimitConf{TrustedProxies: []string{"10.0.0.0/8", "192.0.2.1"}})
+
+	assert.Equal(t, "203.0.113.5", p.ClientIP("203.0.113.5", "198.51.100.7"), "only proxies are believed")
+	assert.Equal(t, "10.1.1.1", p.ClientIP("10.1.1.1", ""))
+	assert.Equal(t, "198.51.100.7", p.ClientIP("192.0.2.1", "1.1.1.1, 198.51.100.7, 10.2.2.2"),
+		"the right-most hop that is not a proxy is the client, whatever it put on the left")
+	assert.Equal(t, "10.3.3.3", p.ClientIP("::ffff:192.0.2.1", "10.3.3.3, 10.2.2.2"))
+	assert.Equal(t, "junk", p.ClientIP("192.0.2.1", "junk"))
+
+	var none Proxies
+	assert.Equal(t, "192.0.2.1", none.ClientIP("192.0.2.1", "198.51.100.7"))
+	assert.Equal(t, "198.51.100.7", none.Forwarded("1.1.1.1, 198.51.100.7", "127.0.0.1"))
+	assert.Equal(t, "127.0.0.1", none.Forwarded("", "127.0.0.1"))
+}
@@ -12,11 +12,89 @@ import (
 	"github.com/streadway/amqp"
 )
 
+// NotificationVersion is the schema version of the notifications published now. Fields are only
+// ever added, so consumers read older versions too; messages without a version are version 1.
+const NotificationVersion = 4
+
+// Notification types. Versions before 3 have no type and only carry reminders.
+const (
+	TypeReminder = "reminder"
+	TypeDigest   = "digest"
+)
+
+// Notification is what the scheduler sends to the sender: the reminder of an event, or the digest
+// of a user's day.
 type Notification struct {
+	Version  int       `json:"version"`
 	EventID  string    `json:"eventId"`
 	Title    string    `json:"title"`
 	DateTime time.Time `json:"datetime"`
 	UserID   string    `json:"userId"`
+
+	// Since version 2.
+	EndTime     time.Time `json:"endTime"`
+	Description string    `json:"description,omitempty"`
+	// NotifyBefore is the reminder offset the event was created with, e.g. 15m.
+	NotifyBefore string `json:"notifyBefore,omitempty"`
+	// TimeZone is the IANA zone the event is planned in, or for digests the zone of the user.
+	TimeZone string `json:"timeZone,omitempty"`
+	AllDay   bool   `json:"allDay,omitempty"`
+	// Locale is the BCP 47 language tag of the recipient; empty leaves the choice to the sender.
+	Locale string `json:"locale,omitempty"`
+
+	// Since version 3.
+	Type string `json:"type"`
+	// Digest is only set on digests, whose DateTime is the start of the day in TimeZone.
+	Digest *Digest `json:"digest,omitempty"`
+
+	// Since version 4.
+	Location   string `json:"location,omitempty"`
+	MeetingURL string `json:"meetingUrl,omitempty"`
+}
+
+// Digest lists the events of a user's day, by start.
+type Digest struct {
+	// Date is the day in the user's time zone, as YYYY-MM-DD.
+	Date   string        `json:"date"`
+	Events []DigestEvent `json:"events"`
+}
+
+type DigestEvent struct {
+	EventID     string    `json:"eventId"`
+	Title       string    `json:"title"`
+	Description string    `json:"description,omitempty"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	AllDay      bool      `json:"allDay,omitempty"`
+	// Since version 4.
+	Location   string `json:"location,omitempty"`
+	MeetingURL string `json:"meetingUrl,omitempty"`
+}
+
+// Message is a notification rendered for delivery, as the sender forwards it.
+type Message struct {
+	Notification
+	// Locale is the one the message was rendered in.
+	Locale  string `json:"locale"`
+	Subject string `json:"subject"`
+	Text    string `json:"text"`
+	HTML    string `json:"html,omitempty"`
+}
+
+// DecodeNotification parses a notification of any version up to NotificationVersion.
+// Newer versions are read as far as this one understands them.
+func DecodeNotification(body []byte) (Notification, error) {
+	var note Notification
+	if err := json.Unmarshal(body, &note); err != nil {
+		return Notification{}, fmt.Errorf("decode notification: %w", err)
+	}
+	if note.Version == 0 {
+		note.Version = 1
+	}
+	if note.Type == "" {
+		note.Type = TypeReminder
+	}
+	return note, nil
 }
 
 type Publisher interface {
@@ -61,10 +139,23 @@ func NewRMQClient(uri, queueName string) (*RMQClient, error) {
 	return &RMQClient{conn: conn, channel: ch, queue: queue}, nil
 }
 
+// PublishNotification publishes note with the current schema version, as a reminder unless typed.
 func (r *RMQClient) PublishNotification(ctx context.Context, note Notification) error {
-	body, err := json.Marshal(note)
+	note.Version = NotificationVersion
+	if note.Type == "" {
+		note.Type = TypeReminder
+	}
+	return r.publish(ctx, note)
+}
+
+func (r *RMQClient) PublishMessage(ctx context.Context, msg Message) error {
+	return r.publish(ctx, msg)
+}
+
+func (r *RMQClient) publish(_ context.Context, v any) error {
+	body, err := json.Marshal(v)
 	if err != nil {
-		return fmt.Errorf("could not marshal notification: %w", err)
+		return fmt.Errorf("could not marshal message: %w", err)
 	}
 
 	return r.channel.Publish(
@@ -101,8 +192,8 @@ func (r *RMQClient) ConsumeNotifications(ctx context.Context, handleFunc func(No
 				if !ok {
 					return
 				}
-				var note Notification
-				if err := json.Unmarshal(d.Body, &note); err != nil {
+				note, err := DecodeNotification(d.Body)
+				if err != nil {
 					log.Printf("could not decode message: %v", err)
 					continue
 				}
@@ -0,0 +1,190 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/rmq"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+// digestRetry is how long a digest that failed waits before it is sent again.
+const digestRetry = time.Minute
+
+// AgendaSource is the part of the storage the digest reads the events of a day from.
+type AgendaSource interface {
+	EventGetList(ctx context.Context, req *models.GetEventListReq) (*models.GetEventListResp, error)
+}
+
+// NotificationPublisher sends a notification to the sender.
+type NotificationPublisher func(ctx context.Context, note rmq.Notification) error
+
+// Digest sends every listed user the events of their day at the configured hour of their time
+// zone. A digest that fails is retried until the day is over. Digests due while the scheduler was
+// down are skipped rather than sent late, so a restart never sends one twice.
+type Digest struct {
+	source    AgendaSource
+	publish   NotificationPublisher
+	hour      int
+	skipEmpty bool
+	users     []*digestUser
+	logger    logger.Logger
+}
+
+type digestUser struct {
+	id     string
+	locale string
+	loc    *time.Location
+	// day is the start of the next day to send, at is when to send it.
+	day, at time.Time
+}
+
+func NewDigest(source AgendaSource, publish NotificationPublisher, cfg configuration.DigestConf,
+	logg logger.Logger,
+) (*Digest, error) {
+	d := &Digest{
+		source:    source,
+		publish:   publish,
+		hour:      cfg.Hour,
+		skipEmpty: cfg.SkipEmpty,
+		logger:    logg,
+	}
+	for _, u := range cfg.Users {
+		loc, err := time.LoadLocation(u.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("digest of user %s: %w", u.User, err)
+		}
+		d.users = append(d.users, &digestUser{id: u.User, locale: u.Locale, loc: loc})
+	}
+	return d, nil
+}
+
+// Run sends the digests until ctx is done.
+func (d *Digest) Run(ctx context.Context) {
+	if len(d.users) == 0 {
+		return
+	}
+	d.plan(time.Now())
+
+	for {
+		next := d.users[0].at
+		for _, u := range d.users[1:] {
+			if u.at.Before(next) {
+				next = u.at
+			}
+		}
+		d.logger.Debug(fmt.Sprintf("next digest at %s", next.Format(time.RFC3339)))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+		d.fire(ctx, time.Now())
+	}
+}
+
+// plan schedules every user's first digest: today's if its hour is still ahead, otherwise tomorrow's.
+func (d *Digest) plan(now time.Time) {
+	for _, u := range d.users {
+		u.schedule(startOfDay(now, u.loc), d.hour)
+		if u.at.Before(now) {
+			u.schedule(u.day.AddDate(0, 0, 1), d.hour)
+		}
+	}
+}
+
+// fire sends the digests due at now and schedules the next ones.
+func (d *Digest) fire(ctx context.Context, now time.Time) {
+	for _, u := range d.users {
+		if u.at.After(now) {
+			continue
+		}
+		err := d.send(ctx, u, u.day)
+		if err == nil {
+			u.schedule(u.day.AddDate(0, 0, 1), d.hour)
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		if retry := now.Add(digestRetry); retry.Before(u.day.AddDate(0, 0, 1)) {
+			d.logger.Warn(fmt.Sprintf("digest of %s for user %s, retrying: %v", u.day.Format(time.DateOnly), u.id, err))
+			u.at = retry
+			continue
+		}
+		d.logger.Error(fmt.Sprintf("digest of %s for user %s dropped: %v", u.day.Format(time.DateOnly), u.id, err))
+		u.schedule(u.day.AddDate(0, 0, 1), d.hour)
+	}
+}
+
+func (u *digestUser) schedule(day time.Time, hour int) {
+	u.day = day
+	y, m, dd := day.Date()
+	u.at = time.Date(y, m, dd, hour, 0, 0, 0, u.loc)
+}
+
+// send publishes the digest of the day starting at day for u.
+func (d *Digest) send(ctx context.Context, u *digestUser, day time.Time) error {
+	start, end := day, day.AddDate(0, 0, 1)
+	resp, err := d.source.EventGetList(ctx, &models.GetEventListReq{Start: &start, End: &end, User: &u.id})
+	if err != nil {
+		return fmt.Errorf("list events: %w", err)
+	}
+
+	events := make([]rmq.DigestEvent, 0, len(resp.Data))
+	for _, ev := range resp.Data {
+		// The list includes events touching the bounds and the ones the user declined.
+		if !ev.EndTime.After(start) || !ev.Date.Before(end) || !slices.Contains(ev.BusyUsers(), u.id) {
+			continue
+		}
+		de := rmq.DigestEvent{
+			EventID: ev.ID,
+			Title:   ev.Title,
+			Start:   ev.Date,
+			End:     ev.EndTime,
+			AllDay:  ev.AllDay,
+		}
+		if ev.Description != nil {
+			de.Description = *ev.Description
+		}
+		if ev.Location != nil {
+			de.Location = *ev.Location
+		}
+		if ev.MeetingURL != nil {
+			de.MeetingURL = *ev.MeetingURL
+		}
+		events = append(events, de)
+	}
+	slices.SortStableFunc(events, func(a, b rmq.DigestEvent) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	if len(events) == 0 && d.skipEmpty {
+		d.logger.Debug(fmt.Sprintf("no events on %s for user %s, digest skipped", day.Format(time.DateOnly), u.id))
+		return nil
+	}
+
+	note := rmq.Notification{
+		Type:     rmq.TypeDigest,
+		UserID:   u.id,
+		DateTime: day,
+		TimeZone: u.loc.String(),
+		Locale:   u.locale,
+		Digest:   &rmq.Digest{Date: day.Format(time.DateOnly), Events: events},
+	}
+	if err := d.publish(ctx, note); err != nil {
+		return fmt.Errorf("publish: %w", err)
+	}
+	d.logger.Info(fmt.Sprintf("digest of %s sent to user %s with %d events", day.Format(time.DateOnly), u.id, len(events)))
+	return nil
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
@@ -0,0 +1,152 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/rmq"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAgenda struct {
+	events []models.Event
+	reqs   []models.GetEventListReq
+}
+
+func (f *fakeAgenda) EventGetList(_ context.Context, req *models.GetEventListReq) (*models.GetEventListResp, error) {
+	f.reqs = append(f.reqs, *req)
+	return &models.GetEventListResp{Data: f.events}, nil
+}
+
+const bob = "7c2e9b41-8f0a-4d3e-b5c6-1a9d0e7f3b22"
+
+func digestConf() configuration.DigestConf {
+	return configuration.DigestConf{
+		Enable:    true,
+		Hour:      7,
+		SkipEmpty: true,
+		Users: []configuration.DigestUserConf{
+			{User: alice, TimeZone: "Europe/Moscow", Locale: "ru"},
+			{User: bob, TimeZone: "America/New_York"},
+		},
+	}
+}
+
+func TestDigestContent(t *testing.T) {
+	msk, err := time.LoadLocation("Europe/Moscow")
+	require.NoError(t, err)
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, msk)
+	description := "daily"
+	agenda := &fakeAgenda{events: []models.Event{
+		{ID: "late", Title: "Review", User: alice, Date: day.Add(17 * time.Hour), EndTime: day.Add(18 * time.Hour)},
+		{
+			ID: "standup", Title: "Standup", User: alice, Description: &description,
+			Date: day.Add(10 * time.Hour), EndTime: day.Add(10*time.Hour + 15*time.Minute),
+		},
+		// Ended at midnight, so it belongs to the day before.
+		{ID: "yesterday", Title: "Party", User: alice, Date: day.Add(-2 * time.Hour), EndTime: day},
+		{
+			ID: "declined", Title: "Sync", User: bob, Date: day.Add(11 * time.Hour), EndTime: day.Add(12 * time.Hour),
+			Attendees: []models.Attendee{{User: alice, Status: models.AttendeeDeclined}},
+		},
+		{
+			ID: "invited", Title: "Demo", User: bob, Date: day.Add(15 * time.Hour), EndTime: day.Add(16 * time.Hour),
+			Attendees: []models.Attendee{{User: alice, Status: models.AttendeeNeedsAction}},
+		},
+		{ID: "holiday", Title: "Holiday", User: alice, AllDay: true, Date: day, EndTime: day.AddDate(0, 0, 1)},
+	}}
+	var sent []rmq.Notification
+	publish := func(_ context.Context, note rmq.Notification) error {
+		sent = append(sent, note)
+		return nil
+	}
+	digest, err := NewDigest(agenda, publish, digestConf(), *logger.NewLogger("scheduler", "test", "error"))
+	require.NoError(t, err)
+
+	require.NoError(t, digest.send(context.Background(), digest.users[0], day))
+	require.Len(t, agenda.reqs, 1)
+	require.Equal(t, alice, *agenda.reqs[0].User)
+	require.True(t, agenda.reqs[0].Start.Equal(day))
+	require.True(t, agenda.reqs[0].End.Equal(day.AddDate(0, 0, 1)))
+
+	require.Len(t, sent, 1)
+	note := sent[0]
+	require.Equal(t, rmq.TypeDigest, note.Type)
+	require.Equal(t, alice, note.UserID)
+	require.Equal(t, "Europe/Moscow", note.TimeZone)
+	require.Equal(t, "ru", note.Locale)
+	require.True(t, note.DateTime.Equal(day))
+	require.Equal(t, "2026-10-19", note.Digest.Date)
+	var ids []string
+	for _, ev := range note.Digest.Events {
+		ids = append(ids, ev.EventID)
+	}
+	require.Equal(t, []string{"holiday", "standup", "invited", "late"}, ids)
+	require.Equal(t, "daily", note.Digest.Events[1].Description)
+	require.True(t, note.Digest.Events[0].AllDay)
+
+	// Nothing is sent for an empty day unless asked to.
+	agenda.events = nil
+	require.NoError(t, digest.send(context.Background(), digest.users[0], day))
+	require.Len(t, sent, 1)
+	digest.skipEmpty = false
+	require.NoError(t, digest.send(context.Background(), digest.users[0], day))
+	require.Len(t, sent, 2)
+	require.Empty(t, sent[1].Digest.Events)
+}
+
+func TestDigestSchedule(t *testing.T) {
+	agenda := &fakeAgenda{events: []models.Event{{ID: "e1", User: alice}}}
+	var sent []string
+	var fail error
+	publish := func(_ context.Context, note rmq.Notification) error {
+		if fail != nil {
+			return fail
+		}
+		sent = append(sent, note.UserID+" "+note.Digest.Date)
+		return nil
+	}
+	cfg := digestConf()
+	cfg.SkipEmpty = false
+	digest, err := NewDigest(agenda, publish, cfg, *logger.NewLogger("scheduler", "test", "error"))
+	require.NoError(t, err)
+	aliceDigest, bobDigest := digest.users[0], digest.users[1]
+
+	// 06:00 in Moscow, where today's digest is ahead, and 23:00 the day before in New York, where
+	// the next one is the following morning.
+	now := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+	digest.plan(now)
+	require.Equal(t, time.Date(2026, 10, 19, 4, 0, 0, 0, time.UTC), aliceDigest.at.UTC())
+	require.Equal(t, time.Date(2026, 10, 19, 11, 0, 0, 0, time.UTC), bobDigest.at.UTC())
+
+	// Started after Moscow's hour, the first digest is tomorrow's.
+	digest.plan(time.Date(2026, 10, 19, 5, 0, 0, 0, time.UTC))
+	require.Equal(t, time.Date(2026, 10, 20, 4, 0, 0, 0, time.UTC), aliceDigest.at.UTC())
+
+	digest.plan(now)
+	digest.fire(context.Background(), aliceDigest.at)
+	require.Equal(t, []string{alice + " 2026-10-19"}, sent)
+	require.Equal(t, time.Date(2026, 10, 20, 4, 0, 0, 0, time.UTC), aliceDigest.at.UTC())
+
+	// A failed digest is retried a minute later, and dropped when its day is over.
+	fail = errors.New("connection reset")
+	retryFrom := bobDigest.at
+	digest.fire(context.Background(), retryFrom)
+	require.Equal(t, retryFrom.Add(digestRetry), bobDigest.at)
+	require.Equal(t, "2026-10-19", bobDigest.day.Format(time.DateOnly))
+
+	fail = nil
+	digest.fire(context.Background(), bobDigest.at)
+	require.Equal(t, []string{alice + " 2026-10-19", bob + " 2026-10-19"}, sent)
+	require.Equal(t, "2026-10-20", bobDigest.day.Format(time.DateOnly))
+
+	fail = errors.New("connection reset")
+	digest.fire(context.Background(), bobDigest.day.Add(24*time.Hour-30*time.Second))
+	require.Equal(t, "2026-10-21", bobDigest.day.Format(time.DateOnly))
+	require.Equal(t, time.Date(2026, 10, 21, 7, 0, 0, 0, bobDigest.loc), bobDigest.at)
+}
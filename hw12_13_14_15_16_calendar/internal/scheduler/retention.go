@@ -0,0 +1,189 @@
+package scheduler
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/cron"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+// RetentionStorage is the part of the storage the retention job removes events from.
+type RetentionStorage interface {
+	ExpireEvents(ctx context.Context, req *models.ExpireEventsReq) (int, error)
+	CountExpiredEvents(ctx context.Context, req *models.ExpireEventsReq) (int, error)
+}
+
+// Retention removes finished events and old trash by rules: one for every user with overrides and
+// a default one for everybody else. Events are removed in batches, each in a statement of its own,
+// so a large backlog never keeps the table locked for long. In a dry run it only counts them.
+type Retention struct {
+	storage   RetentionStorage
+	schedule  *cron.Schedule
+	rules     []retentionRule
+	batchSize int
+	dryRun    bool
+	logger    logger.Logger
+}
+
+type retentionRule struct {
+	name               string
+	users, exceptUsers []string
+	events, trash      time.Duration
+	archive            bool
+}
+
+func NewRetention(storage RetentionStorage, cfg configuration.RetentionConf, logg logger.Logger) (*Retention, error) {
+	schedule, err := cron.Parse(cfg.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("retention schedule: %w", err)
+	}
+
+	rules := make([]retentionRule, 0, len(cfg.Users)+1)
+	overridden := make([]string, 0, len(cfg.Users))
+	for _, u := range cfg.Users {
+		rule := retentionRule{
+			name:    "user " + u.User,
+			users:   []string{u.User},
+			events:  cmp.Or(u.Events, cfg.Events),
+			trash:   cmp.Or(u.Trash, cfg.Trash),
+			archive: cfg.Archive,
+		}
+		if u.Archive != nil {
+			rule.archive = *u.Archive
+		}
+		rules = append(rules, rule)
+		overridden = append(overridden, u.User)
+	}
+	rules = append(rules, retentionRule{
+		name:        "default",
+		exceptUsers: overridden,
+		events:      cfg.Events,
+		trash:       cfg.Trash,
+		archive:     cfg.Archive,
+	})
+
+	return &Retention{
+		storage:   storage,
+		schedule:  schedule,
+		rules:     rules,
+		batchSize: cfg.BatchSize,
+		dryRun:    cfg.DryRun,
+		logger:    logg,
+	}, nil
+}
+
+// RetentionReport tells what a run removed, or would have removed in a dry run.
+type RetentionReport struct {
+	DryRun bool
+	Rules  []RuleReport
+}
+
+type RuleReport struct {
+	Rule    string
+	Archive bool
+	// Ended counts the events that ended before EndedBefore, Trashed the ones moved to the trash
+	// before DeletedBefore.
+	EndedBefore   time.Time
+	Ended         int
+	DeletedBefore time.Time
+	Trashed       int
+}
+
+// Schedule runs the retention at every time of the schedule until ctx is done.
+func (r *Retention) Schedule(ctx context.Context) {
+	for {
+		next := r.schedule.Next(time.Now())
+		r.logger.Debug(fmt.Sprintf("next retention run at %s", next.Format(time.RFC3339)))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+
+		if _, err := r.Run(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			r.logger.Error(fmt.Sprintf("retention: %v", err))
+		}
+	}
+}
+
+// Run applies every rule once. A failing rule does not stop the others; the report covers what was
+// done anyway.
+func (r *Retention) Run(ctx context.Context, now time.Time) (RetentionReport, error) {
+	report := RetentionReport{DryRun: r.dryRun}
+	var errs []error
+	for _, rule := range r.rules {
+		rr := RuleReport{
+			Rule:          rule.name,
+			Archive:       rule.archive,
+			EndedBefore:   now.Add(-rule.events),
+			DeletedBefore: now.Add(-rule.trash),
+		}
+
+		var err error
+		req := rule.request(rr.EndedBefore, false)
+		if rr.Ended, err = r.expire(ctx, req); err != nil {
+			errs = append(errs, fmt.Errorf("rule %s, finished events: %w", rule.name, err))
+		}
+		req = rule.request(rr.DeletedBefore, true)
+		if rr.Trashed, err = r.expire(ctx, req); err != nil {
+			errs = append(errs, fmt.Errorf("rule %s, trash: %w", rule.name, err))
+		}
+
+		report.Rules = append(report.Rules, rr)
+		r.logger.Info(rr.summary(r.dryRun))
+	}
+	return report, errors.Join(errs...)
+}
+
+func (rule *retentionRule) request(before time.Time, trash bool) *models.ExpireEventsReq {
+	return &models.ExpireEventsReq{
+		Trash:       trash,
+		Before:      before,
+		Users:       rule.users,
+		ExceptUsers: rule.exceptUsers,
+		Archive:     rule.archive,
+	}
+}
+
+func (r *Retention) expire(ctx context.Context, req *models.ExpireEventsReq) (int, error) {
+	if r.dryRun {
+		return r.storage.CountExpiredEvents(ctx, req)
+	}
+
+	req.Limit = r.batchSize
+	total := 0
+	for {
+		n, err := r.storage.ExpireEvents(ctx, req)
+		total += n
+		if err != nil {
+			return total, err
+		}
+		// A short batch is the last one; rows locked meanwhile are left for the next run.
+		if n < r.batchSize {
+			return total, nil
+		}
+		if err := ctx.Err(); err != nil {
+			return total, err
+		}
+	}
+}
+
+func (rr RuleReport) summary(dryRun bool) string {
+	verb := "deleted"
+	switch {
+	case dryRun && rr.Archive:
+		verb = "would archive"
+	case dryRun:
+		verb = "would delete"
+	case rr.Archive:
+		verb = "archived"
+	}
+	return fmt.Sprintf("retention rule %q %s %d events ended before %s and %d trashed before %s",
+		rr.Rule, verb, rr.Ended, rr.EndedBefore.Format(time.RFC3339), rr.Trashed, rr.DeletedBefore.Format(time.RFC3339))
+}
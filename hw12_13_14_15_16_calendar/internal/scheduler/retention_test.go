@@ -0,0 +1,127 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeExpiring pretends to hold pending events per rule, keyed by the first user or "*".
+type fakeExpiring struct {
+	pending map[string]int
+	calls   []models.ExpireEventsReq
+	counts  int
+	fail    error
+}
+
+func ruleKey(req *models.ExpireEventsReq) string {
+	key := "*"
+	if len(req.Users) > 0 {
+		key = req.Users[0]
+	}
+	if req.Trash {
+		key += "/trash"
+	}
+	return key
+}
+
+func (f *fakeExpiring) ExpireEvents(_ context.Context, req *models.ExpireEventsReq) (int, error) {
+	f.calls = append(f.calls, *req)
+	if f.fail != nil && len(req.Users) > 0 {
+		return 0, f.fail
+	}
+	n := min(f.pending[ruleKey(req)], req.Limit)
+	f.pending[ruleKey(req)] -= n
+	return n, nil
+}
+
+func (f *fakeExpiring) CountExpiredEvents(_ context.Context, req *models.ExpireEventsReq) (int, error) {
+	f.counts++
+	return f.pending[ruleKey(req)], nil
+}
+
+const alice = "5a1f0c8e-3d4b-4c7a-9e21-0f6b2d8c4a11"
+
+func retentionConf() configuration.RetentionConf {
+	archive := true
+	return configuration.RetentionConf{
+		Schedule:  "0 3 * * *",
+		Events:    365 * 24 * time.Hour,
+		Trash:     30 * 24 * time.Hour,
+		BatchSize: 10,
+		Users:     []configuration.UserRetentionConf{{User: alice, Events: 24 * time.Hour, Archive: &archive}},
+	}
+}
+
+func TestRetentionRules(t *testing.T) {
+	storage := &fakeExpiring{pending: map[string]int{"*": 25, "*/trash": 3, alice: 10}}
+	retention, err := NewRetention(storage, retentionConf(), *logger.NewLogger("scheduler", "test", "error"))
+	require.NoError(t, err)
+
+	now := time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)
+	report, err := retention.Run(context.Background(), now)
+	require.NoError(t, err)
+	require.False(t, report.DryRun)
+	require.Equal(t, []RuleReport{
+		{
+			Rule: "user " + alice, Archive: true,
+			EndedBefore: now.Add(-24 * time.Hour), Ended: 10,
+			DeletedBefore: now.Add(-30 * 24 * time.Hour),
+		},
+		{
+			Rule:        "default",
+			EndedBefore: now.Add(-365 * 24 * time.Hour), Ended: 25,
+			DeletedBefore: now.Add(-30 * 24 * time.Hour), Trashed: 3,
+		},
+	}, report.Rules)
+
+	// A full batch is followed by another one until a short batch shows nothing is left.
+	batches := 0
+	for _, req := range storage.calls {
+		require.Equal(t, 10, req.Limit)
+		if len(req.Users) == 0 && !req.Trash {
+			require.Equal(t, []string{alice}, req.ExceptUsers)
+			require.False(t, req.Archive)
+			batches++
+		}
+		if len(req.Users) > 0 {
+			require.True(t, req.Archive)
+		}
+	}
+	require.Equal(t, 3, batches)
+	require.Zero(t, storage.counts)
+}
+
+func TestRetentionDryRun(t *testing.T) {
+	storage := &fakeExpiring{pending: map[string]int{"*": 25, "*/trash": 3, alice: 10}}
+	cfg := retentionConf()
+	cfg.DryRun = true
+	retention, err := NewRetention(storage, cfg, *logger.NewLogger("scheduler", "test", "error"))
+	require.NoError(t, err)
+
+	report, err := retention.Run(context.Background(), time.Now())
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 10, report.Rules[0].Ended)
+	require.Equal(t, 25, report.Rules[1].Ended)
+	require.Equal(t, 3, report.Rules[1].Trashed)
+
+	require.Empty(t, storage.calls, "a dry run removes nothing")
+	require.Equal(t, 25, storage.pending["*"])
+}
+
+func TestRetentionKeepsGoingAfterFailure(t *testing.T) {
+	storage := &fakeExpiring{pending: map[string]int{"*": 5}, fail: errors.New("deadlock detected")}
+	retention, err := NewRetention(storage, retentionConf(), *logger.NewLogger("scheduler", "test", "error"))
+	require.NoError(t, err)
+
+	report, err := retention.Run(context.Background(), time.Now())
+	require.ErrorContains(t, err, "rule user "+alice+", finished events: deadlock detected")
+	require.Equal(t, 5, report.Rules[1].Ended)
+}
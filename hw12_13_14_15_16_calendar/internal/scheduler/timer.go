@@ -0,0 +1,163 @@
+// Package scheduler fires event reminders at the time they are due.
+package scheduler
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+// Source is the part of the storage the timer reads reminders from and records the sent ones in.
+type Source interface {
+	UpcomingReminders(ctx context.Context, now, until time.Time) ([]models.Event, error)
+	MarkReminderSent(ctx context.Context, eventID string, notifyAt time.Time) error
+}
+
+// Publisher sends the reminder of an event.
+type Publisher func(ctx context.Context, ev models.Event) error
+
+// Timer keeps the reminders due within the horizon in a min-heap and sleeps until the earliest
+// one. It reloads them every refresh interval and whenever Changed is called, so edits made
+// meanwhile are picked up. Reminders already overdue when loaded fire at once, as long as the
+// event has not started. Each reminder fires once, also across restarts and leader changes, as
+// the sent ones are marked in the storage; one that fails to publish is retried on the next reload.
+type Timer struct {
+	source  Source
+	publish Publisher
+	refresh time.Duration
+	horizon time.Duration
+	logger  logger.Logger
+
+	queue reminderQueue
+	// fired remembers the sent reminders until their events start, so reloads skip them even
+	// when marking them in the storage failed.
+	fired   map[string]firedReminder
+	changed chan struct{}
+}
+
+type firedReminder struct {
+	at, start time.Time
+}
+
+func NewTimer(source Source, publish Publisher, refresh, horizon time.Duration, logg logger.Logger) *Timer {
+	return &Timer{
+		source:  source,
+		publish: publish,
+		refresh: refresh,
+		horizon: horizon,
+		logger:  logg,
+		fired:   make(map[string]firedReminder),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Changed asks for a reload. It never blocks, and calls made before the reload starts are merged.
+func (t *Timer) Changed() {
+	select {
+	case t.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Run fires reminders until ctx is done.
+func (t *Timer) Run(ctx context.Context) error {
+	t.reload(ctx)
+
+	refresh := time.NewTicker(t.refresh)
+	defer refresh.Stop()
+	wake := time.NewTimer(0)
+	defer wake.Stop()
+
+	for {
+		wake.Reset(t.untilNext())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-refresh.C:
+			t.reload(ctx)
+		case <-t.changed:
+			t.reload(ctx)
+		case <-wake.C:
+			t.fireDue(ctx)
+		}
+	}
+}
+
+// untilNext is how long to sleep before the earliest reminder; with none queued the timer
+// only wakes up for reloads.
+func (t *Timer) untilNext() time.Duration {
+	if len(t.queue) == 0 {
+		return t.refresh
+	}
+	return max(time.Until(t.queue[0].at), 0)
+}
+
+func (t *Timer) reload(ctx context.Context) {
+	now := time.Now()
+	events, err := t.source.UpcomingReminders(ctx, now, now.Add(t.horizon))
+	if err != nil {
+		t.logger.Error(fmt.Sprintf("load reminders: %v", err))
+		return
+	}
+
+	for id, f := range t.fired {
+		if !f.start.After(now) {
+			delete(t.fired, id)
+		}
+	}
+
+	queue := make(reminderQueue, 0, len(events))
+	for _, ev := range events {
+		at := ev.NotifyAt()
+		if f, ok := t.fired[ev.ID]; ok && f.at.Equal(at) {
+			continue
+		}
+		queue = append(queue, reminder{at: at, event: ev})
+	}
+	heap.Init(&queue)
+	t.queue = queue
+	t.logger.Debug(fmt.Sprintf("reminders loaded count=%d", len(queue)))
+}
+
+func (t *Timer) fireDue(ctx context.Context) {
+	now := time.Now()
+	for len(t.queue) > 0 && !t.queue[0].at.After(now) {
+		r := heap.Pop(&t.queue).(reminder)
+		if err := t.publish(ctx, r.event); err != nil {
+			t.logger.Error(fmt.Sprintf("publish reminder of event %s: %v", r.event.ID, err))
+			continue
+		}
+		t.fired[r.event.ID] = firedReminder{at: r.at, start: r.event.Date}
+		if err := t.source.MarkReminderSent(ctx, r.event.ID, r.at); err != nil {
+			t.logger.Error(fmt.Sprintf("mark reminder of event %s sent: %v", r.event.ID, err))
+		}
+	}
+}
+
+type reminder struct {
+	at    time.Time
+	event models.Event
+}
+
+// reminderQueue is a min-heap of reminders by due time.
+type reminderQueue []reminder
+
+func (q reminderQueue) Len() int           { return len(q) }
+func (q reminderQueue) Less(i, j int) bool { return q[i].at.Before(q[j].at) }
+func (q reminderQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *reminderQueue) Push(x any) {
+	*q = append(*q, x.(reminder))
+}
+
+func (q *reminderQueue) Pop() any {
+	old := *q
+	r := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return r
+}
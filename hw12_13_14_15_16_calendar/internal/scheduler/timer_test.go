@@ -0,0 +1,200 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSource struct {
+	mu     sync.Mutex
+	events []models.Event
+	sent   map[string]time.Time
+	loads  int
+}
+
+func (s *fakeSource) UpcomingReminders(_ context.Context, now, until time.Time) ([]models.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loads++
+	var res []models.Event
+	for _, ev := range s.events {
+		if sent, ok := s.sent[ev.ID]; ok && sent.Equal(ev.NotifyAt()) {
+			continue
+		}
+		if ev.Date.After(now) && ev.NotifyAt().Before(until) {
+			res = append(res, ev)
+		}
+	}
+	return res, nil
+}
+
+func (s *fakeSource) MarkReminderSent(_ context.Context, eventID string, notifyAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.sent == nil {
+		s.sent = make(map[string]time.Time)
+	}
+	s.sent[eventID] = notifyAt
+	return nil
+}
+
+func (s *fakeSource) set(events ...models.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = events
+}
+
+// reminderIn returns an event whose reminder is due after d.
+func reminderIn(id string, d time.Duration) models.Event {
+	before := "1h"
+	return models.Event{ID: id, Date: time.Now().Add(time.Hour + d), NotifyBefore: &before}
+}
+
+type published struct {
+	id string
+	at time.Time
+}
+
+func startTimer(t *testing.T, source Source, publish Publisher) *Timer {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	timer := NewTimer(source, publish, time.Hour, 2*time.Hour, *logger.NewLogger("scheduler", "test", "error"))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, timer.Run(ctx))
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return timer
+}
+
+func recorder() (Publisher, <-chan published) {
+	ch := make(chan published, 10)
+	return func(_ context.Context, ev models.Event) error {
+		ch <- published{id: ev.ID, at: time.Now()}
+		return nil
+	}, ch
+}
+
+func receive(t *testing.T, ch <-chan published) published {
+	t.Helper()
+	select {
+	case p := <-ch:
+		return p
+	case <-time.After(2 * time.Second):
+		t.Fatal("no reminder published")
+		return published{}
+	}
+}
+
+func requireNothing(t *testing.T, ch <-chan published, wait time.Duration) {
+	t.Helper()
+	select {
+	case p := <-ch:
+		t.Fatalf("unexpected reminder of %s", p.id)
+	case <-time.After(wait):
+	}
+}
+
+func TestTimerFiresAtDueTime(t *testing.T) {
+	source := &fakeSource{}
+	late, early := reminderIn("late", 300*time.Millisecond), reminderIn("early", 100*time.Millisecond)
+	source.set(late, early)
+	publish, ch := recorder()
+	startTimer(t, source, publish)
+
+	first := receive(t, ch)
+	require.Equal(t, "early", first.id)
+	require.False(t, first.at.Before(early.NotifyAt()))
+	require.Less(t, first.at.Sub(early.NotifyAt()), 100*time.Millisecond)
+
+	second := receive(t, ch)
+	require.Equal(t, "late", second.id)
+	require.False(t, second.at.Before(late.NotifyAt()))
+
+	source.mu.Lock()
+	defer source.mu.Unlock()
+	require.Equal(t, 1, source.loads, "the storage is not polled while waiting")
+}
+
+func TestTimerReloadsOnChange(t *testing.T) {
+	source := &fakeSource{}
+	publish, ch := recorder()
+	timer := startTimer(t, source, publish)
+
+	source.set(reminderIn("new", 50*time.Millisecond))
+	timer.Changed()
+	require.Equal(t, "new", receive(t, ch).id)
+
+	// Moving the event moves its reminder.
+	moved := reminderIn("new", 200*time.Millisecond)
+	source.set(moved)
+	timer.Changed()
+	p := receive(t, ch)
+	require.Equal(t, "new", p.id)
+	require.False(t, p.at.Before(moved.NotifyAt()))
+}
+
+func TestTimerFiresOverdueReminderOnce(t *testing.T) {
+	source := &fakeSource{}
+	source.set(reminderIn("overdue", -time.Minute))
+	publish, ch := recorder()
+	timer := startTimer(t, source, publish)
+
+	require.Equal(t, "overdue", receive(t, ch).id)
+	timer.Changed()
+	timer.Changed()
+	requireNothing(t, ch, 100*time.Millisecond)
+}
+
+func TestTimerSkipsRemindersSentBeforeRestart(t *testing.T) {
+	source := &fakeSource{}
+	source.set(reminderIn("overdue", -time.Minute))
+	publish, ch := recorder()
+	ctx, cancel := context.WithCancel(context.Background())
+	first := NewTimer(source, publish, time.Hour, 2*time.Hour, *logger.NewLogger("scheduler", "test", "error"))
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, first.Run(ctx))
+	}()
+	require.Equal(t, "overdue", receive(t, ch).id)
+	cancel()
+	<-done
+
+	// A new leader starts with an empty memory of its own.
+	startTimer(t, source, publish)
+	requireNothing(t, ch, 100*time.Millisecond)
+}
+
+func TestTimerRetriesFailedPublish(t *testing.T) {
+	source := &fakeSource{}
+	source.set(reminderIn("flaky", 0))
+	ch := make(chan published, 10)
+	var mu sync.Mutex
+	fail := true
+	timer := startTimer(t, source, func(_ context.Context, ev models.Event) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if fail {
+			fail = false
+			return errors.New("broker down")
+		}
+		ch <- published{id: ev.ID, at: time.Now()}
+		return nil
+	})
+
+	requireNothing(t, ch, 50*time.Millisecond)
+	timer.Changed()
+	require.Equal(t, "flaky", receive(t, ch).id)
+}
@@ -0,0 +1,198 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// InProcess lets the HTTP gateway call the application directly while still running every
+// call through the same interceptors as the gRPC server. Methods missing here answer
+// Unimplemented rather than silently skipping the interceptors. The gateway cannot stream
+// in-process, so the streaming methods, such as the attachment upload and download, are only
+// served by the loopback gateway.
+type InProcess struct {
+	proto.UnimplementedCalendarServer
+	app         Application
+	interceptor grpc.UnaryServerInterceptor
+}
+
+func NewInProcess(app Application, interceptors ...grpc.UnaryServerInterceptor) *InProcess {
+	return &InProcess{app: app, interceptor: chainUnary(interceptors)}
+}
+
+// invoke calls h through the interceptor chain as if the request had arrived as method.
+func invoke[Req, Res any](
+	ctx context.Context,
+	s *InProcess,
+	method string,
+	req Req,
+	h func(context.Context, Req) (Res, error),
+) (Res, error) {
+	info := &grpc.UnaryServerInfo{Server: s.app, FullMethod: method}
+	resp, err := s.interceptor(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return h(ctx, req.(Req))
+	})
+	if err != nil {
+		var zero Res
+		return zero, err
+	}
+	return resp.(Res), nil
+}
+
+// chainUnary composes interceptors so the first one is the outermost, like grpc.ChainUnaryInterceptor.
+func chainUnary(interceptors []grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		next := handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			interceptor, inner := interceptors[i], next
+			next = func(ctx context.Context, req interface{}) (interface{}, error) {
+				return interceptor(ctx, req, info, inner)
+			}
+		}
+		return next(ctx, req)
+	}
+}
+
+func (s *InProcess) GetLiveZ(ctx context.Context, req *emptypb.Empty) (*emptypb.Empty, error) {
+	return invoke(ctx, s, proto.Calendar_GetLiveZ_FullMethodName, req, s.app.GetLiveZ)
+}
+
+func (s *InProcess) CreateEvent(ctx context.Context, req *proto.CreateEventReq) (*proto.Event, error) {
+	return invoke(ctx, s, proto.Calendar_CreateEvent_FullMethodName, req, s.app.CreateEvent)
+}
+
+func (s *InProcess) EditEvent(ctx context.Context, req *proto.EditEventReq) (*proto.Event, error) {
+	return invoke(ctx, s, proto.Calendar_EditEvent_FullMethodName, req, s.app.EditEvent)
+}
+
+func (s *InProcess) GetEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error) {
+	return invoke(ctx, s, proto.Calendar_GetEvent_FullMethodName, req, s.app.GetEvent)
+}
+
+func (s *InProcess) DeleteEvent(ctx context.Context, req *proto.EventByIdReq) (*emptypb.Empty, error) {
+	return invoke(ctx, s, proto.Calendar_DeleteEvent_FullMethodName, req, s.app.DeleteEvent)
+}
+
+func (s *InProcess) GetEventList(ctx context.Context, req *proto.GetEventListReq) (*proto.GetEventListRes, error) {
+	return invoke(ctx, s, proto.Calendar_GetEventList_FullMethodName, req, s.app.GetEventList)
+}
+
+func (s *InProcess) SearchEvents(ctx context.Context, req *proto.SearchEventsReq) (*proto.SearchEventsRes, error) {
+	return invoke(ctx, s, proto.Calendar_SearchEvents_FullMethodName, req, s.app.SearchEvents)
+}
+
+func (s *InProcess) ListDeletedEvents(
+	ctx context.Context,
+	req *proto.ListDeletedEventsReq,
+) (*proto.GetEventListRes, error) {
+	return invoke(ctx, s, proto.Calendar_ListDeletedEvents_FullMethodName, req, s.app.ListDeletedEvents)
+}
+
+func (s *InProcess) RestoreEvent(ctx context.Context, req *proto.EventByIdReq) (*proto.Event, error) {
+	return invoke(ctx, s, proto.Calendar_RestoreEvent_FullMethodName, req, s.app.RestoreEvent)
+}
+
+func (s *InProcess) BatchCreateEvents(
+	ctx context.Context,
+	req *proto.BatchCreateEventsReq,
+) (*proto.BatchEventsRes, error) {
+	return invoke(ctx, s, proto.Calendar_BatchCreateEvents_FullMethodName, req, s.app.BatchCreateEvents)
+}
+
+func (s *InProcess) BatchEditEvents(ctx context.Context, req *proto.BatchEditEventsReq) (*proto.BatchEventsRes, error) {
+	return invoke(ctx, s, proto.Calendar_BatchEditEvents_FullMethodName, req, s.app.BatchEditEvents)
+}
+
+func (s *InProcess) BatchDeleteEvents(
+	ctx context.Context,
+	req *proto.BatchDeleteEventsReq,
+) (*proto.BatchEventsRes, error) {
+	return invoke(ctx, s, proto.Calendar_BatchDeleteEvents_FullMethodName, req, s.app.BatchDeleteEvents)
+}
+
+func (s *InProcess) RespondToEvent(ctx context.Context, req *proto.RespondToEventReq) (*proto.Event, error) {
+	return invoke(ctx, s, proto.Calendar_RespondToEvent_FullMethodName, req, s.app.RespondToEvent)
+}
+
+func (s *InProcess) CreateCalendar(ctx context.Context, req *proto.CreateCalendarReq) (*proto.UserCalendar, error) {
+	return invoke(ctx, s, proto.Calendar_CreateCalendar_FullMethodName, req, s.app.CreateCalendar)
+}
+
+func (s *InProcess) GetCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*proto.UserCalendar, error) {
+	return invoke(ctx, s, proto.Calendar_GetCalendar_FullMethodName, req, s.app.GetCalendar)
+}
+
+func (s *InProcess) ListCalendars(ctx context.Context, req *proto.ListCalendarsReq) (*proto.ListCalendarsRes, error) {
+	return invoke(ctx, s, proto.Calendar_ListCalendars_FullMethodName, req, s.app.ListCalendars)
+}
+
+func (s *InProcess) DeleteCalendar(ctx context.Context, req *proto.CalendarByIdReq) (*emptypb.Empty, error) {
+	return invoke(ctx, s, proto.Calendar_DeleteCalendar_FullMethodName, req, s.app.DeleteCalendar)
+}
+
+func (s *InProcess) ShareCalendar(ctx context.Context, req *proto.ShareCalendarReq) (*proto.UserCalendar, error) {
+	return invoke(ctx, s, proto.Calendar_ShareCalendar_FullMethodName, req, s.app.ShareCalendar)
+}
+
+func (s *InProcess) UnshareCalendar(ctx context.Context, req *proto.UnshareCalendarReq) (*proto.UserCalendar, error) {
+	return invoke(ctx, s, proto.Calendar_UnshareCalendar_FullMethodName, req, s.app.UnshareCalendar)
+}
+
+func (s *InProcess) FreeBusy(ctx context.Context, req *proto.FreeBusyReq) (*proto.FreeBusyRes, error) {
+	return invoke(ctx, s, proto.Calendar_FreeBusy_FullMethodName, req, s.app.FreeBusy)
+}
+
+func (s *InProcess) FindSlot(ctx context.Context, req *proto.FindSlotReq) (*proto.FindSlotRes, error) {
+	return invoke(ctx, s, proto.Calendar_FindSlot_FullMethodName, req, s.app.FindSlot)
+}
+
+func (s *InProcess) CreateLabel(ctx context.Context, req *proto.CreateLabelReq) (*proto.Label, error) {
+	return invoke(ctx, s, proto.Calendar_CreateLabel_FullMethodName, req, s.app.CreateLabel)
+}
+
+func (s *InProcess) ListLabels(ctx context.Context, req *proto.ListLabelsReq) (*proto.ListLabelsRes, error) {
+	return invoke(ctx, s, proto.Calendar_ListLabels_FullMethodName, req, s.app.ListLabels)
+}
+
+func (s *InProcess) DeleteLabel(ctx context.Context, req *proto.LabelByIdReq) (*emptypb.Empty, error) {
+	return invoke(ctx, s, proto.Calendar_DeleteLabel_FullMethodName, req, s.app.DeleteLabel)
+}
+
+func (s *InProcess) CreateWebhook(ctx context.Context, req *proto.CreateWebhookReq) (*proto.Webhook, error) {
+	return invoke(ctx, s, proto.Calendar_CreateWebhook_FullMethodName, req, s.app.CreateWebhook)
+}
+
+func (s *InProcess) ListWebhooks(ctx context.Context, req *proto.ListWebhooksReq) (*proto.ListWebhooksRes, error) {
+	return invoke(ctx, s, proto.Calendar_ListWebhooks_FullMethodName, req, s.app.ListWebhooks)
+}
+
+func (s *InProcess) DeleteWebhook(ctx context.Context, req *proto.WebhookByIdReq) (*emptypb.Empty, error) {
+	return invoke(ctx, s, proto.Calendar_DeleteWebhook_FullMethodName, req, s.app.DeleteWebhook)
+}
+
+func (s *InProcess) ListWebhookDeliveries(
+	ctx context.Context,
+	req *proto.ListWebhookDeliveriesReq,
+) (*proto.ListWebhookDeliveriesRes, error) {
+	return invoke(ctx, s, proto.Calendar_ListWebhookDeliveries_FullMethodName, req, s.app.ListWebhookDeliveries)
+}
+
+func (s *InProcess) ListAttachments(ctx context.Context, req *proto.EventByIdReq) (*proto.ListAttachmentsRes, error) {
+	return invoke(ctx, s, proto.Calendar_ListAttachments_FullMethodName, req, s.app.ListAttachments)
+}
+
+func (s *InProcess) GetAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*proto.Attachment, error) {
+	return invoke(ctx, s, proto.Calendar_GetAttachment_FullMethodName, req, s.app.GetAttachment)
+}
+
+func (s *InProcess) DeleteAttachment(ctx context.Context, req *proto.AttachmentByIdReq) (*emptypb.Empty, error) {
+	return invoke(ctx, s, proto.Calendar_DeleteAttachment_FullMethodName, req, s.app.DeleteAttachment)
+}
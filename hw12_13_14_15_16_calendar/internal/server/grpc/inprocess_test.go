@@ -0,0 +1,94 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/ratelimit"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type stubApp struct {
+	proto.UnimplementedCalendarServer
+}
+
+func (stubApp) GetEvent(_ context.Context, req *proto.EventByIdReq) (*proto.Event, error) {
+	return &proto.Event{Id: req.EventId}, nil
+}
+
+func TestInProcessInterceptsEveryMethod(t *testing.T) {
+	var seen []string
+	record := func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		seen = append(seen, info.FullMethod)
+		return handler(ctx, req)
+	}
+	srv := reflect.ValueOf(NewInProcess(stubApp{}, record))
+
+	for _, m := range proto.Calendar_ServiceDesc.Methods {
+		method := srv.MethodByName(m.MethodName)
+		require.True(t, method.IsValid(), m.MethodName)
+
+		req := reflect.New(method.Type().In(1).Elem())
+		method.Call([]reflect.Value{reflect.ValueOf(context.Background()), req})
+
+		full := "/" + proto.Calendar_ServiceDesc.ServiceName + "/" + m.MethodName
+		require.NotEmpty(t, seen, "%s bypasses the interceptors", m.MethodName)
+		assert.Equal(t, full, seen[len(seen)-1])
+	}
+}
+
+func TestInProcessInterceptorOrder(t *testing.T) {
+	var order []string
+	named := func(name string) grpc.UnaryServerInterceptor {
+		return func(
+			ctx context.Context,
+			req interface{},
+			_ *grpc.UnaryServerInfo,
+			handler grpc.UnaryHandler,
+		) (interface{}, error) {
+			order = append(order, name)
+			return handler(ctx, req)
+		}
+	}
+	srv := NewInProcess(stubApp{}, named("outer"), named("inner"))
+
+	res, err := srv.GetEvent(context.Background(), &proto.EventByIdReq{EventId: "42"})
+	require.NoError(t, err)
+	assert.Equal(t, "42", res.Id)
+	assert.Equal(t, []string{"outer", "inner"}, order)
+
+	deny := func(context.Context, interface{}, *grpc.UnaryServerInfo, grpc.UnaryHandler) (interface{}, error) {
+		return nil, status.Error(codes.ResourceExhausted, "slow down")
+	}
+	res, err = NewInProcess(stubApp{}, deny).GetEvent(context.Background(), &proto.EventByIdReq{EventId: "42"})
+	assert.Nil(t, res)
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+}
+
+func TestPeerIPFromGateway(t *testing.T) {
+	assert.Equal(t, "unknown", peerIP(context.Background(), nil))
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "10.0.0.7, 10.0.0.1"))
+	assert.Equal(t, "10.0.0.1", peerIP(ctx, nil), "in-process calls carry no peer; the gateway appends its own")
+
+	proxies := ratelimit.NewProxies(configuration.RateLimitConf{TrustedProxies: []string{"10.0.0.1"}})
+	assert.Equal(t, "10.0.0.7", peerIP(ctx, proxies), "a trusted proxy reports the client")
+
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("203.0.113.9"), Port: 4000}})
+	assert.Equal(t, "203.0.113.9", peerIP(ctx, proxies), "direct callers are not believed")
+}
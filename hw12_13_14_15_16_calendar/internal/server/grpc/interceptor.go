@@ -3,15 +3,18 @@ package grpc
 import (
 	"context"
 	"net"
+	"strings"
 	"time"
 
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/ratelimit"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 )
 
-func UnaryLoggingInterceptor(log Logger) grpc.UnaryServerInterceptor {
+func UnaryLoggingInterceptor(log Logger, proxies ratelimit.Proxies) grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
 		req interface{},
@@ -19,14 +22,7 @@ func UnaryLoggingInterceptor(log Logger) grpc.UnaryServerInterceptor {
 		handler grpc.UnaryHandler,
 	) (resp interface{}, err error) {
 		start := time.Now()
-		var ip string
-
-		if p, ok := peer.FromContext(ctx); ok {
-			ip, _, _ = net.SplitHostPort(p.Addr.String())
-		}
-		if ip == "" {
-			ip = "unknown"
-		}
+		ip := peerIP(ctx, proxies)
 
 		userAgent := getUserAgent(ctx)
 
@@ -48,6 +44,100 @@ func UnaryLoggingInterceptor(log Logger) grpc.UnaryServerInterceptor {
 	}
 }
 
+// StreamLoggingInterceptor logs a streaming call once it ends.
+func StreamLoggingInterceptor(log Logger, proxies ratelimit.Proxies) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := ss.Context()
+
+		err := handler(srv, ss)
+
+		log.Info(
+			"gRPC stream handled | " +
+				"IP=" + peerIP(ctx, proxies) + " | " +
+				"Time=" + start.Format(time.RFC3339) + " | " +
+				"Method=" + info.FullMethod + " | " +
+				"Code=" + status.Code(err).String() + " | " +
+				"Latency=" + time.Since(start).String() + " | " +
+				"UserAgent=" + getUserAgent(ctx),
+		)
+		return err
+	}
+}
+
+// UnaryRateLimitInterceptor rejects calls over the client's quota with ResourceExhausted and a
+// retry-after header. Calls relayed by the local HTTP gateway are charged to the original client.
+func UnaryRateLimitInterceptor(
+	quota *ratelimit.Quota,
+	userHeader string,
+	proxies ratelimit.Proxies,
+) grpc.UnaryServerInterceptor {
+	userKey := strings.ToLower(userHeader)
+	return func(
+		ctx context.Context,
+		req interface{},
+		_ *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		if ok, wait := quota.Allow(peerIP(ctx, proxies), firstMetadata(ctx, userKey)); !ok {
+			retryAfter := ratelimit.RetryAfter(wait)
+			_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", retryAfter))
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %ss", retryAfter)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimitInterceptor charges a streaming call once, when it starts, like a unary call.
+func StreamRateLimitInterceptor(
+	quota *ratelimit.Quota,
+	userHeader string,
+	proxies ratelimit.Proxies,
+) grpc.StreamServerInterceptor {
+	userKey := strings.ToLower(userHeader)
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		if ok, wait := quota.Allow(peerIP(ctx, proxies), firstMetadata(ctx, userKey)); !ok {
+			retryAfter := ratelimit.RetryAfter(wait)
+			_ = ss.SetHeader(metadata.Pairs("retry-after", retryAfter))
+			return status.Errorf(codes.ResourceExhausted, "rate limit exceeded, retry after %ss", retryAfter)
+		}
+		return handler(srv, ss)
+	}
+}
+
+// peerIP is the address of the caller. Calls from the gateway, over the loopback interface or
+// in-process without any peer, come from the client it reports in x-forwarded-for. The gateway
+// appends the address of its HTTP peer there, so the client is the right-most hop that is not a
+// trusted proxy; whatever the client sent itself stays on the left.
+func peerIP(ctx context.Context, proxies ratelimit.Proxies) string {
+	ip := ""
+	if p, ok := peer.FromContext(ctx); ok {
+		var err error
+		if ip, _, err = net.SplitHostPort(p.Addr.String()); err != nil {
+			ip = p.Addr.String()
+		}
+		if addr := net.ParseIP(ip); addr == nil || !addr.IsLoopback() {
+			return ip
+		}
+	}
+	if ip == "" {
+		ip = "unknown"
+	}
+	return proxies.Forwarded(firstMetadata(ctx, "x-forwarded-for"), ip)
+}
+
+func firstMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(key); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
 func getUserAgent(ctx context.Context) string {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
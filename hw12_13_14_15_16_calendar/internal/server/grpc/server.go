@@ -6,8 +6,11 @@ import (
 	"time"
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/ratelimit"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/tlsutil"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -30,15 +33,24 @@ type Logger interface {
 	Fatal(msg string)
 }
 
-func NewGrpcServer(cfg *configuration.Config, logger Logger, app Application) *Server {
+func NewGrpcServer(cfg *configuration.Config, logger Logger, app Application) (*Server, error) {
 	opts := []grpc.ServerOption{
-		grpc.ChainUnaryInterceptor(UnaryLoggingInterceptor(logger)),
+		grpc.ChainUnaryInterceptor(UnaryInterceptors(cfg, logger)...),
+		grpc.ChainStreamInterceptor(StreamInterceptors(cfg, logger)...),
 	}
 
 	if cfg.System.Grpc.ConnectionTimeout > 0 {
 		opts = append(opts, grpc.ConnectionTimeout(time.Second*time.Duration(cfg.System.Grpc.ConnectionTimeout)))
 	}
 
+	if cfg.System.TLS.Enable {
+		creds, err := serverCredentials(cfg.System.TLS, logger)
+		if err != nil {
+			return nil, fmt.Errorf("grpc tls: %w", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+	}
+
 	srv := grpc.NewServer(opts...)
 	proto.RegisterCalendarServer(srv, app)
 	reflection.Register(srv)
@@ -48,7 +60,40 @@ func NewGrpcServer(cfg *configuration.Config, logger Logger, app Application) *S
 		logger:     logger,
 		app:        app,
 		cfg:        cfg,
+	}, nil
+}
+
+// UnaryInterceptors are applied to every call, whether it arrives on the gRPC port or from the
+// in-process gateway.
+func UnaryInterceptors(cfg *configuration.Config, logger Logger) []grpc.UnaryServerInterceptor {
+	rl := cfg.System.RateLimit
+	proxies := ratelimit.NewProxies(rl)
+	return []grpc.UnaryServerInterceptor{
+		UnaryLoggingInterceptor(logger, proxies),
+		UnaryRateLimitInterceptor(ratelimit.NewQuota(rl), rl.UserHeader, proxies),
+	}
+}
+
+// StreamInterceptors are applied to the streaming calls, which only arrive on the gRPC port.
+func StreamInterceptors(cfg *configuration.Config, logger Logger) []grpc.StreamServerInterceptor {
+	rl := cfg.System.RateLimit
+	proxies := ratelimit.NewProxies(rl)
+	return []grpc.StreamServerInterceptor{
+		StreamLoggingInterceptor(logger, proxies),
+		StreamRateLimitInterceptor(ratelimit.NewQuota(rl), rl.UserHeader, proxies),
+	}
+}
+
+func serverCredentials(cfg configuration.TLSConf, logger Logger) (credentials.TransportCredentials, error) {
+	reloader, err := tlsutil.NewReloader(cfg.CertFile, cfg.KeyFile, cfg.ReloadInterval, logger)
+	if err != nil {
+		return nil, err
+	}
+	tc, err := tlsutil.ServerConfig(cfg, reloader, true)
+	if err != nil {
+		return nil, err
 	}
+	return credentials.NewTLS(tc), nil
 }
 
 func (s *Server) Start() error {
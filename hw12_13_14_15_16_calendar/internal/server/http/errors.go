@@ -0,0 +1,65 @@
+package internalhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/genproto/googleapis/rpc/code"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// errorBody is the JSON error envelope of the REST API. Its shape is part of the API: clients
+// should match on status and the reason in details rather than on the message.
+type errorBody struct {
+	Error errorPayload `json:"error"`
+}
+
+type errorPayload struct {
+	Code    int               `json:"code"`
+	Status  string            `json:"status"`
+	Message string            `json:"message"`
+	Details []json.RawMessage `json:"details"`
+}
+
+var detailsMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// errorHandler renders gRPC errors of the gateway as errorBody, keeping the Retry-After the
+// rate limiter sets as gRPC metadata.
+func errorHandler(
+	ctx context.Context,
+	_ *runtime.ServeMux,
+	_ runtime.Marshaler,
+	w http.ResponseWriter,
+	_ *http.Request,
+	err error,
+) {
+	if md, ok := runtime.ServerMetadataFromContext(ctx); ok {
+		if v := md.HeaderMD.Get("retry-after"); len(v) > 0 {
+			w.Header().Set("Retry-After", v[0])
+		}
+	}
+	writeError(w, status.Convert(err))
+}
+
+func writeError(w http.ResponseWriter, st *status.Status) {
+	body := errorBody{Error: errorPayload{
+		Code:    runtime.HTTPStatusFromCode(st.Code()),
+		Status:  code.Code(st.Code()).String(),
+		Message: st.Message(),
+		Details: make([]json.RawMessage, 0),
+	}}
+	for _, d := range st.Proto().GetDetails() {
+		raw, err := detailsMarshaler.Marshal(d)
+		if err != nil {
+			continue
+		}
+		body.Error.Details = append(body.Error.Details, raw)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(body.Error.Code)
+	_ = json.NewEncoder(w).Encode(body)
+}
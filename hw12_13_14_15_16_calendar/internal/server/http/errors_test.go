@@ -0,0 +1,65 @@
+package internalhttp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorHandler(t *testing.T) {
+	st, err := status.New(codes.NotFound, "event not found: 42").WithDetails(
+		&errdetails.ErrorInfo{Reason: "EVENT_NOT_FOUND", Domain: "calendar"},
+	)
+	require.NoError(t, err)
+
+	w := httptest.NewRecorder()
+	errorHandler(context.Background(), nil, nil, w, httptest.NewRequest(http.MethodGet, "/", nil), st.Err())
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var body struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Status  string `json:"status"`
+			Message string `json:"message"`
+			Details []struct {
+				Type   string `json:"@type"`
+				Reason string `json:"reason"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, http.StatusNotFound, body.Error.Code)
+	assert.Equal(t, "NOT_FOUND", body.Error.Status)
+	assert.Equal(t, "event not found: 42", body.Error.Message)
+	require.Len(t, body.Error.Details, 1)
+	assert.Equal(t, "type.googleapis.com/google.rpc.ErrorInfo", body.Error.Details[0].Type)
+	assert.Equal(t, "EVENT_NOT_FOUND", body.Error.Details[0].Reason)
+}
+
+func TestErrorHandlerRetryAfter(t *testing.T) {
+	ctx := runtime.NewServerMetadataContext(context.Background(), runtime.ServerMetadata{
+		HeaderMD: metadata.Pairs("retry-after", "3"),
+	})
+
+	w := httptest.NewRecorder()
+	errorHandler(ctx, nil, nil, w, httptest.NewRequest(http.MethodGet, "/", nil),
+		status.Error(codes.ResourceExhausted, "rate limit exceeded"))
+
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "3", w.Header().Get("Retry-After"))
+	assert.JSONEq(t,
+		`{"error":{"code":429,"status":"RESOURCE_EXHAUSTED","message":"rate limit exceeded","details":[]}}`,
+		w.Body.String())
+}
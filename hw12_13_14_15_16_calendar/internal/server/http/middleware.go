@@ -4,8 +4,11 @@ import (
 	"fmt"
 	"net"
 	"net/http"
-	"strings"
 	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/ratelimit"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type responseWriter struct {
@@ -18,10 +21,10 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
-func loggingMiddleware(log Logger, next http.Handler) http.Handler {
+func loggingMiddleware(log Logger, proxies ratelimit.Proxies, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
-		ip := clientIP(r)
+		ip := clientIP(r, proxies)
 		rw := &responseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
 		next.ServeHTTP(rw, r)
@@ -44,17 +47,33 @@ func loggingMiddleware(log Logger, next http.Handler) http.Handler {
 	})
 }
 
-func clientIP(r *http.Request) string {
-	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
-		parts := strings.Split(forwarded, ",")
-		return strings.TrimSpace(parts[0])
+// rateLimitMiddleware rejects requests over the client's quota with 429 and a Retry-After header.
+func rateLimitMiddleware(
+	quota *ratelimit.Quota,
+	userHeader string,
+	proxies ratelimit.Proxies,
+	next http.Handler,
+) http.Handler {
+	if quota == nil {
+		return next
 	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ok, wait := quota.Allow(clientIP(r, proxies), r.Header.Get(userHeader)); !ok {
+			w.Header().Set("Retry-After", ratelimit.RetryAfter(wait))
+			writeError(w, status.New(codes.ResourceExhausted, "rate limit exceeded"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
 
+// clientIP is the peer of the request, or the client behind it when the peer is a trusted proxy.
+func clientIP(r *http.Request, proxies ratelimit.Proxies) string {
 	ip, _, err := net.SplitHostPort(r.RemoteAddr)
 	if err != nil {
-		return r.RemoteAddr
+		ip = r.RemoteAddr
 	}
-	return ip
+	return proxies.ClientIP(ip, r.Header.Get("X-Forwarded-For"))
 }
 
 func userAgent(r *http.Request) string {
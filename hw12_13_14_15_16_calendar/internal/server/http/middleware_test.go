@@ -0,0 +1,46 @@
+package internalhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/ratelimit"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	rl := configuration.RateLimitConf{
+		Enable:         true,
+		PerIP:          configuration.LimitConf{Rate: 1, Burst: 1},
+		TrustedProxies: []string{"10.0.0.0/8"},
+	}
+	h := rateLimitMiddleware(ratelimit.NewQuota(rl), "X-User-Id", ratelimit.NewProxies(rl),
+		http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+	do := func(remoteAddr, forwardedFor string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/event", nil)
+		r.RemoteAddr = remoteAddr
+		r.Header.Set("X-Forwarded-For", forwardedFor)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusNoContent, do("10.0.0.1:4000", "198.51.100.1").Code)
+
+	limited := do("10.0.0.2:4000", "198.51.100.1")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code, "the client is charged whichever proxy relays it")
+	assert.Equal(t, "1", limited.Header().Get("Retry-After"))
+
+	assert.Equal(t, http.StatusNoContent, do("10.0.0.1:4000", "198.51.100.2").Code)
+
+	assert.Equal(t, http.StatusNoContent, do("203.0.113.9:4000", "").Code)
+	assert.Equal(t, http.StatusTooManyRequests, do("203.0.113.9:4000", "198.51.100.3").Code,
+		"a forged X-Forwarded-For does not get a fresh bucket")
+	assert.Equal(t, http.StatusTooManyRequests, do("10.0.0.1:4000", "198.51.100.4, 203.0.113.9").Code,
+		"nor does one prepended to the hops a proxy appends")
+}
@@ -2,15 +2,21 @@ package internalhttp
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"net/http"
 	"strconv"
 	"time"
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/caldav"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/ratelimit"
+	grpcserver "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/server/grpc"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/tlsutil"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/encoding/protojson"
 )
@@ -34,8 +40,9 @@ type Logger interface {
 }
 
 func NewHTTPServer(cfg *configuration.Config, logger Logger, app Application) *Server {
-	gw := runtime.NewServeMux(runtime.WithMarshalerOption(runtime.MIMEWildcard,
-		&runtime.HTTPBodyMarshaler{
+	gw := runtime.NewServeMux(
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.HTTPBodyMarshaler{
 			Marshaler: &runtime.JSONPb{
 				MarshalOptions: protojson.MarshalOptions{
 					UseProtoNames:   true,
@@ -45,24 +52,52 @@ func NewHTTPServer(cfg *configuration.Config, logger Logger, app Application) *S
 					DiscardUnknown: true,
 				},
 			},
-		}))
+		}),
+	)
 
-	if err := proto.RegisterCalendarHandlerFromEndpoint(
-		context.Background(),
-		gw,
-		"127.0.0.1:"+strconv.Itoa(int(cfg.System.Grpc.Port)),
-		[]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}); err != nil {
+	var err error
+	dialCreds := insecure.NewCredentials()
+	var serverTLS *tls.Config
+	if cfg.System.TLS.Enable {
+		dialCreds, serverTLS, err = gatewayTLS(cfg.System.TLS, logger)
+		if err != nil {
+			logger.Error("http tls: " + err.Error())
+			return nil
+		}
+	}
+
+	if cfg.System.HTTP.Gateway == configuration.GatewayInProcess {
+		inProcess := grpcserver.NewInProcess(app, grpcserver.UnaryInterceptors(cfg, logger)...)
+		err = proto.RegisterCalendarHandlerServer(context.Background(), gw, inProcess)
+	} else {
+		err = proto.RegisterCalendarHandlerFromEndpoint(
+			context.Background(),
+			gw,
+			"127.0.0.1:"+strconv.Itoa(int(cfg.System.Grpc.Port)),
+			[]grpc.DialOption{grpc.WithTransportCredentials(dialCreds)})
+	}
+	if err != nil {
 		return nil
 	}
 
+	rl := cfg.System.RateLimit
+	quota := ratelimit.NewQuota(rl)
+	proxies := ratelimit.NewProxies(rl)
+
 	mux := http.NewServeMux()
 	mux.Handle("/", gw)
+	if cfg.System.HTTP.CalDAV {
+		dav := caldav.NewHandler(app, logger)
+		mux.Handle(caldav.Prefix, dav)
+		mux.Handle(caldav.WellKnown, dav)
+	}
 
 	httpServer := &http.Server{
 		Addr:         cfg.System.HTTP.Address,
-		Handler:      loggingMiddleware(logger, mux),
+		Handler:      loggingMiddleware(logger, proxies, rateLimitMiddleware(quota, rl.UserHeader, proxies, mux)),
 		ReadTimeout:  time.Duration(cfg.System.HTTP.ReadTimeout) * time.Second,
 		WriteTimeout: time.Duration(cfg.System.HTTP.WriteTimeout) * time.Second,
+		TLSConfig:    serverTLS,
 	}
 
 	return &Server{
@@ -72,10 +107,34 @@ func NewHTTPServer(cfg *configuration.Config, logger Logger, app Application) *S
 	}
 }
 
+// gatewayTLS returns the credentials of the gateway connection to the gRPC port and the
+// configuration of the HTTP listener, which does not ask browsers for client certificates.
+func gatewayTLS(cfg configuration.TLSConf, logger Logger) (credentials.TransportCredentials, *tls.Config, error) {
+	reloader, err := tlsutil.NewReloader(cfg.CertFile, cfg.KeyFile, cfg.ReloadInterval, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	client, err := tlsutil.ClientConfig(cfg, reloader)
+	if err != nil {
+		return nil, nil, err
+	}
+	server, err := tlsutil.ServerConfig(cfg, reloader, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	return credentials.NewTLS(client), server, nil
+}
+
 func (s *Server) Start() error {
 	go func() {
 		s.logger.Info("Starting HTTP server at " + s.httpServer.Addr)
-		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		var err error
+		if s.httpServer.TLSConfig != nil {
+			err = s.httpServer.ListenAndServeTLS("", "")
+		} else {
+			err = s.httpServer.ListenAndServe()
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
 			s.logger.Error("HTTP server error: " + err.Error())
 		}
 	}()
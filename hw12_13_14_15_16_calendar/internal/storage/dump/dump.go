@@ -0,0 +1,309 @@
+// Package dump moves calendar data between storages through a portable file.
+//
+// A dump is a gzip-compressed stream of JSON lines: a header naming the format and its version,
+// one record per calendar, label and event, and an end record with the number of records and the
+// SHA-256 of every line before it. Events in the trash are not dumped.
+package dump
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+const (
+	Format = "calendar-dump"
+	// Version is bumped whenever a record changes in a way older readers cannot handle.
+	Version = 1
+)
+
+// ErrCorrupt is returned for dumps that are truncated, altered or not dumps at all.
+var ErrCorrupt = errors.New("corrupt dump")
+
+const (
+	typeCalendar = "calendar"
+	typeLabel    = "label"
+	typeEvent    = "event"
+	typeEnd      = "end"
+)
+
+// maxLine bounds a single record, an event with a long description and many attendees included.
+const maxLine = 4 << 20
+
+type header struct {
+	Format    string    `json:"format"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type record struct {
+	Type     string          `json:"type"`
+	Calendar *calendarRecord `json:"calendar,omitempty"`
+	Label    *labelRecord    `json:"label,omitempty"`
+	Event    *eventRecord    `json:"event,omitempty"`
+	// Records and SHA256 are only set in the end record.
+	Records int    `json:"records,omitempty"`
+	SHA256  string `json:"sha256,omitempty"`
+}
+
+type calendarRecord struct {
+	ID          string        `json:"id"`
+	Owner       string        `json:"owner"`
+	Title       string        `json:"title"`
+	Description *string       `json:"description,omitempty"`
+	Shares      []shareRecord `json:"shares,omitempty"`
+}
+
+type shareRecord struct {
+	User string `json:"user"`
+	Role string `json:"role"`
+}
+
+type labelRecord struct {
+	Owner string  `json:"owner"`
+	Name  string  `json:"name"`
+	Color *string `json:"color,omitempty"`
+}
+
+type eventRecord struct {
+	ID           string           `json:"id"`
+	Title        string           `json:"title"`
+	Start        time.Time        `json:"start"`
+	End          time.Time        `json:"end"`
+	Description  *string          `json:"description,omitempty"`
+	User         string           `json:"user"`
+	NotifyBefore *string          `json:"notify_before,omitempty"`
+	CalendarID   *string          `json:"calendar_id,omitempty"`
+	Attendees    []attendeeRecord `json:"attendees,omitempty"`
+	TimeZone     string           `json:"time_zone,omitempty"`
+	AllDay       bool             `json:"all_day,omitempty"`
+	Labels       []string         `json:"labels,omitempty"`
+	Location     *string          `json:"location,omitempty"`
+	MeetingURL   *string          `json:"meeting_url,omitempty"`
+}
+
+type attendeeRecord struct {
+	User   string `json:"user"`
+	Status string `json:"status"`
+}
+
+// Stats counts the records written or restored.
+type Stats struct {
+	Calendars int
+	Labels    int
+	Events    int
+	// Failed counts the records Restore could not apply.
+	Failed int
+}
+
+// Dump writes every calendar, label and live event of st to w. Calendars and labels come first,
+// so Restore can create events in them. Attachments are left out: their content is not in st.
+func Dump(ctx context.Context, st storage.Storage, w io.Writer, now time.Time) (Stats, error) {
+	var stats Stats
+	gz := gzip.NewWriter(w)
+	enc := &lineWriter{w: bufio.NewWriter(gz), sum: sha256.New()}
+
+	if err := enc.write(header{Format: Format, Version: Version, CreatedAt: now.UTC()}); err != nil {
+		return stats, err
+	}
+
+	owners, err := st.OwnerList(ctx)
+	if err != nil {
+		return stats, fmt.Errorf("dump: %w", err)
+	}
+	for _, owner := range owners {
+		calendars, err := st.CalendarList(ctx, &models.ListCalendarsReq{User: owner})
+		if err != nil {
+			return stats, fmt.Errorf("dump calendars of %s: %w", owner, err)
+		}
+		for _, cal := range calendars {
+			// Calendars shared with the owner are dumped with their own owner.
+			if cal.Owner != owner {
+				continue
+			}
+			if err := enc.write(record{Type: typeCalendar, Calendar: toCalendarRecord(cal)}); err != nil {
+				return stats, err
+			}
+			stats.Calendars++
+		}
+
+		labels, err := st.LabelList(ctx, &models.ListLabelsReq{User: owner})
+		if err != nil {
+			return stats, fmt.Errorf("dump labels of %s: %w", owner, err)
+		}
+		for _, l := range labels {
+			rec := &labelRecord{Owner: l.Owner, Name: l.Name, Color: l.Color}
+			if err := enc.write(record{Type: typeLabel, Label: rec}); err != nil {
+				return stats, err
+			}
+			stats.Labels++
+		}
+	}
+
+	err = st.EventScan(ctx, func(ev models.Event) error {
+		if err := enc.write(record{Type: typeEvent, Event: toEventRecord(ev)}); err != nil {
+			return err
+		}
+		stats.Events++
+		return nil
+	})
+	if err != nil {
+		return stats, fmt.Errorf("dump events: %w", err)
+	}
+
+	end := record{
+		Type:    typeEnd,
+		Records: stats.Calendars + stats.Labels + stats.Events,
+		SHA256:  hex.EncodeToString(enc.sum.Sum(nil)),
+	}
+	if err := enc.write(end); err != nil {
+		return stats, err
+	}
+	if err := enc.w.Flush(); err != nil {
+		return stats, fmt.Errorf("dump: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return stats, fmt.Errorf("dump: %w", err)
+	}
+	return stats, nil
+}
+
+// lineWriter writes JSON lines and hashes them.
+type lineWriter struct {
+	w   *bufio.Writer
+	sum hash.Hash
+}
+
+func (lw *lineWriter) write(v any) error {
+	line, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("dump: %w", err)
+	}
+	line = append(line, '\n')
+	lw.sum.Write(line)
+	if _, err := lw.w.Write(line); err != nil {
+		return fmt.Errorf("dump: %w", err)
+	}
+	return nil
+}
+
+// Verify reads the whole dump and checks its header, record count and checksum.
+func Verify(r io.Reader) error {
+	return read(r, func(record) error { return nil })
+}
+
+// read calls fn for every record of the dump. The end record is checked only after the last
+// call, so callers that must not act on a corrupt dump run Verify first.
+func read(r io.Reader, fn func(record) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	defer gz.Close()
+
+	sc := bufio.NewScanner(gz)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLine)
+	sum := sha256.New()
+
+	if !sc.Scan() {
+		return fmt.Errorf("%w: no header", ErrCorrupt)
+	}
+	var h header
+	if err := json.Unmarshal(sc.Bytes(), &h); err != nil || h.Format != Format {
+		return fmt.Errorf("%w: not a %s file", ErrCorrupt, Format)
+	}
+	if h.Version != Version {
+		return fmt.Errorf("unsupported dump version %d, expected %d", h.Version, Version)
+	}
+	sum.Write(sc.Bytes())
+	sum.Write([]byte{'\n'})
+
+	count := 0
+	for line := 2; sc.Scan(); line++ {
+		var rec record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return fmt.Errorf("%w: line %d: %w", ErrCorrupt, line, err)
+		}
+
+		if rec.Type == typeEnd {
+			if sc.Scan() {
+				return fmt.Errorf("%w: data after the end record", ErrCorrupt)
+			}
+			if err := sc.Err(); err != nil {
+				return fmt.Errorf("%w: %w", ErrCorrupt, err)
+			}
+			if rec.Records != count {
+				return fmt.Errorf("%w: %d records, end record says %d", ErrCorrupt, count, rec.Records)
+			}
+			if got := hex.EncodeToString(sum.Sum(nil)); got != rec.SHA256 {
+				return fmt.Errorf("%w: checksum mismatch", ErrCorrupt)
+			}
+			return nil
+		}
+
+		if err := checkRecord(rec); err != nil {
+			return fmt.Errorf("%w: line %d: %w", ErrCorrupt, line, err)
+		}
+		sum.Write(sc.Bytes())
+		sum.Write([]byte{'\n'})
+		count++
+		if err := fn(rec); err != nil {
+			return err
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	return fmt.Errorf("%w: truncated, no end record", ErrCorrupt)
+}
+
+func checkRecord(rec record) error {
+	switch {
+	case rec.Type == typeCalendar && rec.Calendar != nil,
+		rec.Type == typeLabel && rec.Label != nil,
+		rec.Type == typeEvent && rec.Event != nil:
+		return nil
+	}
+	return fmt.Errorf("unexpected %q record", rec.Type)
+}
+
+func toCalendarRecord(cal models.Calendar) *calendarRecord {
+	rec := &calendarRecord{ID: cal.ID, Owner: cal.Owner, Title: cal.Title, Description: cal.Description}
+	for _, sh := range cal.Shares {
+		rec.Shares = append(rec.Shares, shareRecord{User: sh.User, Role: string(sh.Role)})
+	}
+	return rec
+}
+
+func toEventRecord(ev models.Event) *eventRecord {
+	rec := &eventRecord{
+		ID:           ev.ID,
+		Title:        ev.Title,
+		Start:        ev.Date.UTC(),
+		End:          ev.EndTime.UTC(),
+		Description:  ev.Description,
+		User:         ev.User,
+		NotifyBefore: ev.NotifyBefore,
+		CalendarID:   ev.CalendarID,
+		TimeZone:     ev.TimeZone,
+		AllDay:       ev.AllDay,
+		Labels:       ev.Labels,
+		Location:     ev.Location,
+		MeetingURL:   ev.MeetingURL,
+	}
+	for _, a := range ev.Attendees {
+		rec.Attendees = append(rec.Attendees, attendeeRecord{User: a.User, Status: string(a.Status)})
+	}
+	return rec
+}
@@ -0,0 +1,161 @@
+package dump
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	memorystorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testLogger() logger.Logger {
+	return *logger.NewLogger("calendar", "test", "error")
+}
+
+func seed(t *testing.T) *memorystorage.LocalStorage {
+	t.Helper()
+	ctx := context.Background()
+	st := memorystorage.NewLocalStorage(testLogger())
+
+	team, err := st.CalendarCreate(ctx, &models.CreateCalendarReq{Owner: "alice", Title: "team"})
+	require.NoError(t, err)
+	_, err = st.CalendarShare(ctx, &models.ShareCalendarReq{CalendarID: team.ID, User: "bob", Role: models.RoleWrite})
+	require.NoError(t, err)
+	// Owns a calendar but no events.
+	_, err = st.CalendarCreate(ctx, &models.CreateCalendarReq{Owner: "carol", Title: "empty"})
+	require.NoError(t, err)
+	_, err = st.LabelCreate(ctx, &models.CreateLabelReq{Owner: "bob", Name: "work"})
+	require.NoError(t, err)
+
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	room := "Room 4"
+	ev, err := st.EventCreate(ctx, &models.CreateEventReq{
+		Title:      "planning",
+		Date:       start,
+		EndTime:    start.Add(time.Hour),
+		User:       "bob",
+		CalendarID: &team.ID,
+		Attendees:  []string{"alice", "carol"},
+		Labels:     []string{"work"},
+		TimeZone:   "Europe/Moscow",
+		Location:   &room,
+	})
+	require.NoError(t, err)
+	_, err = st.EventRespond(ctx, &models.RespondEventReq{EventID: ev.ID, User: "alice", Status: models.AttendeeAccepted})
+	require.NoError(t, err)
+
+	trashed, err := st.EventCreate(ctx, &models.CreateEventReq{
+		Title: "cancelled", Date: start.AddDate(0, 0, 1), EndTime: start.AddDate(0, 0, 1).Add(time.Hour), User: "bob",
+	})
+	require.NoError(t, err)
+	require.NoError(t, st.EventDelete(ctx, &models.EventIDReq{ID: trashed.ID}))
+	return st
+}
+
+func TestDumpRestore(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	stats, err := Dump(ctx, seed(t), &buf, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Calendars: 2, Labels: 1, Events: 1}, stats)
+
+	target := memorystorage.NewLocalStorage(testLogger())
+	stats, err = Restore(ctx, target, bytes.NewReader(buf.Bytes()), testLogger())
+	require.NoError(t, err)
+	assert.Equal(t, Stats{Calendars: 2, Labels: 1, Events: 1}, stats)
+
+	calendars, err := target.CalendarList(ctx, &models.ListCalendarsReq{User: "bob"})
+	require.NoError(t, err)
+	require.Len(t, calendars, 1)
+	assert.Equal(t, "alice", calendars[0].Owner)
+	assert.Equal(t, []models.CalendarShare{{User: "bob", Role: models.RoleWrite}}, calendars[0].Shares)
+
+	owners, err := target.OwnerList(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob", "carol"}, owners)
+
+	var events []models.Event
+	require.NoError(t, target.EventScan(ctx, func(ev models.Event) error {
+		events = append(events, ev)
+		return nil
+	}))
+	require.Len(t, events, 1)
+	ev := events[0]
+	assert.Equal(t, "planning", ev.Title)
+	assert.Equal(t, calendars[0].ID, *ev.CalendarID)
+	assert.Equal(t, []string{"work"}, ev.Labels)
+	assert.Equal(t, "Europe/Moscow", ev.TimeZone)
+	require.NotNil(t, ev.Location)
+	assert.Equal(t, "Room 4", *ev.Location)
+	assert.Nil(t, ev.MeetingURL)
+	assert.Equal(t, []models.Attendee{
+		{User: "alice", Status: models.AttendeeAccepted},
+		{User: "carol", Status: models.AttendeeNeedsAction},
+	}, ev.Attendees)
+}
+
+// lines returns the decompressed JSON lines of a dump.
+func lines(t *testing.T, data []byte) []string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	raw, err := io.ReadAll(gz)
+	require.NoError(t, err)
+	return strings.SplitAfter(strings.TrimSuffix(string(raw), "\n"), "\n")
+}
+
+func compress(t *testing.T, lines []string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(strings.Join(lines, "")))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestVerifyRejectsCorruptDumps(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := Dump(context.Background(), seed(t), &buf, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, Verify(bytes.NewReader(buf.Bytes())))
+	orig := lines(t, buf.Bytes())
+
+	altered := append([]string(nil), orig...)
+	altered[len(altered)-2] = strings.Replace(altered[len(altered)-2], "planning", "planing", 1)
+	truncated := orig[:len(orig)-1]
+	dropped := append(append([]string(nil), orig[:1]...), orig[2:]...)
+
+	for name, data := range map[string][]byte{
+		"altered":   compress(t, altered),
+		"truncated": compress(t, truncated),
+		"dropped":   compress(t, dropped),
+		"plain":     []byte(strings.Join(orig, "")),
+		"other":     compress(t, []string{`{"format":"other"}` + "\n"}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := Verify(bytes.NewReader(data))
+			require.ErrorIs(t, err, ErrCorrupt)
+
+			target := memorystorage.NewLocalStorage(testLogger())
+			_, err = Restore(context.Background(), target, bytes.NewReader(data), testLogger())
+			require.ErrorIs(t, err, ErrCorrupt)
+			owners, err := target.OwnerList(context.Background())
+			require.NoError(t, err)
+			require.Empty(t, owners)
+		})
+	}
+
+	newer := append([]string(nil), orig...)
+	newer[0] = strings.Replace(newer[0], `"version":1`, `"version":2`, 1)
+	err = Verify(bytes.NewReader(compress(t, newer)))
+	require.ErrorContains(t, err, "unsupported dump version 2")
+}
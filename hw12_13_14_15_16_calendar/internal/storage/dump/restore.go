@@ -0,0 +1,140 @@
+package dump
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+// Restore verifies the dump and then creates its records in st through the regular storage
+// methods, so the data is checked like any other write and gets new IDs; calendar references
+// are mapped to the new calendars. Labels that already exist are reused. A record that cannot be
+// created is logged and counted as failed instead of stopping the restore, so st should be empty
+// to begin with.
+func Restore(ctx context.Context, st storage.Storage, r io.ReadSeeker, logg logger.Logger) (Stats, error) {
+	var stats Stats
+	if err := Verify(r); err != nil {
+		return stats, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return stats, fmt.Errorf("restore: %w", err)
+	}
+
+	rs := &restorer{st: st, calendars: make(map[string]string), stats: &stats}
+	err := read(r, func(rec record) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var err error
+		switch rec.Type {
+		case typeCalendar:
+			err = rs.calendar(ctx, rec.Calendar)
+		case typeLabel:
+			err = rs.label(ctx, rec.Label)
+		case typeEvent:
+			err = rs.event(ctx, rec.Event)
+		}
+		if err != nil {
+			logg.Error("restore: " + err.Error())
+			stats.Failed++
+		}
+		return nil
+	})
+	return stats, err
+}
+
+type restorer struct {
+	st storage.Storage
+	// calendars maps the dumped calendar IDs to the created ones.
+	calendars map[string]string
+	stats     *Stats
+}
+
+func (rs *restorer) calendar(ctx context.Context, rec *calendarRecord) error {
+	cal, err := rs.st.CalendarCreate(ctx, &models.CreateCalendarReq{
+		Owner:       rec.Owner,
+		Title:       rec.Title,
+		Description: rec.Description,
+	})
+	if err != nil {
+		return fmt.Errorf("calendar %s: %w", rec.ID, err)
+	}
+	rs.calendars[rec.ID] = cal.ID
+
+	for _, sh := range rec.Shares {
+		_, err := rs.st.CalendarShare(ctx, &models.ShareCalendarReq{
+			CalendarID: cal.ID,
+			User:       sh.User,
+			Role:       models.CalendarRole(sh.Role),
+		})
+		if err != nil {
+			return fmt.Errorf("calendar %s: share with %s: %w", rec.ID, sh.User, err)
+		}
+	}
+	rs.stats.Calendars++
+	return nil
+}
+
+func (rs *restorer) label(ctx context.Context, rec *labelRecord) error {
+	_, err := rs.st.LabelCreate(ctx, &models.CreateLabelReq{Owner: rec.Owner, Name: rec.Name, Color: rec.Color})
+	if err != nil && !errors.Is(err, calendarErrors.ErrLabelExists) {
+		return fmt.Errorf("label %s of %s: %w", rec.Name, rec.Owner, err)
+	}
+	rs.stats.Labels++
+	return nil
+}
+
+// event creates the event and then replays the attendees' responses, which creation resets.
+func (rs *restorer) event(ctx context.Context, rec *eventRecord) error {
+	req := &models.CreateEventReq{
+		Title:        rec.Title,
+		Date:         rec.Start,
+		EndTime:      rec.End,
+		Description:  rec.Description,
+		User:         rec.User,
+		NotifyBefore: rec.NotifyBefore,
+		TimeZone:     rec.TimeZone,
+		AllDay:       rec.AllDay,
+		Labels:       rec.Labels,
+		Location:     rec.Location,
+		MeetingURL:   rec.MeetingURL,
+	}
+	if rec.CalendarID != nil {
+		id, ok := rs.calendars[*rec.CalendarID]
+		if !ok {
+			return fmt.Errorf("event %s: %w", rec.ID, calendarErrors.NotFound(calendarErrors.ErrCalendarNotFound,
+				*rec.CalendarID))
+		}
+		req.CalendarID = &id
+	}
+	for _, a := range rec.Attendees {
+		req.Attendees = append(req.Attendees, a.User)
+	}
+
+	ev, err := rs.st.EventCreate(ctx, req)
+	if err != nil {
+		return fmt.Errorf("event %s: %w", rec.ID, err)
+	}
+
+	for _, a := range rec.Attendees {
+		if models.AttendeeStatus(a.Status) == models.AttendeeNeedsAction {
+			continue
+		}
+		_, err := rs.st.EventRespond(ctx, &models.RespondEventReq{
+			EventID: ev.ID,
+			User:    a.User,
+			Status:  models.AttendeeStatus(a.Status),
+		})
+		if err != nil {
+			return fmt.Errorf("event %s: response of %s: %w", rec.ID, a.User, err)
+		}
+	}
+	rs.stats.Events++
+	return nil
+}
@@ -0,0 +1,128 @@
+package memorystorage
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+func (s *LocalStorage) AttachmentCreate(
+	_ context.Context,
+	req *models.CreateAttachmentReq,
+) (*models.Attachment, error) {
+	s.logger.Debug("AttachmentCreate called")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.liveEvent(req.EventID); !ok {
+		return nil, fmt.Errorf("attachment create: %w", errors.NotFound(errors.ErrEventNotFound, req.EventID))
+	}
+	if req.MaxPerEvent > 0 && len(s.eventAttachments(req.EventID)) >= req.MaxPerEvent {
+		return nil, fmt.Errorf("attachment create: %w", errors.ErrTooManyAttachments)
+	}
+
+	a := &models.Attachment{
+		ID:          req.ID,
+		EventID:     req.EventID,
+		Name:        req.Name,
+		ContentType: req.ContentType,
+		Size:        req.Size,
+		SHA256:      req.SHA256,
+		UploadedBy:  req.UploadedBy,
+		CreatedAt:   time.Now().UTC(),
+	}
+	s.attachments[a.ID] = a
+
+	s.logger.Debug("attachment created id=" + a.ID)
+	cpy := *a
+	return &cpy, nil
+}
+
+func (s *LocalStorage) AttachmentGet(_ context.Context, req *models.AttachmentIDReq) (*models.Attachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.attachments[req.ID]
+	if !ok {
+		return nil, fmt.Errorf("attachment get: %w", errors.NotFound(errors.ErrAttachmentNotFound, req.ID))
+	}
+	cpy := *a
+	return &cpy, nil
+}
+
+func (s *LocalStorage) AttachmentList(_ context.Context, req *models.ListAttachmentsReq) ([]models.Attachment, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.liveEvent(req.EventID); !ok {
+		return nil, fmt.Errorf("attachment list: %w", errors.NotFound(errors.ErrEventNotFound, req.EventID))
+	}
+	return s.eventAttachments(req.EventID), nil
+}
+
+func (s *LocalStorage) AttachmentDelete(_ context.Context, req *models.AttachmentIDReq) error {
+	s.logger.Debug("AttachmentDelete called id=" + req.ID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.attachments[req.ID]; !ok {
+		return fmt.Errorf("attachment delete: %w", errors.NotFound(errors.ErrAttachmentNotFound, req.ID))
+	}
+	s.dropAttachment(req.ID)
+	return nil
+}
+
+func (s *LocalStorage) AttachmentOrphans(_ context.Context, limit int) ([]models.AttachmentOrphan, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.AttachmentOrphan, 0, len(s.orphans))
+	for _, o := range s.orphans {
+		result = append(result, o)
+	}
+	slices.SortFunc(result, func(a, b models.AttachmentOrphan) int { return cmp.Compare(a.ID, b.ID) })
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result, nil
+}
+
+func (s *LocalStorage) AttachmentOrphansForget(_ context.Context, ids []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, id := range ids {
+		delete(s.orphans, id)
+	}
+	return nil
+}
+
+// dropAttachment removes the record and keeps the attachment among the orphans, as the trigger
+// on event_attachments does for the SQL storage. It must be called with s.mu held.
+func (s *LocalStorage) dropAttachment(id string) {
+	if a, ok := s.attachments[id]; ok {
+		s.orphans[id] = models.AttachmentOrphan{ID: a.ID, EventID: a.EventID}
+		delete(s.attachments, id)
+	}
+}
+
+// eventAttachments returns the attachments of an event, oldest first. It must be called with s.mu held.
+func (s *LocalStorage) eventAttachments(eventID string) []models.Attachment {
+	result := make([]models.Attachment, 0)
+	for _, a := range s.attachments {
+		if a.EventID == eventID {
+			result = append(result, *a)
+		}
+	}
+	slices.SortFunc(result, func(a, b models.Attachment) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return result
+}
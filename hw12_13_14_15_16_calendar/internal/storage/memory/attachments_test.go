@@ -0,0 +1,79 @@
+package memorystorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachments(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	start := time.Now()
+
+	event, err := store.EventCreate(ctx, newCreateReq("user", "Review", start, start.Add(time.Hour)))
+	require.NoError(t, err)
+
+	create := func(id string) error {
+		_, err := store.AttachmentCreate(ctx, &models.CreateAttachmentReq{
+			ID: id, EventID: event.ID, Name: id + ".txt", ContentType: "text/plain", UploadedBy: "user", MaxPerEvent: 2,
+		})
+		return err
+	}
+	require.NoError(t, create("a"))
+	require.NoError(t, create("b"))
+	assert.ErrorIs(t, create("c"), errors.ErrTooManyAttachments)
+
+	list, err := store.AttachmentList(ctx, &models.ListAttachmentsReq{EventID: event.ID})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "a", list[0].ID)
+
+	require.NoError(t, store.AttachmentDelete(ctx, &models.AttachmentIDReq{ID: "a"}))
+	assert.ErrorIs(t, store.AttachmentDelete(ctx, &models.AttachmentIDReq{ID: "a"}), errors.ErrAttachmentNotFound)
+	require.NoError(t, create("c"))
+
+	// Trashed events take no attachments; purged ones lose theirs.
+	require.NoError(t, store.EventDelete(ctx, &models.EventIDReq{ID: event.ID}))
+	assert.ErrorIs(t, create("d"), errors.ErrEventNotFound)
+	_, err = store.AttachmentList(ctx, &models.ListAttachmentsReq{EventID: event.ID})
+	assert.ErrorIs(t, err, errors.ErrEventNotFound)
+
+	_, err = store.ExpireEvents(ctx, &models.ExpireEventsReq{Trash: true, Before: time.Now().Add(time.Hour)})
+	require.NoError(t, err)
+	_, err = store.AttachmentGet(ctx, &models.AttachmentIDReq{ID: "b"})
+	assert.ErrorIs(t, err, errors.ErrAttachmentNotFound)
+
+	// Every removed record is left for the sweep, deleted alone or with its event.
+	orphans, err := store.AttachmentOrphans(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []models.AttachmentOrphan{{ID: "a", EventID: event.ID}, {ID: "b", EventID: event.ID}}, orphans)
+	require.NoError(t, store.AttachmentOrphansForget(ctx, []string{"a", "b"}))
+	orphans, err = store.AttachmentOrphans(ctx, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []models.AttachmentOrphan{{ID: "c", EventID: event.ID}}, orphans)
+}
+
+func TestEventLocation(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	start := time.Now()
+
+	req := newCreateReq("user", "Review", start, start.Add(time.Hour))
+	place, url, empty := "Room 4", "https://meet.example.com/review", ""
+	req.Location, req.MeetingURL = &place, &url
+	event, err := store.EventCreate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, &place, event.Location)
+	assert.Equal(t, &url, event.MeetingURL)
+
+	event, err = store.EventEdit(ctx, &models.EditEventReq{ID: event.ID, Location: &empty})
+	require.NoError(t, err)
+	assert.Nil(t, event.Location)
+	assert.Equal(t, &url, event.MeetingURL)
+}
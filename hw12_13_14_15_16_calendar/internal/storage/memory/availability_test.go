@@ -0,0 +1,91 @@
+package memorystorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFreeBusy(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	day := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	_, err := store.EventCreate(ctx, newCreateReq("alice", "Standup", day.Add(9*time.Hour), day.Add(10*time.Hour)))
+	require.NoError(t, err)
+	_, err = store.EventCreate(ctx, newCreateReq("alice", "Review", day.Add(10*time.Hour), day.Add(11*time.Hour)))
+	require.NoError(t, err)
+
+	meeting := newCreateReq("carol", "Planning", day.Add(14*time.Hour), day.Add(15*time.Hour))
+	meeting.Attendees = []string{"bob"}
+	_, err = store.EventCreate(ctx, meeting)
+	require.NoError(t, err)
+
+	res, err := store.FreeBusy(ctx, &models.FreeBusyReq{
+		Users: []string{"alice", "bob", "dave"},
+		Start: day.Add(9*time.Hour + 30*time.Minute),
+		End:   day.Add(24 * time.Hour),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.UserBusy{
+		{User: "alice", Busy: []models.Interval{
+			{Start: day.Add(9*time.Hour + 30*time.Minute), End: day.Add(11 * time.Hour)},
+		}},
+		{User: "bob", Busy: []models.Interval{{Start: day.Add(14 * time.Hour), End: day.Add(15 * time.Hour)}}},
+		{User: "dave", Busy: []models.Interval{}},
+	}, res)
+}
+
+func TestFindSlot(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	// Friday.
+	day := time.Date(2026, 10, 23, 0, 0, 0, 0, time.UTC)
+
+	_, err := store.EventCreate(ctx, newCreateReq("alice", "Standup", day.Add(9*time.Hour), day.Add(10*time.Hour)))
+	require.NoError(t, err)
+	offsite := newCreateReq("bob", "Offsite", day.Add(10*time.Hour+30*time.Minute), day.Add(17*time.Hour))
+	_, err = store.EventCreate(ctx, offsite)
+	require.NoError(t, err)
+
+	req := &models.FindSlotReq{
+		Users:    []string{"alice", "bob"},
+		Start:    day,
+		End:      day.Add(4 * 24 * time.Hour),
+		Duration: 30 * time.Minute,
+		Count:    3,
+		WorkingHours: &models.WorkingHours{
+			From:     9 * time.Hour,
+			To:       18 * time.Hour,
+			Location: time.UTC,
+			Weekdays: []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+		},
+	}
+
+	slots, err := store.FindSlot(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Interval{
+		{Start: day.Add(10 * time.Hour), End: day.Add(10*time.Hour + 30*time.Minute)},
+		{Start: day.Add(17 * time.Hour), End: day.Add(17*time.Hour + 30*time.Minute)},
+		{Start: day.Add(17*time.Hour + 30*time.Minute), End: day.Add(18 * time.Hour)},
+	}, slots)
+
+	// The weekend is skipped, so the next slots are on Monday morning.
+	req.Start = day.Add(18 * time.Hour)
+	req.Count = 1
+	slots, err = store.FindSlot(ctx, req)
+	require.NoError(t, err)
+	monday := day.Add(3 * 24 * time.Hour)
+	assert.Equal(t, []models.Interval{
+		{Start: monday.Add(9 * time.Hour), End: monday.Add(9*time.Hour + 30*time.Minute)},
+	}, slots)
+
+	req.Duration = 10 * time.Hour
+	slots, err = store.FindSlot(ctx, req)
+	require.NoError(t, err)
+	assert.Empty(t, slots)
+}
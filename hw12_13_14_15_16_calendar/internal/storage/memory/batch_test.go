@@ -0,0 +1,77 @@
+package memorystorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventBatchCreateAtomic(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	start := time.Now()
+
+	existing, err := store.EventCreate(ctx, newCreateReq("user", "Existing", start, start.Add(time.Hour)))
+	require.NoError(t, err)
+
+	_, err = store.EventBatchCreate(ctx, []models.CreateEventReq{
+		*newCreateReq("user", "Later", start.Add(2*time.Hour), start.Add(3*time.Hour)),
+		*newCreateReq("user", "Clash", start.Add(30*time.Minute), start.Add(90*time.Minute)),
+	}, true)
+	var itemErr *models.BatchItemError
+	require.ErrorAs(t, err, &itemErr)
+	assert.Equal(t, 1, itemErr.Index)
+	assert.ErrorIs(t, err, errors.ErrDateBusy)
+
+	list, err := store.EventGetList(ctx, &models.GetEventListReq{})
+	require.NoError(t, err)
+	assert.Equal(t, []models.Event{*existing}, list.Data)
+}
+
+func TestEventBatchBestEffort(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	start := time.Now()
+
+	res, err := store.EventBatchCreate(ctx, []models.CreateEventReq{
+		*newCreateReq("user", "First", start, start.Add(time.Hour)),
+		*newCreateReq("user", "Clash", start.Add(30*time.Minute), start.Add(90*time.Minute)),
+		*newCreateReq("user", "Second", start.Add(time.Hour), start.Add(2*time.Hour)),
+	}, false)
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.NoError(t, res[0].Err)
+	assert.ErrorIs(t, res[1].Err, errors.ErrDateBusy)
+	assert.Nil(t, res[1].Event)
+	assert.NoError(t, res[2].Err)
+
+	title := "Renamed"
+	edited, err := store.EventBatchEdit(ctx, []models.EditEventReq{
+		{ID: res[0].Event.ID, Title: &title},
+		{ID: "missing", Title: &title},
+	}, false)
+	require.NoError(t, err)
+	assert.Equal(t, title, edited[0].Event.Title)
+	assert.ErrorIs(t, edited[1].Err, errors.ErrEventNotFound)
+
+	deleted, err := store.EventBatchDelete(ctx, []models.EventIDReq{
+		{ID: res[0].Event.ID},
+		{ID: res[0].Event.ID},
+		{ID: res[2].Event.ID},
+	}, false)
+	require.NoError(t, err)
+	assert.NoError(t, deleted[0].Err)
+	require.NotNil(t, deleted[0].Event)
+	assert.NotNil(t, deleted[0].Event.DeletedAt, "the result is the trashed event")
+	assert.ErrorIs(t, deleted[1].Err, errors.ErrEventNotFound)
+	assert.NoError(t, deleted[2].Err)
+
+	list, err := store.EventGetList(ctx, &models.GetEventListReq{})
+	require.NoError(t, err)
+	assert.Empty(t, list.Data)
+}
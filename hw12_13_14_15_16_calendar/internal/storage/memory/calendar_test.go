@@ -0,0 +1,111 @@
+package memorystorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarSharing(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	cal, err := store.CalendarCreate(ctx, &models.CreateCalendarReq{Owner: "owner", Title: "Team"})
+	require.NoError(t, err)
+
+	start := time.Now()
+	req := newCreateReq("reader", "Standup", start, start.Add(time.Hour))
+	req.CalendarID = &cal.ID
+
+	_, err = store.EventCreate(ctx, req)
+	assert.ErrorIs(t, err, errors.ErrCalendarAccessDenied)
+
+	_, err = store.CalendarShare(ctx, &models.ShareCalendarReq{CalendarID: cal.ID, User: "reader", Role: models.RoleRead})
+	require.NoError(t, err)
+	_, err = store.EventCreate(ctx, req)
+	assert.ErrorIs(t, err, errors.ErrCalendarAccessDenied)
+
+	shared, err := store.CalendarShare(ctx, &models.ShareCalendarReq{
+		CalendarID: cal.ID, User: "reader", Role: models.RoleWrite,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []models.CalendarShare{{User: "reader", Role: models.RoleWrite}}, shared.Shares)
+
+	_, err = store.EventCreate(ctx, req)
+	require.NoError(t, err)
+
+	list, err := store.CalendarList(ctx, &models.ListCalendarsReq{User: "reader"})
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+
+	unshared, err := store.CalendarUnshare(ctx, &models.UnshareCalendarReq{CalendarID: cal.ID, User: "reader"})
+	require.NoError(t, err)
+	assert.Empty(t, unshared.Shares)
+
+	list, err = store.CalendarList(ctx, &models.ListCalendarsReq{User: "reader"})
+	require.NoError(t, err)
+	assert.Empty(t, list)
+}
+
+func TestCalendarDeleteRemovesEvents(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	cal, err := store.CalendarCreate(ctx, &models.CreateCalendarReq{Owner: "owner", Title: "Personal"})
+	require.NoError(t, err)
+
+	req := newCreateReq("owner", "Gym", time.Now(), time.Now().Add(time.Hour))
+	req.CalendarID = &cal.ID
+	event, err := store.EventCreate(ctx, req)
+	require.NoError(t, err)
+
+	removed, err := store.CalendarDelete(ctx, &models.CalendarIDReq{ID: cal.ID})
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	assert.Equal(t, event.ID, removed[0].ID)
+
+	_, err = store.EventGet(ctx, &models.EventIDReq{ID: event.ID})
+	assert.ErrorIs(t, err, errors.ErrEventNotFound)
+	_, err = store.CalendarDelete(ctx, &models.CalendarIDReq{ID: cal.ID})
+	assert.ErrorIs(t, err, errors.ErrCalendarNotFound)
+}
+
+func TestAttendeeOverlap(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	start := time.Now()
+	end := start.Add(time.Hour)
+
+	meeting := newCreateReq("organizer", "Planning", start, end)
+	meeting.Attendees = []string{"guest"}
+	event, err := store.EventCreate(ctx, meeting)
+	require.NoError(t, err)
+	assert.Equal(t, []models.Attendee{{User: "guest", Status: models.AttendeeNeedsAction}}, event.Attendees)
+
+	_, err = store.EventCreate(ctx, newCreateReq("guest", "Lunch", start.Add(30*time.Minute), end))
+	assert.ErrorIs(t, err, errors.ErrDateBusy)
+
+	_, err = store.EventRespond(ctx, &models.RespondEventReq{
+		EventID: event.ID, User: "guest", Status: models.AttendeeDeclined,
+	})
+	require.NoError(t, err)
+
+	_, err = store.EventCreate(ctx, newCreateReq("guest", "Lunch", start.Add(30*time.Minute), end))
+	require.NoError(t, err)
+
+	_, err = store.EventRespond(ctx, &models.RespondEventReq{
+		EventID: event.ID, User: "guest", Status: models.AttendeeAccepted,
+	})
+	assert.ErrorIs(t, err, errors.ErrDateBusy)
+
+	_, err = store.EventRespond(ctx, &models.RespondEventReq{
+		EventID: event.ID, User: "stranger", Status: models.AttendeeAccepted,
+	})
+	assert.ErrorIs(t, err, errors.ErrAttendeeNotFound)
+}
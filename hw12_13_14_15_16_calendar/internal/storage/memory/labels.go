@@ -0,0 +1,37 @@
+package memorystorage
+
+import "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+
+// labelIndex maps a label name to the live events carrying it. It is guarded by LocalStorage.mu.
+type labelIndex map[string]map[string]struct{}
+
+func (idx labelIndex) add(ev *models.Event) {
+	for _, name := range ev.Labels {
+		ids, ok := idx[name]
+		if !ok {
+			ids = make(map[string]struct{})
+			idx[name] = ids
+		}
+		ids[ev.ID] = struct{}{}
+	}
+}
+
+func (idx labelIndex) remove(ev *models.Event) {
+	for _, name := range ev.Labels {
+		delete(idx[name], ev.ID)
+		if len(idx[name]) == 0 {
+			delete(idx, name)
+		}
+	}
+}
+
+// events returns the IDs of the events carrying any of names.
+func (idx labelIndex) events(names []string) map[string]struct{} {
+	res := make(map[string]struct{})
+	for _, name := range names {
+		for id := range idx[name] {
+			res[id] = struct{}{}
+		}
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+package memorystorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelCatalogue(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	work, err := store.LabelCreate(ctx, &models.CreateLabelReq{Owner: "owner", Name: "work"})
+	require.NoError(t, err)
+	_, err = store.LabelCreate(ctx, &models.CreateLabelReq{Owner: "owner", Name: "home"})
+	require.NoError(t, err)
+	_, err = store.LabelCreate(ctx, &models.CreateLabelReq{Owner: "other", Name: "work"})
+	require.NoError(t, err)
+
+	_, err = store.LabelCreate(ctx, &models.CreateLabelReq{Owner: "owner", Name: "work"})
+	assert.ErrorIs(t, err, errors.ErrLabelExists)
+
+	list, err := store.LabelList(ctx, &models.ListLabelsReq{User: "owner"})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	assert.Equal(t, "home", list[0].Name)
+	assert.Equal(t, "work", list[1].Name)
+
+	require.NoError(t, store.LabelDelete(ctx, &models.LabelIDReq{ID: work.ID}))
+	assert.ErrorIs(t, store.LabelDelete(ctx, &models.LabelIDReq{ID: work.ID}), errors.ErrLabelNotFound)
+}
+
+func TestEventLabels(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	_, err := store.LabelCreate(ctx, &models.CreateLabelReq{Owner: "owner", Name: "work"})
+	require.NoError(t, err)
+	home, err := store.LabelCreate(ctx, &models.CreateLabelReq{Owner: "owner", Name: "home"})
+	require.NoError(t, err)
+
+	start := time.Now()
+	req := newCreateReq("owner", "Standup", start, start.Add(time.Hour))
+	req.Labels = []string{"unknown"}
+	_, err = store.EventCreate(ctx, req)
+	assert.ErrorIs(t, err, errors.ErrLabelNotFound)
+
+	req.Labels = []string{"work", "home", "work"}
+	standup, err := store.EventCreate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"home", "work"}, standup.Labels)
+
+	req = newCreateReq("owner", "Review", start.Add(2*time.Hour), start.Add(3*time.Hour))
+	req.Labels = []string{"work"}
+	_, err = store.EventCreate(ctx, req)
+	require.NoError(t, err)
+
+	_, err = store.EventCreate(ctx, newCreateReq("guest", "Lunch", start, start.Add(time.Hour)))
+	require.NoError(t, err)
+
+	list, err := store.EventGetList(ctx, &models.GetEventListReq{Labels: []string{"home"}})
+	require.NoError(t, err)
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, standup.ID, list.Data[0].ID)
+
+	owner := "owner"
+	list, err = store.EventGetList(ctx, &models.GetEventListReq{User: &owner})
+	require.NoError(t, err)
+	assert.Len(t, list.Data, 2)
+
+	require.NoError(t, store.LabelDelete(ctx, &models.LabelIDReq{ID: home.ID}))
+	got, err := store.EventGet(ctx, &models.EventIDReq{ID: standup.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"work"}, got.Labels)
+
+	list, err = store.EventGetList(ctx, &models.GetEventListReq{Labels: []string{"home"}})
+	require.NoError(t, err)
+	assert.Empty(t, list.Data)
+}
@@ -0,0 +1,201 @@
+package memorystorage
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+// Title matches weigh more than description matches, like the A and B weights of ts_rank in Postgres.
+const (
+	titleWeight       = 1.0
+	descriptionWeight = 0.4
+)
+
+// searchIndex is an inverted index from a lower-cased word to the weighted number of its
+// occurrences in every live event. It is guarded by LocalStorage.mu.
+type searchIndex struct {
+	postings map[string]map[string]float64
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{postings: make(map[string]map[string]float64)}
+}
+
+func (idx *searchIndex) add(ev *models.Event) {
+	for term, weight := range eventTerms(ev) {
+		docs, ok := idx.postings[term]
+		if !ok {
+			docs = make(map[string]float64)
+			idx.postings[term] = docs
+		}
+		docs[ev.ID] = weight
+	}
+}
+
+func (idx *searchIndex) remove(ev *models.Event) {
+	for term := range eventTerms(ev) {
+		docs := idx.postings[term]
+		delete(docs, ev.ID)
+		if len(docs) == 0 {
+			delete(idx.postings, term)
+		}
+	}
+}
+
+// search returns the events matching query with their rank: the weights of the words they
+// matched. events are the live events, which negations and phrases are checked against.
+func (idx *searchIndex) search(query string, events map[string]*models.Event) map[string]float64 {
+	res := make(map[string]float64)
+	for _, group := range parseQuery(query) {
+		for id, rank := range idx.matchGroup(group, events) {
+			res[id] = max(res[id], rank)
+		}
+	}
+	return res
+}
+
+// matchGroup returns the events matching every clause of group.
+func (idx *searchIndex) matchGroup(group []searchClause, events map[string]*models.Event) map[string]float64 {
+	var res map[string]float64
+	for _, c := range group {
+		if c.not {
+			continue
+		}
+		docs := idx.postings[c.words[0]]
+		next := make(map[string]float64)
+		for id := range docs {
+			if res != nil {
+				if _, ok := res[id]; !ok {
+					continue
+				}
+			}
+			if len(c.words) > 1 && !containsPhrase(eventWords(events[id]), c.words) {
+				continue
+			}
+			rank := res[id]
+			for _, w := range c.words {
+				rank += idx.postings[w][id]
+			}
+			next[id] = rank
+		}
+		res = next
+	}
+	if res == nil {
+		// Only negations: they filter every live event, as in Postgres.
+		res = make(map[string]float64, len(events))
+		for id, ev := range events {
+			if ev.DeletedAt == nil {
+				res[id] = 0
+			}
+		}
+	}
+
+	for _, c := range group {
+		if !c.not {
+			continue
+		}
+		for id := range res {
+			if _, ok := idx.postings[c.words[0]][id]; ok && containsPhrase(eventWords(events[id]), c.words) {
+				delete(res, id)
+			}
+		}
+	}
+	return res
+}
+
+// searchClause is a word, or a phrase of consecutive words, that an event must contain, or must
+// not with not.
+type searchClause struct {
+	words []string
+	not   bool
+}
+
+// parseQuery follows websearch_to_tsquery: words are ANDed, "quoted words" form a phrase, a
+// leading - negates a word or phrase and "or" separates alternatives. It returns the alternatives,
+// each with the clauses all of which have to match.
+func parseQuery(query string) [][]searchClause {
+	var groups [][]searchClause
+	var group []searchClause
+	flush := func() {
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+		group = nil
+	}
+
+	rest, not := query, false
+	for rest != "" {
+		r, size := utf8.DecodeRuneInString(rest)
+		switch {
+		case unicode.IsSpace(r):
+			rest, not = rest[size:], false
+		case r == '-':
+			rest, not = rest[size:], true
+		case r == '"':
+			phrase, after, _ := strings.Cut(rest[size:], `"`)
+			if words := tokenize(phrase); len(words) > 0 {
+				group = append(group, searchClause{words: words, not: not})
+			}
+			rest, not = after, false
+		default:
+			end := strings.IndexFunc(rest, func(r rune) bool { return unicode.IsSpace(r) || r == '"' })
+			if end < 0 {
+				end = len(rest)
+			}
+			word := rest[:end]
+			if strings.EqualFold(word, "or") && !not {
+				flush()
+			} else if words := tokenize(word); len(words) > 0 {
+				group = append(group, searchClause{words: words, not: not})
+			}
+			rest, not = rest[end:], false
+		}
+	}
+	flush()
+	return groups
+}
+
+// eventWords returns the words of the title followed by those of the description, the order
+// the search vector concatenates them in.
+func eventWords(ev *models.Event) []string {
+	words := tokenize(ev.Title)
+	if ev.Description != nil {
+		words = append(words, tokenize(*ev.Description)...)
+	}
+	return words
+}
+
+func containsPhrase(words, phrase []string) bool {
+	if len(phrase) == 1 {
+		return true
+	}
+	for i := 0; i+len(phrase) <= len(words); i++ {
+		if slices.Equal(words[i:i+len(phrase)], phrase) {
+			return true
+		}
+	}
+	return false
+}
+
+func eventTerms(ev *models.Event) map[string]float64 {
+	terms := make(map[string]float64)
+	for _, t := range tokenize(ev.Title) {
+		terms[t] += titleWeight
+	}
+	if ev.Description != nil {
+		for _, t := range tokenize(*ev.Description) {
+			terms[t] += descriptionWeight
+		}
+	}
+	return terms
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
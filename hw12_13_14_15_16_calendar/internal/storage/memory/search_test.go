@@ -0,0 +1,102 @@
+package memorystorage
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func searchTitles(t *testing.T, store *LocalStorage, req *models.SearchEventsReq) []string {
+	t.Helper()
+	hits, err := store.EventSearch(context.Background(), req)
+	require.NoError(t, err)
+
+	titles := make([]string, 0, len(hits))
+	for _, h := range hits {
+		titles = append(titles, h.Event.Title)
+	}
+	return titles
+}
+
+func TestEventSearch(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	review := "Quarterly budget review with finance"
+	req := newCreateReq("alice", "Team sync", start, start.Add(time.Hour))
+	req.Description = &review
+	_, err := store.EventCreate(ctx, req)
+	require.NoError(t, err)
+
+	next := start.Add(24 * time.Hour)
+	budget, err := store.EventCreate(ctx, newCreateReq("alice", "Budget review", next, next.Add(time.Hour)))
+	require.NoError(t, err)
+
+	shared := newCreateReq("bob", "Budget planning", start.Add(48*time.Hour), start.Add(49*time.Hour))
+	shared.Attendees = []string{"alice"}
+	_, err = store.EventCreate(ctx, shared)
+	require.NoError(t, err)
+
+	_, err = store.EventCreate(ctx, newCreateReq("carol", "Budget review", start, start.Add(time.Hour)))
+	require.NoError(t, err)
+
+	// Title matches rank above description matches; carol's event is not visible to alice.
+	assert.Equal(t, []string{"Budget review", "Team sync"},
+		searchTitles(t, store, &models.SearchEventsReq{Query: "budget REVIEW", User: "alice"}))
+	assert.Equal(t, []string{"Budget review", "Budget planning", "Team sync"},
+		searchTitles(t, store, &models.SearchEventsReq{Query: "budget", User: "alice"}))
+
+	end := start.Add(30 * time.Hour)
+	assert.Equal(t, []string{"Budget review", "Team sync"},
+		searchTitles(t, store, &models.SearchEventsReq{Query: "budget", User: "alice", End: &end}))
+	assert.Equal(t, []string{"Budget review"},
+		searchTitles(t, store, &models.SearchEventsReq{Query: "budget", User: "alice", Limit: 1}))
+
+	// The index follows edits and deletes.
+	title := "Offsite"
+	_, err = store.EventEdit(ctx, &models.EditEventReq{ID: budget.ID, Title: &title})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Offsite"}, searchTitles(t, store, &models.SearchEventsReq{Query: "offsite", User: "alice"}))
+	assert.Equal(t, []string{"Budget planning", "Team sync"},
+		searchTitles(t, store, &models.SearchEventsReq{Query: "budget", User: "alice"}))
+
+	require.NoError(t, store.EventDelete(ctx, &models.EventIDReq{ID: budget.ID}))
+	assert.Empty(t, searchTitles(t, store, &models.SearchEventsReq{Query: "offsite", User: "alice"}))
+
+	_, err = store.EventRestore(ctx, &models.EventIDReq{ID: budget.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Offsite"}, searchTitles(t, store, &models.SearchEventsReq{Query: "offsite", User: "alice"}))
+}
+
+func TestEventSearchOperators(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	for i, title := range []string{"Budget review", "Review of the budget", "Design review", "Offsite planning"} {
+		at := start.Add(time.Duration(i) * time.Hour)
+		_, err := store.EventCreate(ctx, newCreateReq("alice", title, at, at.Add(time.Hour)))
+		require.NoError(t, err)
+	}
+	search := func(query string) []string {
+		titles := searchTitles(t, store, &models.SearchEventsReq{Query: query, User: "alice"})
+		slices.Sort(titles)
+		return titles
+	}
+
+	assert.Equal(t, []string{"Budget review", "Review of the budget"}, search("budget review"))
+	assert.Equal(t, []string{"Budget review"}, search(`"budget review"`))
+	assert.Equal(t, []string{"Design review", "Review of the budget"}, search(`review -"budget review"`))
+	assert.Equal(t, []string{"Design review"}, search("review -budget"))
+	assert.Equal(t, []string{"Budget review", "Design review", "Review of the budget"},
+		search("design or budget"))
+	assert.Equal(t, []string{"Design review", "Offsite planning"}, search("offsite OR design review"))
+	assert.Equal(t, []string{"Offsite planning"}, search("-review"))
+	assert.Empty(t, search(`or "" -`))
+}
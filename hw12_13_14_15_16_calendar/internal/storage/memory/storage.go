@@ -3,25 +3,51 @@ package memorystorage
 import (
 	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
 	"github.com/google/uuid"
 )
 
 type LocalStorage struct {
-	mu     sync.RWMutex
-	events map[string]*models.Event
-	logger logger.Logger
+	mu        sync.RWMutex
+	events    map[string]*models.Event
+	calendars map[string]*models.Calendar
+	labels    map[string]*models.Label
+	webhooks  map[string]*models.Webhook
+	// deliveries is the webhook delivery queue and log.
+	deliveries  map[string]*models.WebhookDelivery
+	attachments map[string]*models.Attachment
+	// orphans are the removed attachments whose content is still to delete.
+	orphans map[string]models.AttachmentOrphan
+	// reminded holds the time of the last reminder sent per event.
+	reminded map[string]time.Time
+	index    *searchIndex
+	byLabel  labelIndex
+	logger   logger.Logger
 }
 
 func NewLocalStorage(logger logger.Logger) *LocalStorage {
 	return &LocalStorage{
-		events: make(map[string]*models.Event),
-		logger: logger,
+		events:      make(map[string]*models.Event),
+		calendars:   make(map[string]*models.Calendar),
+		labels:      make(map[string]*models.Label),
+		webhooks:    make(map[string]*models.Webhook),
+		deliveries:  make(map[string]*models.WebhookDelivery),
+		attachments: make(map[string]*models.Attachment),
+		orphans:     make(map[string]models.AttachmentOrphan),
+		reminded:    make(map[string]time.Time),
+		index:       newSearchIndex(),
+		byLabel:     make(labelIndex),
+		logger:      logger,
 	}
 }
 
@@ -31,14 +57,21 @@ func (s *LocalStorage) EventCreate(_ context.Context, req *models.CreateEventReq
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	for _, ev := range s.events {
-		if ev.User == req.User && rangesOverlap(req.Date, req.EndTime, ev.Date, ev.EndTime) {
-			s.logger.Error(fmt.Sprintf("conflict on create for user=%s at %s-%s", req.User, req.Date, req.EndTime))
-			return nil, fmt.Errorf("event create: %w", errors.ErrDateBusy)
-		}
+	return s.eventCreate(req)
+}
+
+// eventCreate must be called with s.mu held.
+func (s *LocalStorage) eventCreate(req *models.CreateEventReq) (*models.Event, error) {
+	if err := s.checkCalendarWrite(req.CalendarID, req.User); err != nil {
+		return nil, fmt.Errorf("event create: %w", err)
 	}
 
-	id := uuid.New().String()
+	id := req.ID
+	if id == "" {
+		id = uuid.New().String()
+	} else if _, ok := s.events[id]; ok {
+		return nil, fmt.Errorf("event create: %w", errors.ErrEventExists)
+	}
 	event := &models.Event{
 		ID:           id,
 		Title:        req.Title,
@@ -47,11 +80,30 @@ func (s *LocalStorage) EventCreate(_ context.Context, req *models.CreateEventReq
 		Description:  req.Description,
 		User:         req.User,
 		NotifyBefore: req.NotifyBefore,
+		CalendarID:   req.CalendarID,
+		Attendees:    models.MergeAttendees(nil, req.Attendees),
+		TimeZone:     req.TimeZone,
+		AllDay:       req.AllDay,
+		Labels:       models.NormalizeLabels(req.Labels),
+		Location:     req.Location,
+		MeetingURL:   req.MeetingURL,
+	}
+	if err := event.Normalize(); err != nil {
+		return nil, fmt.Errorf("event create: %w", err)
+	}
+	if err := s.checkLabels(event.User, event.Labels); err != nil {
+		return nil, fmt.Errorf("event create: %w", err)
+	}
+
+	if err := s.checkBusy(event.BusyUsers(), event.Date, event.EndTime, ""); err != nil {
+		s.logger.Error(fmt.Sprintf("conflict on create for user=%s at %s-%s", req.User, req.Date, req.EndTime))
+		return nil, fmt.Errorf("event create: %w", err)
 	}
 
-	s.events[id] = event
+	s.putEvent(event)
 	s.logger.Debug("event created id=" + id)
-	return event, nil
+	res := copyEvent(event)
+	return &res, nil
 }
 
 func (s *LocalStorage) EventEdit(_ context.Context, req *models.EditEventReq) (*models.Event, error) {
@@ -60,13 +112,18 @@ func (s *LocalStorage) EventEdit(_ context.Context, req *models.EditEventReq) (*
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	event, ok := s.events[req.ID]
+	return s.eventEdit(req)
+}
+
+// eventEdit must be called with s.mu held.
+func (s *LocalStorage) eventEdit(req *models.EditEventReq) (*models.Event, error) {
+	event, ok := s.liveEvent(req.ID)
 	if !ok {
 		s.logger.Error("event not found id=" + req.ID)
-		return nil, fmt.Errorf("event edit: %w", errors.ErrEventNotFound)
+		return nil, fmt.Errorf("event edit: %w", errors.NotFound(errors.ErrEventNotFound, req.ID))
 	}
 
-	updated := *event
+	updated := copyEvent(event)
 
 	if req.Title != nil {
 		updated.Title = *req.Title
@@ -86,17 +143,47 @@ func (s *LocalStorage) EventEdit(_ context.Context, req *models.EditEventReq) (*
 	if req.NotifyBefore != nil {
 		updated.NotifyBefore = req.NotifyBefore
 	}
+	if req.CalendarID != nil {
+		updated.CalendarID = req.CalendarID
+	}
+	if req.Location != nil {
+		updated.Location = req.Location
+	}
+	if req.MeetingURL != nil {
+		updated.MeetingURL = req.MeetingURL
+	}
+	if req.Attendees != nil {
+		updated.Attendees = models.MergeAttendees(updated.Attendees, *req.Attendees)
+	}
+	if req.TimeZone != nil {
+		updated.TimeZone = *req.TimeZone
+	}
+	if req.AllDay != nil {
+		updated.AllDay = *req.AllDay
+	}
+	if req.Labels != nil {
+		updated.Labels = models.NormalizeLabels(*req.Labels)
+	}
+	if err := updated.Normalize(); err != nil {
+		return nil, fmt.Errorf("event edit: %w", err)
+	}
+	if err := s.checkLabels(updated.User, updated.Labels); err != nil {
+		return nil, fmt.Errorf("event edit: %w", err)
+	}
 
-	for _, ev := range s.events {
-		if ev.ID != req.ID && ev.User == updated.User && rangesOverlap(updated.Date, updated.EndTime, ev.Date, ev.EndTime) {
-			s.logger.Error("conflict on edit id=" + req.ID)
-			return nil, fmt.Errorf("event edit: %w", errors.ErrDateBusy)
-		}
+	if err := s.checkCalendarWrite(updated.CalendarID, updated.User); err != nil {
+		return nil, fmt.Errorf("event edit: %w", err)
 	}
 
-	s.events[req.ID] = &updated
+	if err := s.checkBusy(updated.BusyUsers(), updated.Date, updated.EndTime, req.ID); err != nil {
+		s.logger.Error("conflict on edit id=" + req.ID)
+		return nil, fmt.Errorf("event edit: %w", err)
+	}
+
+	s.putEvent(&updated)
 	s.logger.Debug("event edited id=" + req.ID)
-	return &updated, nil
+	res := copyEvent(&updated)
+	return &res, nil
 }
 
 func (s *LocalStorage) EventDelete(_ context.Context, req *models.EventIDReq) error {
@@ -105,24 +192,159 @@ func (s *LocalStorage) EventDelete(_ context.Context, req *models.EventIDReq) er
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	delete(s.events, req.ID)
-	s.logger.Debug("event deleted id=" + req.ID)
+	if _, err := s.eventDelete(req.ID); err != nil {
+		return err
+	}
+	s.logger.Debug("event moved to trash id=" + req.ID)
 	return nil
 }
 
+// eventDelete moves the event to the trash and returns a copy of it as it is there.
+// It must be called with s.mu held.
+func (s *LocalStorage) eventDelete(id string) (*models.Event, error) {
+	event, ok := s.liveEvent(id)
+	if !ok {
+		s.logger.Error("event not found id=" + id)
+		return nil, fmt.Errorf("event delete: %w", errors.NotFound(errors.ErrEventNotFound, id))
+	}
+
+	deleted := copyEvent(event)
+	now := time.Now()
+	deleted.DeletedAt = &now
+	s.putEvent(&deleted)
+	res := copyEvent(&deleted)
+	return &res, nil
+}
+
+func (s *LocalStorage) EventListDeleted(_ context.Context, req *models.ListDeletedEventsReq) ([]models.Event, error) {
+	s.logger.Debug("EventListDeleted called")
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.Event, 0)
+	for _, ev := range s.events {
+		if ev.DeletedAt == nil || (req.User != nil && ev.User != *req.User) {
+			continue
+		}
+		result = append(result, copyEvent(ev))
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].DeletedAt.After(*result[j].DeletedAt) })
+
+	s.logger.Debug(fmt.Sprintf("deleted event list returned count=%d", len(result)))
+	return result, nil
+}
+
+func (s *LocalStorage) EventRestore(_ context.Context, req *models.EventIDReq) (*models.Event, error) {
+	s.logger.Debug("EventRestore called id=" + req.ID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, ok := s.events[req.ID]
+	if !ok || event.DeletedAt == nil {
+		s.logger.Error("deleted event not found id=" + req.ID)
+		return nil, fmt.Errorf("event restore: %w", errors.NotFound(errors.ErrEventNotFound, req.ID))
+	}
+
+	if err := s.checkBusy(event.BusyUsers(), event.Date, event.EndTime, event.ID); err != nil {
+		s.logger.Error("conflict on restore id=" + req.ID)
+		return nil, fmt.Errorf("event restore: %w", err)
+	}
+
+	restored := copyEvent(event)
+	restored.DeletedAt = nil
+	s.putEvent(&restored)
+
+	s.logger.Debug("event restored id=" + req.ID)
+	res := copyEvent(&restored)
+	return &res, nil
+}
+
+func (s *LocalStorage) EventBatchCreate(
+	_ context.Context,
+	reqs []models.CreateEventReq,
+	atomic bool,
+) ([]models.BatchResult, error) {
+	s.logger.Debug(fmt.Sprintf("EventBatchCreate called count=%d", len(reqs)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.runBatch(len(reqs), atomic, func(i int) (*models.Event, error) {
+		return s.eventCreate(&reqs[i])
+	})
+}
+
+func (s *LocalStorage) EventBatchEdit(
+	_ context.Context,
+	reqs []models.EditEventReq,
+	atomic bool,
+) ([]models.BatchResult, error) {
+	s.logger.Debug(fmt.Sprintf("EventBatchEdit called count=%d", len(reqs)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.runBatch(len(reqs), atomic, func(i int) (*models.Event, error) {
+		return s.eventEdit(&reqs[i])
+	})
+}
+
+func (s *LocalStorage) EventBatchDelete(
+	_ context.Context,
+	reqs []models.EventIDReq,
+	atomic bool,
+) ([]models.BatchResult, error) {
+	s.logger.Debug(fmt.Sprintf("EventBatchDelete called count=%d", len(reqs)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.runBatch(len(reqs), atomic, func(i int) (*models.Event, error) {
+		return s.eventDelete(reqs[i].ID)
+	})
+}
+
+// runBatch applies n items under the already held s.mu. Events are never modified in place,
+// so restoring a shallow copy of the map is enough to roll an atomic batch back.
+func (s *LocalStorage) runBatch(
+	n int,
+	atomic bool,
+	apply func(i int) (*models.Event, error),
+) ([]models.BatchResult, error) {
+	var snapshot map[string]*models.Event
+	if atomic {
+		snapshot = maps.Clone(s.events)
+	}
+
+	res := make([]models.BatchResult, n)
+	for i := 0; i < n; i++ {
+		ev, err := apply(i)
+		if err != nil && atomic {
+			s.events = snapshot
+			s.rebuildIndex()
+			s.logger.Error(fmt.Sprintf("batch rolled back at item %d: %v", i, err))
+			return nil, &models.BatchItemError{Index: i, Err: err}
+		}
+		res[i] = models.BatchResult{Event: ev, Err: err}
+	}
+	return res, nil
+}
+
 func (s *LocalStorage) EventGet(_ context.Context, req *models.EventIDReq) (*models.Event, error) {
 	s.logger.Debug("EventGet called id=" + req.ID)
 
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	event, ok := s.events[req.ID]
+	event, ok := s.liveEvent(req.ID)
 	if !ok {
 		s.logger.Error("event not found id=" + req.ID)
-		return nil, fmt.Errorf("event get: %w", errors.ErrEventNotFound)
+		return nil, fmt.Errorf("event get: %w", errors.NotFound(errors.ErrEventNotFound, req.ID))
 	}
 
-	cpy := *event
+	cpy := copyEvent(event)
 	s.logger.Debug("event fetched id=" + req.ID)
 	return &cpy, nil
 }
@@ -133,8 +355,22 @@ func (s *LocalStorage) EventGetList(_ context.Context, req *models.GetEventListR
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
-	result := make([]models.Event, 0, len(s.events))
-	for _, ev := range s.events {
+	candidates := s.events
+	if len(req.Labels) > 0 {
+		candidates = make(map[string]*models.Event)
+		for id := range s.byLabel.events(req.Labels) {
+			candidates[id] = s.events[id]
+		}
+	}
+
+	result := make([]models.Event, 0, len(candidates))
+	for _, ev := range candidates {
+		if ev.DeletedAt != nil {
+			continue
+		}
+		if req.User != nil && !ev.Involves(*req.User) {
+			continue
+		}
 		if req.Start != nil && ev.EndTime.Before(*req.Start) {
 			continue
 		}
@@ -142,46 +378,532 @@ func (s *LocalStorage) EventGetList(_ context.Context, req *models.GetEventListR
 			continue
 		}
 
-		result = append(result, *ev)
+		result = append(result, copyEvent(ev))
 	}
 
 	s.logger.Debug(fmt.Sprintf("event list returned count=%d", len(result)))
 	return &models.GetEventListResp{Data: result}, nil
 }
 
+func (s *LocalStorage) EventSearch(_ context.Context, req *models.SearchEventsReq) ([]models.SearchHit, error) {
+	s.logger.Debug("EventSearch called")
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.SearchHit, 0)
+	for id, rank := range s.index.search(req.Query, s.events) {
+		ev := s.events[id]
+		if !ev.Involves(req.User) {
+			continue
+		}
+		if req.Start != nil && ev.EndTime.Before(*req.Start) {
+			continue
+		}
+		if req.End != nil && ev.Date.After(*req.End) {
+			continue
+		}
+		result = append(result, models.SearchHit{Event: copyEvent(ev), Rank: rank})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Rank != result[j].Rank {
+			return result[i].Rank > result[j].Rank
+		}
+		return result[i].Event.Date.Before(result[j].Event.Date)
+	})
+	if req.Limit > 0 && len(result) > req.Limit {
+		result = result[:req.Limit]
+	}
+
+	s.logger.Debug(fmt.Sprintf("search returned count=%d", len(result)))
+	return result, nil
+}
+
 func (s *LocalStorage) EventsToNotify(_ context.Context) ([]models.Event, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 	now := time.Now()
 	result := make([]models.Event, 0, len(s.events))
 	for _, event := range s.events {
-		var notifyBefore time.Duration
-		var err error
-		if event.NotifyBefore != nil {
-			notifyBefore, err = time.ParseDuration(*event.NotifyBefore)
-			if err != nil {
-				notifyBefore = 0
-			}
+		if event.DeletedAt != nil {
+			continue
+		}
+		if now.After(event.NotifyAt()) && now.Before(event.Date) {
+			result = append(result, copyEvent(event))
+		}
+	}
+	return result, nil
+}
+
+func (s *LocalStorage) UpcomingReminders(_ context.Context, now, until time.Time) ([]models.Event, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.Event, 0)
+	for _, event := range s.events {
+		if event.DeletedAt != nil || event.NotifyBefore == nil || !event.Date.After(now) {
+			continue
 		}
-		notifyAt := event.Date.Add(-notifyBefore)
-		if now.After(notifyAt) && now.Before(event.Date) {
-			result = append(result, *event)
+		at := event.NotifyAt()
+		if sent, ok := s.reminded[event.ID]; ok && sent.Equal(at) {
+			continue
+		}
+		if at.Before(until) {
+			result = append(result, copyEvent(event))
 		}
 	}
+	sort.Slice(result, func(i, j int) bool { return result[i].NotifyAt().Before(result[j].NotifyAt()) })
 	return result, nil
 }
 
-func (s *LocalStorage) DeleteOldEvents(_ context.Context, cutoff time.Time) error {
+func (s *LocalStorage) MarkReminderSent(_ context.Context, eventID string, notifyAt time.Time) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+
+	if _, ok := s.events[eventID]; !ok {
+		return fmt.Errorf("mark reminder sent: %w", errors.ErrEventNotFound)
+	}
+	s.reminded[eventID] = notifyAt
+	return nil
+}
+
+// EventScan calls fn outside the lock; events are replaced rather than changed in place, so the
+// snapshot taken under it stays consistent.
+func (s *LocalStorage) EventScan(ctx context.Context, fn func(models.Event) error) error {
+	s.mu.RLock()
+	snapshot := make([]*models.Event, 0, len(s.events))
+	for _, ev := range s.events {
+		if ev.DeletedAt == nil {
+			snapshot = append(snapshot, ev)
+		}
+	}
+	s.mu.RUnlock()
+
+	slices.SortFunc(snapshot, func(a, b *models.Event) int { return strings.Compare(a.ID, b.ID) })
+	for _, ev := range snapshot {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(copyEvent(ev)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *LocalStorage) ExpireEvents(_ context.Context, req *models.ExpireEventsReq) (int, error) {
+	if req.Archive {
+		return 0, storage.ErrNoArchive
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ids := s.expired(req)
+	if req.Limit > 0 && len(ids) > req.Limit {
+		ids = ids[:req.Limit]
+	}
+	for _, id := range ids {
+		s.dropEvent(id)
+	}
+	return len(ids), nil
+}
+
+func (s *LocalStorage) CountExpiredEvents(_ context.Context, req *models.ExpireEventsReq) (int, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.expired(req)), nil
+}
+
+// expired returns the IDs of the events req selects in ID order, the order the SQL storage removes them in.
+func (s *LocalStorage) expired(req *models.ExpireEventsReq) []string {
+	var ids []string
 	for id, event := range s.events {
-		if event.EndTime.Before(cutoff) {
-			delete(s.events, id)
+		if req.Matches(event) {
+			ids = append(ids, id)
 		}
 	}
+	slices.Sort(ids)
+	return ids
+}
+
+func (s *LocalStorage) EventRespond(_ context.Context, req *models.RespondEventReq) (*models.Event, error) {
+	s.logger.Debug("EventRespond called id=" + req.EventID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	event, ok := s.liveEvent(req.EventID)
+	if !ok {
+		s.logger.Error("event not found id=" + req.EventID)
+		return nil, fmt.Errorf("event respond: %w", errors.NotFound(errors.ErrEventNotFound, req.EventID))
+	}
+
+	updated := copyEvent(event)
+	idx := slices.IndexFunc(updated.Attendees, func(a models.Attendee) bool { return a.User == req.User })
+	if idx < 0 {
+		return nil, fmt.Errorf("event respond: %w", errors.NotFound(errors.ErrAttendeeNotFound, req.User))
+	}
+
+	wasDeclined := updated.Attendees[idx].Status == models.AttendeeDeclined
+	updated.Attendees[idx].Status = req.Status
+	if wasDeclined && req.Status != models.AttendeeDeclined {
+		if err := s.checkBusy([]string{req.User}, updated.Date, updated.EndTime, updated.ID); err != nil {
+			s.logger.Error("conflict on respond id=" + req.EventID + " user=" + req.User)
+			return nil, fmt.Errorf("event respond: %w", err)
+		}
+	}
+
+	s.putEvent(&updated)
+	s.logger.Debug("event response saved id=" + req.EventID + " user=" + req.User)
+	res := copyEvent(&updated)
+	return &res, nil
+}
+
+func (s *LocalStorage) CalendarCreate(_ context.Context, req *models.CreateCalendarReq) (*models.Calendar, error) {
+	s.logger.Debug("CalendarCreate called")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cal := &models.Calendar{
+		ID:          uuid.New().String(),
+		Owner:       req.Owner,
+		Title:       req.Title,
+		Description: req.Description,
+	}
+	s.calendars[cal.ID] = cal
+
+	s.logger.Debug("calendar created id=" + cal.ID)
+	res := copyCalendar(cal)
+	return &res, nil
+}
+
+func (s *LocalStorage) CalendarGet(_ context.Context, req *models.CalendarIDReq) (*models.Calendar, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cal, ok := s.calendars[req.ID]
+	if !ok {
+		s.logger.Error("calendar not found id=" + req.ID)
+		return nil, fmt.Errorf("calendar get: %w", errors.NotFound(errors.ErrCalendarNotFound, req.ID))
+	}
+	res := copyCalendar(cal)
+	return &res, nil
+}
+
+func (s *LocalStorage) CalendarList(_ context.Context, req *models.ListCalendarsReq) ([]models.Calendar, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.Calendar, 0)
+	for _, cal := range s.calendars {
+		if cal.CanRead(req.User) {
+			result = append(result, copyCalendar(cal))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Title != result[j].Title {
+			return result[i].Title < result[j].Title
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result, nil
+}
+
+func (s *LocalStorage) CalendarDelete(_ context.Context, req *models.CalendarIDReq) ([]models.Event, error) {
+	s.logger.Debug("CalendarDelete called id=" + req.ID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.calendars[req.ID]; !ok {
+		return nil, fmt.Errorf("calendar delete: %w", errors.NotFound(errors.ErrCalendarNotFound, req.ID))
+	}
+	delete(s.calendars, req.ID)
+	var removed []models.Event
+	for id, ev := range s.events {
+		if ev.CalendarID != nil && *ev.CalendarID == req.ID {
+			if ev.DeletedAt == nil {
+				removed = append(removed, copyEvent(ev))
+			}
+			s.dropEvent(id)
+		}
+	}
+	s.logger.Debug("calendar deleted id=" + req.ID)
+	return removed, nil
+}
+
+func (s *LocalStorage) CalendarShare(_ context.Context, req *models.ShareCalendarReq) (*models.Calendar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cal, ok := s.calendars[req.CalendarID]
+	if !ok {
+		return nil, fmt.Errorf("calendar share: %w", errors.NotFound(errors.ErrCalendarNotFound, req.CalendarID))
+	}
+
+	updated := copyCalendar(cal)
+	idx := slices.IndexFunc(updated.Shares, func(sh models.CalendarShare) bool { return sh.User == req.User })
+	if idx >= 0 {
+		updated.Shares[idx].Role = req.Role
+	} else {
+		updated.Shares = append(updated.Shares, models.CalendarShare{User: req.User, Role: req.Role})
+	}
+	s.calendars[req.CalendarID] = &updated
+
+	s.logger.Debug("calendar id=" + req.CalendarID + " shared with user=" + req.User)
+	res := copyCalendar(&updated)
+	return &res, nil
+}
+
+func (s *LocalStorage) CalendarUnshare(_ context.Context, req *models.UnshareCalendarReq) (*models.Calendar, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cal, ok := s.calendars[req.CalendarID]
+	if !ok {
+		return nil, fmt.Errorf("calendar unshare: %w", errors.NotFound(errors.ErrCalendarNotFound, req.CalendarID))
+	}
+
+	updated := copyCalendar(cal)
+	updated.Shares = slices.DeleteFunc(updated.Shares, func(sh models.CalendarShare) bool { return sh.User == req.User })
+	s.calendars[req.CalendarID] = &updated
+
+	s.logger.Debug("calendar id=" + req.CalendarID + " unshared from user=" + req.User)
+	res := copyCalendar(&updated)
+	return &res, nil
+}
+
+func (s *LocalStorage) FreeBusy(_ context.Context, req *models.FreeBusyReq) ([]models.UserBusy, error) {
+	s.logger.Debug("FreeBusy called")
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.freeBusy(req.Users, req.Start, req.End), nil
+}
+
+func (s *LocalStorage) FindSlot(_ context.Context, req *models.FindSlotReq) ([]models.Interval, error) {
+	s.logger.Debug("FindSlot called")
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var busy []models.Interval
+	for _, ub := range s.freeBusy(req.Users, req.Start, req.End) {
+		busy = append(busy, ub.Busy...)
+	}
+	return models.FreeSlots(busy, req), nil
+}
+
+// freeBusy must be called with s.mu held.
+func (s *LocalStorage) freeBusy(users []string, start, end time.Time) []models.UserBusy {
+	busy := make(map[string][]models.Interval, len(users))
+	for _, ev := range s.events {
+		if ev.DeletedAt != nil || !rangesOverlap(start, end, ev.Date, ev.EndTime) {
+			continue
+		}
+		for _, u := range ev.BusyUsers() {
+			if slices.Contains(users, u) {
+				busy[u] = append(busy[u], models.Interval{Start: ev.Date, End: ev.EndTime})
+			}
+		}
+	}
+
+	res := make([]models.UserBusy, 0, len(users))
+	for _, u := range users {
+		res = append(res, models.UserBusy{User: u, Busy: models.MergeIntervals(busy[u], start, end)})
+	}
+	return res
+}
+
+func (s *LocalStorage) LabelCreate(_ context.Context, req *models.CreateLabelReq) (*models.Label, error) {
+	s.logger.Debug("LabelCreate called")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.labelByName(req.Owner, req.Name); ok {
+		return nil, fmt.Errorf("label create: %w", errors.ErrLabelExists)
+	}
+
+	label := &models.Label{ID: uuid.New().String(), Owner: req.Owner, Name: req.Name, Color: req.Color}
+	s.labels[label.ID] = label
+
+	s.logger.Debug("label created id=" + label.ID)
+	res := *label
+	return &res, nil
+}
+
+func (s *LocalStorage) LabelList(_ context.Context, req *models.ListLabelsReq) ([]models.Label, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.Label, 0)
+	for _, label := range s.labels {
+		if label.Owner == req.User {
+			result = append(result, *label)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+func (s *LocalStorage) OwnerList(_ context.Context) ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	owners := make([]string, 0, len(s.calendars)+len(s.labels))
+	for _, cal := range s.calendars {
+		owners = append(owners, cal.Owner)
+	}
+	for _, label := range s.labels {
+		owners = append(owners, label.Owner)
+	}
+	slices.Sort(owners)
+	return slices.Compact(owners), nil
+}
+
+func (s *LocalStorage) LabelDelete(_ context.Context, req *models.LabelIDReq) error {
+	s.logger.Debug("LabelDelete called id=" + req.ID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	label, ok := s.labels[req.ID]
+	if !ok {
+		return fmt.Errorf("label delete: %w", errors.NotFound(errors.ErrLabelNotFound, req.ID))
+	}
+	delete(s.labels, req.ID)
+
+	for _, ev := range s.events {
+		if ev.User != label.Owner || !slices.Contains(ev.Labels, label.Name) {
+			continue
+		}
+		updated := copyEvent(ev)
+		updated.Labels = slices.DeleteFunc(updated.Labels, func(name string) bool { return name == label.Name })
+		s.putEvent(&updated)
+	}
+
+	s.logger.Debug("label deleted id=" + req.ID)
 	return nil
 }
 
+// labelByName must be called with s.mu held.
+func (s *LocalStorage) labelByName(owner, name string) (*models.Label, bool) {
+	for _, label := range s.labels {
+		if label.Owner == owner && label.Name == name {
+			return label, true
+		}
+	}
+	return nil, false
+}
+
+// checkLabels makes sure every name is in the owner's catalogue. It must be called with s.mu held.
+func (s *LocalStorage) checkLabels(owner string, names []string) error {
+	for _, name := range names {
+		if _, ok := s.labelByName(owner, name); !ok {
+			return errors.NotFound(errors.ErrLabelNotFound, name)
+		}
+	}
+	return nil
+}
+
+// checkCalendarWrite must be called with s.mu held.
+func (s *LocalStorage) checkCalendarWrite(calendarID *string, user string) error {
+	if calendarID == nil {
+		return nil
+	}
+	cal, ok := s.calendars[*calendarID]
+	if !ok {
+		return errors.NotFound(errors.ErrCalendarNotFound, *calendarID)
+	}
+	if !cal.CanWrite(user) {
+		return errors.ErrCalendarAccessDenied
+	}
+	return nil
+}
+
+// checkBusy fails with a ConflictError if any of users already has an event overlapping
+// [start, end), ignoring the event excludeID. It must be called with s.mu held.
+func (s *LocalStorage) checkBusy(users []string, start, end time.Time, excludeID string) error {
+	for _, ev := range s.events {
+		if ev.ID == excludeID || ev.DeletedAt != nil || !rangesOverlap(start, end, ev.Date, ev.EndTime) {
+			continue
+		}
+		for _, u := range ev.BusyUsers() {
+			if slices.Contains(users, u) {
+				return &errors.ConflictError{EventID: ev.ID}
+			}
+		}
+	}
+	return nil
+}
+
+// putEvent stores ev and keeps the search index in sync. It must be called with s.mu held.
+func (s *LocalStorage) putEvent(ev *models.Event) {
+	if old, ok := s.events[ev.ID]; ok && old.DeletedAt == nil {
+		s.index.remove(old)
+		s.byLabel.remove(old)
+	}
+	s.events[ev.ID] = ev
+	if ev.DeletedAt == nil {
+		s.index.add(ev)
+		s.byLabel.add(ev)
+	}
+}
+
+// dropEvent removes the event permanently, with the records of its attachments, whose content is
+// left to the orphan sweep. It must be called with s.mu held.
+func (s *LocalStorage) dropEvent(id string) {
+	if ev, ok := s.events[id]; ok && ev.DeletedAt == nil {
+		s.index.remove(ev)
+		s.byLabel.remove(ev)
+	}
+	delete(s.events, id)
+	delete(s.reminded, id)
+	for attID, a := range s.attachments {
+		if a.EventID == id {
+			s.dropAttachment(attID)
+		}
+	}
+}
+
+// rebuildIndex recreates the search and label indexes. It must be called with s.mu held.
+func (s *LocalStorage) rebuildIndex() {
+	s.index = newSearchIndex()
+	s.byLabel = make(labelIndex)
+	for _, ev := range s.events {
+		if ev.DeletedAt == nil {
+			s.index.add(ev)
+			s.byLabel.add(ev)
+		}
+	}
+}
+
+// liveEvent returns the event unless it is missing or in the trash. It must be called with s.mu held.
+func (s *LocalStorage) liveEvent(id string) (*models.Event, bool) {
+	ev, ok := s.events[id]
+	if !ok || ev.DeletedAt != nil {
+		return nil, false
+	}
+	return ev, true
+}
+
+func copyEvent(ev *models.Event) models.Event {
+	cpy := *ev
+	cpy.Attendees = slices.Clone(ev.Attendees)
+	cpy.Labels = slices.Clone(ev.Labels)
+	return cpy
+}
+
+func copyCalendar(cal *models.Calendar) models.Calendar {
+	cpy := *cal
+	cpy.Shares = slices.Clone(cal.Shares)
+	return cpy
+}
+
 func rangesOverlap(start1, end1, start2, end2 time.Time) bool {
 	return start1.Before(end2) && start2.Before(end1)
 }
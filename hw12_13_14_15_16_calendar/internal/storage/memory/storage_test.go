@@ -12,6 +12,7 @@ import (
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.openly.dev/pointy"
 )
 
 func testLogger() logger.Logger {
@@ -46,6 +47,24 @@ func TestCreateAndGetEvent(t *testing.T) {
 	assert.Equal(t, created.ID, got.ID)
 }
 
+func TestCreateEventWithID(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	start := time.Now()
+	req := newCreateReq("user1", "Meeting", start, start.Add(time.Hour))
+	req.ID = "6f1c2b7e-8a4d-4e1f-9c3b-2d5a7e9f1b04"
+	created, err := store.EventCreate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, req.ID, created.ID)
+
+	// The ID stays taken while the event is in the trash.
+	require.NoError(t, store.EventDelete(ctx, &models.EventIDReq{ID: req.ID}))
+	req.Date, req.EndTime = start.Add(2*time.Hour), start.Add(3*time.Hour)
+	_, err = store.EventCreate(ctx, req)
+	assert.ErrorIs(t, err, errors.ErrEventExists)
+}
+
 func TestCreateEventConflict(t *testing.T) {
 	store := NewLocalStorage(testLogger())
 	ctx := context.Background()
@@ -147,7 +166,7 @@ func TestDeleteEvent_NotFound(t *testing.T) {
 	ctx := context.Background()
 
 	err := store.EventDelete(ctx, &models.EventIDReq{ID: "non-existent-id"})
-	assert.NoError(t, err)
+	assert.ErrorIs(t, err, errors.ErrEventNotFound)
 }
 
 func TestConcurrentAccess(t *testing.T) {
@@ -274,3 +293,71 @@ func TestConcurrentDeleteAndRead(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestAllDayEventOverlap(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	// A Tokyo all-day event on 2026-10-20 covers 2026-10-19T15:00Z..2026-10-20T15:00Z.
+	tz := "Asia/Tokyo"
+	day := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	req := newCreateReq("user1", "Holiday", day, day)
+	req.TimeZone = tz
+	req.AllDay = true
+	holiday, err := store.EventCreate(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC), holiday.Date.UTC())
+
+	evening := time.Date(2026, 10, 19, 16, 0, 0, 0, time.UTC)
+	_, err = store.EventCreate(ctx, newCreateReq("user1", "Call", evening, evening.Add(time.Hour)))
+	assert.ErrorIs(t, err, errors.ErrDateBusy)
+
+	later := time.Date(2026, 10, 20, 15, 0, 0, 0, time.UTC)
+	_, err = store.EventCreate(ctx, newCreateReq("user1", "Call", later, later.Add(time.Hour)))
+	require.NoError(t, err)
+
+	bad := "Nowhere/City"
+	_, err = store.EventEdit(ctx, &models.EditEventReq{ID: holiday.ID, TimeZone: &bad})
+	assert.ErrorIs(t, err, errors.ErrInvalidTimeZone)
+}
+
+func TestUpcomingReminders(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	create := func(title string, start time.Time, before *string) {
+		req := newCreateReq(title, title, start, start.Add(time.Hour))
+		req.NotifyBefore = before
+		_, err := store.EventCreate(ctx, req)
+		require.NoError(t, err)
+	}
+	create("later", now.Add(3*time.Hour), pointy.String("30m"))
+	create("overdue", now.Add(10*time.Minute), pointy.String("1h"))
+	create("soon", now.Add(2*time.Hour), pointy.String("90m"))
+	create("started", now.Add(-10*time.Minute), pointy.String("1h"))
+	create("silent", now.Add(time.Hour), nil)
+
+	events, err := store.UpcomingReminders(ctx, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	titles := make([]string, 0, len(events))
+	for _, ev := range events {
+		titles = append(titles, ev.Title)
+	}
+	assert.Equal(t, []string{"overdue", "soon"}, titles)
+
+	overdue := events[0]
+	require.NoError(t, store.MarkReminderSent(ctx, overdue.ID, overdue.NotifyAt()))
+	events, err = store.UpcomingReminders(ctx, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "soon", events[0].Title)
+
+	// Moving the event brings its reminder back.
+	moved, movedEnd := overdue.Date.Add(5*time.Minute), overdue.EndTime.Add(5*time.Minute)
+	_, err = store.EventEdit(ctx, &models.EditEventReq{ID: overdue.ID, Date: &moved, EndTime: &movedEnd})
+	require.NoError(t, err)
+	events, err = store.UpcomingReminders(ctx, now, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, events, 2)
+}
@@ -0,0 +1,124 @@
+package memorystorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrashAndRestore(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	start := time.Now()
+
+	event, err := store.EventCreate(ctx, newCreateReq("user", "Standup", start, start.Add(time.Hour)))
+	require.NoError(t, err)
+
+	require.NoError(t, store.EventDelete(ctx, &models.EventIDReq{ID: event.ID}))
+	assert.ErrorIs(t, store.EventDelete(ctx, &models.EventIDReq{ID: event.ID}), errors.ErrEventNotFound)
+
+	user := "user"
+	trash, err := store.EventListDeleted(ctx, &models.ListDeletedEventsReq{User: &user})
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, event.ID, trash[0].ID)
+	assert.NotNil(t, trash[0].DeletedAt)
+
+	// The trashed event no longer occupies its slot.
+	blocker, err := store.EventCreate(ctx, newCreateReq("user", "Blocker", start, start.Add(time.Hour)))
+	require.NoError(t, err)
+
+	_, err = store.EventRestore(ctx, &models.EventIDReq{ID: event.ID})
+	assert.ErrorIs(t, err, errors.ErrDateBusy)
+
+	require.NoError(t, store.EventDelete(ctx, &models.EventIDReq{ID: blocker.ID}))
+	restored, err := store.EventRestore(ctx, &models.EventIDReq{ID: event.ID})
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+
+	_, err = store.EventRestore(ctx, &models.EventIDReq{ID: event.ID})
+	assert.ErrorIs(t, err, errors.ErrEventNotFound)
+
+	_, err = store.EventGet(ctx, &models.EventIDReq{ID: event.ID})
+	require.NoError(t, err)
+}
+
+func TestPurgeDeletedEvents(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	start := time.Now()
+
+	event, err := store.EventCreate(ctx, newCreateReq("user", "Old", start, start.Add(time.Hour)))
+	require.NoError(t, err)
+	require.NoError(t, store.EventDelete(ctx, &models.EventIDReq{ID: event.ID}))
+
+	purged, err := store.ExpireEvents(ctx, &models.ExpireEventsReq{Trash: true, Before: start.Add(-time.Hour)})
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+	trash, err := store.EventListDeleted(ctx, &models.ListDeletedEventsReq{})
+	require.NoError(t, err)
+	assert.Len(t, trash, 1)
+
+	purged, err = store.ExpireEvents(ctx, &models.ExpireEventsReq{Trash: true, Before: time.Now().Add(time.Second)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, purged)
+	trash, err = store.EventListDeleted(ctx, &models.ListDeletedEventsReq{})
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+
+	_, err = store.EventRestore(ctx, &models.EventIDReq{ID: event.ID})
+	assert.ErrorIs(t, err, errors.ErrEventNotFound)
+}
+
+func TestExpireEvents(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+	old := time.Now().Add(-48 * time.Hour)
+
+	var ids []string
+	for i, user := range []string{"alice", "alice", "bob", "carol"} {
+		start := old.Add(time.Duration(i) * time.Hour)
+		ev, err := store.EventCreate(ctx, newCreateReq(user, "Old", start, start.Add(time.Hour)))
+		require.NoError(t, err)
+		ids = append(ids, ev.ID)
+	}
+	_, err := store.EventCreate(ctx, newCreateReq("alice", "Upcoming", time.Now().Add(time.Hour),
+		time.Now().Add(2*time.Hour)))
+	require.NoError(t, err)
+	// Trashed events are left to the trash rules.
+	require.NoError(t, store.EventDelete(ctx, &models.EventIDReq{ID: ids[3]}))
+
+	req := &models.ExpireEventsReq{Before: time.Now(), ExceptUsers: []string{"bob"}}
+	n, err := store.CountExpiredEvents(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	req.Limit = 1
+	for range 2 {
+		n, err = store.ExpireEvents(ctx, req)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	}
+	n, err = store.ExpireEvents(ctx, req)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	user := "alice"
+	list, err := store.EventGetList(ctx, &models.GetEventListReq{User: &user})
+	require.NoError(t, err)
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "Upcoming", list.Data[0].Title)
+
+	n, err = store.ExpireEvents(ctx, &models.ExpireEventsReq{Before: time.Now(), Users: []string{"bob"}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	_, err = store.ExpireEvents(ctx, &models.ExpireEventsReq{Trash: true, Before: time.Now(), Archive: true})
+	assert.ErrorIs(t, err, storage.ErrNoArchive)
+}
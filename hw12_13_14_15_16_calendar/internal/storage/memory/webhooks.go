@@ -0,0 +1,199 @@
+package memorystorage
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/google/uuid"
+)
+
+func (s *LocalStorage) WebhookCreate(_ context.Context, req *models.CreateWebhookReq) (*models.Webhook, error) {
+	s.logger.Debug("WebhookCreate called")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	hook := &models.Webhook{
+		ID:        uuid.New().String(),
+		Owner:     req.Owner,
+		URL:       req.URL,
+		Secret:    req.Secret,
+		Types:     slices.Clone(req.Types),
+		CreatedAt: time.Now().UTC(),
+	}
+	s.webhooks[hook.ID] = hook
+
+	s.logger.Debug("webhook created id=" + hook.ID)
+	cpy := copyWebhook(hook)
+	return &cpy, nil
+}
+
+func (s *LocalStorage) WebhookList(_ context.Context, req *models.ListWebhooksReq) ([]models.Webhook, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.Webhook, 0)
+	for _, hook := range s.webhooks {
+		if hook.Owner == req.User {
+			result = append(result, copyWebhook(hook))
+		}
+	}
+	slices.SortFunc(result, func(a, b models.Webhook) int {
+		return cmp.Or(a.CreatedAt.Compare(b.CreatedAt), cmp.Compare(a.ID, b.ID))
+	})
+	return result, nil
+}
+
+func (s *LocalStorage) WebhookDelete(_ context.Context, req *models.WebhookIDReq) error {
+	s.logger.Debug("WebhookDelete called id=" + req.ID)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.webhooks[req.ID]; !ok {
+		return fmt.Errorf("webhook delete: %w", errors.NotFound(errors.ErrWebhookNotFound, req.ID))
+	}
+	delete(s.webhooks, req.ID)
+	for id, d := range s.deliveries {
+		if d.WebhookID == req.ID {
+			delete(s.deliveries, id)
+		}
+	}
+
+	s.logger.Debug("webhook deleted id=" + req.ID)
+	return nil
+}
+
+func (s *LocalStorage) WebhookEnqueue(_ context.Context, req *models.EnqueueWebhookReq) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	n := 0
+	for _, hook := range s.webhooks {
+		if !slices.Contains(req.Users, hook.Owner) || !slices.Contains(hook.Types, req.Type) {
+			continue
+		}
+		if req.Key != "" && s.hasDelivery(hook.ID, req.Key) {
+			continue
+		}
+		d := &models.WebhookDelivery{
+			ID:            uuid.New().String(),
+			WebhookID:     hook.ID,
+			Type:          req.Type,
+			Payload:       slices.Clone(req.Payload),
+			Status:        models.DeliveryPending,
+			CreatedAt:     now,
+			Key:           req.Key,
+			NextAttemptAt: now,
+		}
+		s.deliveries[d.ID] = d
+		n++
+	}
+	return n, nil
+}
+
+// hasDelivery reports whether the webhook has a delivery with the key; the caller holds the lock.
+func (s *LocalStorage) hasDelivery(webhookID, key string) bool {
+	for _, d := range s.deliveries {
+		if d.WebhookID == webhookID && d.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *LocalStorage) WebhookClaimDeliveries(
+	_ context.Context,
+	req *models.ClaimDeliveriesReq,
+) ([]models.WebhookDelivery, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	due := make([]*models.WebhookDelivery, 0)
+	for _, d := range s.deliveries {
+		if d.Status == models.DeliveryPending && !d.NextAttemptAt.After(req.Now) {
+			due = append(due, d)
+		}
+	}
+	slices.SortFunc(due, func(a, b *models.WebhookDelivery) int {
+		return cmp.Or(a.NextAttemptAt.Compare(b.NextAttemptAt), cmp.Compare(a.ID, b.ID))
+	})
+	if req.Limit > 0 && len(due) > req.Limit {
+		due = due[:req.Limit]
+	}
+
+	result := make([]models.WebhookDelivery, 0, len(due))
+	for _, d := range due {
+		d.NextAttemptAt = req.Now.Add(req.Lease)
+		cpy := copyDelivery(d)
+		hook := s.webhooks[d.WebhookID]
+		cpy.URL, cpy.Secret = hook.URL, hook.Secret
+		result = append(result, cpy)
+	}
+	return result, nil
+}
+
+func (s *LocalStorage) WebhookRecordAttempt(_ context.Context, req *models.DeliveryAttempt) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	d, ok := s.deliveries[req.DeliveryID]
+	if !ok {
+		// The webhook was deleted while the delivery was being sent.
+		return nil
+	}
+	at := req.At
+	d.Attempts++
+	d.Status = req.Status
+	d.LastAttemptAt = &at
+	d.ResponseCode = req.ResponseCode
+	d.LastError = req.Error
+	d.NextAttemptAt = req.NextAttemptAt
+	if req.Status == models.DeliveryDelivered {
+		d.DeliveredAt = &at
+	}
+	return nil
+}
+
+func (s *LocalStorage) WebhookDeliveries(
+	_ context.Context,
+	req *models.ListDeliveriesReq,
+) ([]models.WebhookDelivery, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.webhooks[req.WebhookID]; !ok {
+		return nil, fmt.Errorf("webhook deliveries: %w", errors.NotFound(errors.ErrWebhookNotFound, req.WebhookID))
+	}
+	result := make([]models.WebhookDelivery, 0)
+	for _, d := range s.deliveries {
+		if d.WebhookID == req.WebhookID {
+			result = append(result, copyDelivery(d))
+		}
+	}
+	slices.SortFunc(result, func(a, b models.WebhookDelivery) int {
+		return cmp.Or(b.CreatedAt.Compare(a.CreatedAt), cmp.Compare(b.ID, a.ID))
+	})
+	if req.Limit > 0 && len(result) > req.Limit {
+		result = result[:req.Limit]
+	}
+	return result, nil
+}
+
+func copyWebhook(hook *models.Webhook) models.Webhook {
+	cpy := *hook
+	cpy.Types = slices.Clone(hook.Types)
+	return cpy
+}
+
+func copyDelivery(d *models.WebhookDelivery) models.WebhookDelivery {
+	cpy := *d
+	cpy.Payload = slices.Clone(d.Payload)
+	return cpy
+}
@@ -0,0 +1,116 @@
+package memorystorage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookQueue(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	events, err := store.WebhookCreate(ctx, &models.CreateWebhookReq{
+		Owner: "owner", URL: "https://example.com/events", Secret: "s1",
+		Types: []string{models.WebhookEventCreated, models.WebhookEventDeleted},
+	})
+	require.NoError(t, err)
+	reminders, err := store.WebhookCreate(ctx, &models.CreateWebhookReq{
+		Owner: "owner", URL: "https://example.com/reminders", Secret: "s2", Types: []string{models.WebhookReminderDue},
+	})
+	require.NoError(t, err)
+	_, err = store.WebhookCreate(ctx, &models.CreateWebhookReq{
+		Owner: "other", URL: "https://example.org", Secret: "s3", Types: []string{models.WebhookEventCreated},
+	})
+	require.NoError(t, err)
+
+	list, err := store.WebhookList(ctx, &models.ListWebhooksReq{User: "owner"})
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+
+	// Only the subscriptions of the users involved and to the type get a delivery.
+	n, err := store.WebhookEnqueue(ctx, &models.EnqueueWebhookReq{
+		Type: models.WebhookEventCreated, Users: []string{"owner", "attendee"}, Payload: []byte(`{"n":1}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = store.WebhookEnqueue(ctx, &models.EnqueueWebhookReq{
+		Type: models.WebhookEventUpdated, Users: []string{"owner"}, Payload: []byte(`{}`),
+	})
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	now := time.Now().Add(time.Second)
+	claimed, err := store.WebhookClaimDeliveries(ctx, &models.ClaimDeliveriesReq{Now: now, Lease: time.Minute, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	d := claimed[0]
+	assert.Equal(t, events.ID, d.WebhookID)
+	assert.Equal(t, "https://example.com/events", d.URL)
+	assert.Equal(t, "s1", d.Secret)
+	assert.JSONEq(t, `{"n":1}`, string(d.Payload))
+
+	// A claimed delivery is leased and not handed out again.
+	claimed, err = store.WebhookClaimDeliveries(ctx, &models.ClaimDeliveriesReq{Now: now, Lease: time.Minute, Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, claimed)
+
+	require.NoError(t, store.WebhookRecordAttempt(ctx, &models.DeliveryAttempt{
+		DeliveryID: d.ID, At: now, Status: models.DeliveryPending, ResponseCode: 503, Error: "503 Service Unavailable",
+		NextAttemptAt: now.Add(10 * time.Second),
+	}))
+	claimed, err = store.WebhookClaimDeliveries(ctx, &models.ClaimDeliveriesReq{
+		Now: now.Add(10 * time.Second), Lease: time.Minute, Limit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	require.NoError(t, store.WebhookRecordAttempt(ctx, &models.DeliveryAttempt{
+		DeliveryID: d.ID, At: now.Add(10 * time.Second), Status: models.DeliveryDelivered, ResponseCode: 204,
+	}))
+
+	log, err := store.WebhookDeliveries(ctx, &models.ListDeliveriesReq{WebhookID: events.ID})
+	require.NoError(t, err)
+	require.Len(t, log, 1)
+	assert.Equal(t, models.DeliveryDelivered, log[0].Status)
+	assert.Equal(t, 2, log[0].Attempts)
+	assert.Equal(t, 204, log[0].ResponseCode)
+	assert.Empty(t, log[0].LastError)
+	require.NotNil(t, log[0].DeliveredAt)
+
+	log, err = store.WebhookDeliveries(ctx, &models.ListDeliveriesReq{WebhookID: reminders.ID})
+	require.NoError(t, err)
+	assert.Empty(t, log)
+
+	require.NoError(t, store.WebhookDelete(ctx, &models.WebhookIDReq{ID: events.ID}))
+	assert.ErrorIs(t, store.WebhookDelete(ctx, &models.WebhookIDReq{ID: events.ID}), errors.ErrWebhookNotFound)
+	_, err = store.WebhookDeliveries(ctx, &models.ListDeliveriesReq{WebhookID: events.ID})
+	assert.ErrorIs(t, err, errors.ErrWebhookNotFound)
+}
+
+func TestWebhookEnqueueKey(t *testing.T) {
+	store := NewLocalStorage(testLogger())
+	ctx := context.Background()
+
+	_, err := store.WebhookCreate(ctx, &models.CreateWebhookReq{
+		Owner: "owner", URL: "https://example.com/reminders", Secret: "s", Types: []string{models.WebhookReminderDue},
+	})
+	require.NoError(t, err)
+
+	enqueue := func(key string) int {
+		n, err := store.WebhookEnqueue(ctx, &models.EnqueueWebhookReq{
+			Type: models.WebhookReminderDue, Users: []string{"owner"}, Payload: []byte(`{}`), Key: key,
+		})
+		require.NoError(t, err)
+		return n
+	}
+	assert.Equal(t, 1, enqueue("event@2026-10-20T09:45:00Z"))
+	assert.Zero(t, enqueue("event@2026-10-20T09:45:00Z"), "same key is queued once")
+	assert.Equal(t, 1, enqueue("event@2026-10-20T10:45:00Z"))
+	assert.Equal(t, 1, enqueue(""))
+	assert.Equal(t, 1, enqueue(""), "empty key is not deduplicated")
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// Attachment is the record of a file attached to an event. The content is kept apart, in the
+// attachment store, under the event and attachment IDs.
+type Attachment struct {
+	ID          string
+	EventID     string
+	Name        string
+	ContentType string
+	Size        int64
+	// SHA256 is the hex digest of the content.
+	SHA256     string
+	UploadedBy string
+	CreatedAt  time.Time
+}
+
+// CreateAttachmentReq records a stored file. The event has to be outside the trash and, unless
+// MaxPerEvent is zero, have fewer attachments than that.
+type CreateAttachmentReq struct {
+	ID          string
+	EventID     string
+	Name        string
+	ContentType string
+	Size        int64
+	SHA256      string
+	UploadedBy  string
+	MaxPerEvent int
+}
+
+type AttachmentIDReq struct {
+	ID string
+}
+
+type ListAttachmentsReq struct {
+	EventID string
+}
+
+// AttachmentOrphan is an attachment whose record is gone, deleted alone or with its event, while
+// its content may still be in the attachment store.
+type AttachmentOrphan struct {
+	ID      string
+	EventID string
+}
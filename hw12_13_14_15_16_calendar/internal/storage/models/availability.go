@@ -0,0 +1,145 @@
+package models
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+type FreeBusyReq struct {
+	Users []string
+	Start time.Time
+	End   time.Time
+}
+
+type UserBusy struct {
+	User string
+	Busy []Interval
+}
+
+// WorkingHours limits proposed slots to [From, To) after local midnight in Location
+// on the given weekdays. Empty Weekdays means every day.
+type WorkingHours struct {
+	From     time.Duration
+	To       time.Duration
+	Location *time.Location
+	Weekdays []time.Weekday
+}
+
+type FindSlotReq struct {
+	Users        []string
+	Start        time.Time
+	End          time.Time
+	Duration     time.Duration
+	Count        int
+	WorkingHours *WorkingHours
+}
+
+// MergeIntervals clips intervals to [start, end) and joins the overlapping or adjacent ones.
+// The result is sorted by start time.
+func MergeIntervals(intervals []Interval, start, end time.Time) []Interval {
+	clipped := make([]Interval, 0, len(intervals))
+	for _, iv := range intervals {
+		if iv.Start.Before(start) {
+			iv.Start = start
+		}
+		if iv.End.After(end) {
+			iv.End = end
+		}
+		if iv.Start.Before(iv.End) {
+			clipped = append(clipped, iv)
+		}
+	}
+	sort.Slice(clipped, func(i, j int) bool { return clipped[i].Start.Before(clipped[j].Start) })
+
+	res := make([]Interval, 0, len(clipped))
+	for _, iv := range clipped {
+		if n := len(res); n > 0 && !iv.Start.After(res[n-1].End) {
+			if iv.End.After(res[n-1].End) {
+				res[n-1].End = iv.End
+			}
+			continue
+		}
+		res = append(res, iv)
+	}
+	return res
+}
+
+// FreeSlots proposes up to req.Count back-to-back slots of req.Duration that avoid every busy
+// interval and fit into the working hours, earliest first.
+func FreeSlots(busy []Interval, req *FindSlotReq) []Interval {
+	busy = MergeIntervals(busy, req.Start, req.End)
+
+	var res []Interval
+	for _, window := range workingWindows(req) {
+		from := window.Start
+		for _, b := range busy {
+			if !b.End.After(from) {
+				continue
+			}
+			if !b.Start.Before(window.End) {
+				break
+			}
+			res = fillGap(res, from, b.Start, req)
+			from = b.End
+		}
+		res = fillGap(res, from, window.End, req)
+		if len(res) >= req.Count {
+			return res[:req.Count]
+		}
+	}
+	return res
+}
+
+func fillGap(res []Interval, from, to time.Time, req *FindSlotReq) []Interval {
+	for len(res) < req.Count && !from.Add(req.Duration).After(to) {
+		res = append(res, Interval{Start: from, End: from.Add(req.Duration)})
+		from = from.Add(req.Duration)
+	}
+	return res
+}
+
+func workingWindows(req *FindSlotReq) []Interval {
+	wh := req.WorkingHours
+	if wh == nil {
+		return []Interval{{Start: req.Start, End: req.End}}
+	}
+
+	loc := wh.Location
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	var res []Interval
+	local := req.Start.In(loc)
+	for day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc); day.Before(req.End); {
+		y, m, d := day.Date()
+		next := time.Date(y, m, d+1, 0, 0, 0, 0, loc)
+		if len(wh.Weekdays) > 0 && !slices.Contains(wh.Weekdays, day.Weekday()) {
+			day = next
+			continue
+		}
+
+		// time.Date normalises minutes past 60, which keeps wall-clock hours right across DST changes.
+		window := Interval{
+			Start: time.Date(y, m, d, 0, int(wh.From/time.Minute), 0, 0, loc),
+			End:   time.Date(y, m, d, 0, int(wh.To/time.Minute), 0, 0, loc),
+		}
+		if window.Start.Before(req.Start) {
+			window.Start = req.Start
+		}
+		if window.End.After(req.End) {
+			window.End = req.End
+		}
+		if window.Start.Before(window.End) {
+			res = append(res, window)
+		}
+		day = next
+	}
+	return res
+}
@@ -0,0 +1,23 @@
+package models
+
+import "fmt"
+
+// BatchResult is the outcome of a single item of a best-effort batch.
+type BatchResult struct {
+	Event *Event
+	Err   error
+}
+
+// BatchItemError reports the item that aborted an atomic batch.
+type BatchItemError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchItemError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchItemError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,72 @@
+package models
+
+type CalendarRole string
+
+const (
+	RoleRead  CalendarRole = "read"
+	RoleWrite CalendarRole = "write"
+)
+
+type CalendarShare struct {
+	User string
+	Role CalendarRole
+}
+
+type Calendar struct {
+	ID          string
+	Owner       string
+	Title       string
+	Description *string
+	Shares      []CalendarShare
+}
+
+// CanWrite reports whether user may add or change events in the calendar.
+func (c *Calendar) CanWrite(user string) bool {
+	if c.Owner == user {
+		return true
+	}
+	for _, sh := range c.Shares {
+		if sh.User == user && sh.Role == RoleWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// CanRead reports whether the calendar is visible to user.
+func (c *Calendar) CanRead(user string) bool {
+	if c.Owner == user {
+		return true
+	}
+	for _, sh := range c.Shares {
+		if sh.User == user {
+			return true
+		}
+	}
+	return false
+}
+
+type CreateCalendarReq struct {
+	Owner       string
+	Title       string
+	Description *string
+}
+
+type CalendarIDReq struct {
+	ID string
+}
+
+type ListCalendarsReq struct {
+	User string
+}
+
+type ShareCalendarReq struct {
+	CalendarID string
+	User       string
+	Role       CalendarRole
+}
+
+type UnshareCalendarReq struct {
+	CalendarID string
+	User       string
+}
@@ -1,6 +1,24 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+)
+
+type AttendeeStatus string
+
+const (
+	AttendeeNeedsAction AttendeeStatus = "needs_action"
+	AttendeeAccepted    AttendeeStatus = "accepted"
+	AttendeeDeclined    AttendeeStatus = "declined"
+)
+
+type Attendee struct {
+	User   string
+	Status AttendeeStatus
+}
 
 type Event struct {
 	ID           string
@@ -10,15 +28,168 @@ type Event struct {
 	Description  *string
 	User         string
 	NotifyBefore *string
+	CalendarID   *string
+	Attendees    []Attendee
+	// DeletedAt is set while the event is in the trash.
+	DeletedAt *time.Time
+	// TimeZone is the IANA name of the zone the event is planned in, UTC when empty.
+	TimeZone string
+	// AllDay events span whole days of TimeZone, see Normalize.
+	AllDay bool
+	// Labels are names from the owner's label catalogue, sorted.
+	Labels []string
+	// Location is where the event takes place, and MeetingURL the link to join it online.
+	Location   *string
+	MeetingURL *string
+}
+
+// TimeLocation loads the event's time zone.
+func (e *Event) TimeLocation() (*time.Location, error) {
+	if e.TimeZone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(e.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", errors.ErrInvalidTimeZone, e.TimeZone)
+	}
+	return loc, nil
+}
+
+// Normalize checks the time zone and widens an all-day event to midnights of its zone, so that
+// storages compare plain instants and a day across a DST change is 23 or 25 hours long. An empty
+// location or meeting URL is dropped.
+func (e *Event) Normalize() error {
+	if e.Location != nil && *e.Location == "" {
+		e.Location = nil
+	}
+	if e.MeetingURL != nil && *e.MeetingURL == "" {
+		e.MeetingURL = nil
+	}
+	loc, err := e.TimeLocation()
+	if err != nil {
+		return err
+	}
+	if e.TimeZone == "" {
+		e.TimeZone = time.UTC.String()
+	}
+	if !e.AllDay {
+		return nil
+	}
+
+	e.Date = startOfDay(e.Date, loc)
+	end := startOfDay(e.EndTime, loc)
+	if end.Before(e.EndTime) {
+		end = end.AddDate(0, 0, 1)
+	}
+	if !end.After(e.Date) {
+		end = e.Date.AddDate(0, 0, 1)
+	}
+	e.EndTime = end
+	return nil
+}
+
+// NotifyAt returns when the reminder is due. NotifyBefore is subtracted on the wall clock of the
+// event's zone, so a "24h" reminder keeps its local time across a DST change.
+func (e *Event) NotifyAt() time.Time {
+	if e.NotifyBefore == nil {
+		return e.Date
+	}
+	before, err := time.ParseDuration(*e.NotifyBefore)
+	if err != nil {
+		return e.Date
+	}
+	loc, err := e.TimeLocation()
+	if err != nil {
+		loc = time.UTC
+	}
+
+	local := e.Date.In(loc)
+	wall := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), local.Second(),
+		local.Nanosecond(), time.UTC).Add(-before)
+	return time.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), wall.Second(),
+		wall.Nanosecond(), loc)
+}
+
+func startOfDay(t time.Time, loc *time.Location) time.Time {
+	y, m, d := t.In(loc).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+// BusyUsers returns the users whose time the event occupies: the owner and every attendee
+// who has not declined.
+func (e *Event) BusyUsers() []string {
+	users := []string{e.User}
+	for _, a := range e.Attendees {
+		if a.Status != AttendeeDeclined && a.User != e.User {
+			users = append(users, a.User)
+		}
+	}
+	return users
+}
+
+// InvolvedUsers returns the owner and every attendee, whatever their answer.
+func (e *Event) InvolvedUsers() []string {
+	users := []string{e.User}
+	for _, a := range e.Attendees {
+		if a.User != e.User {
+			users = append(users, a.User)
+		}
+	}
+	return users
+}
+
+// Involves reports whether user owns the event or is invited to it.
+func (e *Event) Involves(user string) bool {
+	if e.User == user {
+		return true
+	}
+	for _, a := range e.Attendees {
+		if a.User == user {
+			return true
+		}
+	}
+	return false
+}
+
+// MergeAttendees builds the attendee list for users, keeping the status of those already invited.
+func MergeAttendees(current []Attendee, users []string) []Attendee {
+	statuses := make(map[string]AttendeeStatus, len(current))
+	for _, a := range current {
+		statuses[a.User] = a.Status
+	}
+
+	res := make([]Attendee, 0, len(users))
+	seen := make(map[string]bool, len(users))
+	for _, u := range users {
+		if seen[u] {
+			continue
+		}
+		seen[u] = true
+		status, ok := statuses[u]
+		if !ok {
+			status = AttendeeNeedsAction
+		}
+		res = append(res, Attendee{User: u, Status: status})
+	}
+	return res
 }
 
 type CreateEventReq struct {
+	// ID is generated when empty.
+	ID           string
 	Title        string
 	Date         time.Time
 	EndTime      time.Time
 	Description  *string
 	User         string
 	NotifyBefore *string
+	CalendarID   *string
+	Attendees    []string
+	TimeZone     string
+	AllDay       bool
+	Labels       []string
+	Location     *string
+	MeetingURL   *string
 }
 
 type EditEventReq struct {
@@ -29,17 +200,54 @@ type EditEventReq struct {
 	Description  *string
 	User         *string
 	NotifyBefore *string
+	CalendarID   *string
+	// Attendees replaces the attendee list when not nil.
+	Attendees *[]string
+	TimeZone  *string
+	AllDay    *bool
+	// Labels replaces the labels when not nil.
+	Labels *[]string
+	// Location and MeetingURL replace the current ones when not nil; empty ones are removed.
+	Location   *string
+	MeetingURL *string
+}
+
+type RespondEventReq struct {
+	EventID string
+	User    string
+	Status  AttendeeStatus
 }
 
 type EventIDReq struct {
 	ID string
 }
 
+type ListDeletedEventsReq struct {
+	User *string
+}
+
 type GetEventListReq struct {
 	Start *time.Time
 	End   *time.Time
+	// Labels keeps events carrying any of them.
+	Labels []string
+	// User keeps events the user owns or attends.
+	User *string
 }
 
 type GetEventListResp struct {
 	Data []Event
 }
+
+type SearchEventsReq struct {
+	Query string
+	User  string
+	Start *time.Time
+	End   *time.Time
+	Limit int
+}
+
+type SearchHit struct {
+	Event Event
+	Rank  float64
+}
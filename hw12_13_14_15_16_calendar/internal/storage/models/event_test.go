@@ -0,0 +1,51 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAllDay(t *testing.T) {
+	// Berlin leaves summer time on 2026-10-25, so that day lasts 25 hours.
+	noon := time.Date(2026, 10, 25, 11, 0, 0, 0, time.UTC)
+	e := Event{Date: noon, EndTime: noon.Add(time.Hour), TimeZone: "Europe/Berlin", AllDay: true}
+
+	require.NoError(t, e.Normalize())
+	assert.Equal(t, time.Date(2026, 10, 24, 22, 0, 0, 0, time.UTC), e.Date.UTC())
+	assert.Equal(t, time.Date(2026, 10, 25, 23, 0, 0, 0, time.UTC), e.EndTime.UTC())
+
+	// Already normalized events stay as they are.
+	date, end := e.Date, e.EndTime
+	require.NoError(t, e.Normalize())
+	assert.Equal(t, date, e.Date)
+	assert.Equal(t, end, e.EndTime)
+}
+
+func TestNormalizeTimeZone(t *testing.T) {
+	e := Event{Date: time.Now(), EndTime: time.Now().Add(time.Hour)}
+	require.NoError(t, e.Normalize())
+	assert.Equal(t, "UTC", e.TimeZone)
+
+	e.TimeZone = "Mars/Olympus"
+	assert.ErrorIs(t, e.Normalize(), errors.ErrInvalidTimeZone)
+}
+
+func TestNotifyAtKeepsLocalTime(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	before := "24h"
+	e := Event{
+		Date:         time.Date(2026, 10, 25, 10, 0, 0, 0, berlin),
+		NotifyBefore: &before,
+		TimeZone:     "Europe/Berlin",
+	}
+	assert.True(t, time.Date(2026, 10, 24, 10, 0, 0, 0, berlin).Equal(e.NotifyAt()))
+
+	e.NotifyBefore = nil
+	assert.True(t, e.Date.Equal(e.NotifyAt()))
+}
@@ -0,0 +1,34 @@
+package models
+
+import "slices"
+
+type Label struct {
+	ID    string
+	Owner string
+	Name  string
+	Color *string
+}
+
+type CreateLabelReq struct {
+	Owner string
+	Name  string
+	Color *string
+}
+
+type ListLabelsReq struct {
+	User string
+}
+
+type LabelIDReq struct {
+	ID string
+}
+
+// NormalizeLabels sorts names and drops duplicates.
+func NormalizeLabels(names []string) []string {
+	if len(names) == 0 {
+		return nil
+	}
+	res := slices.Clone(names)
+	slices.Sort(res)
+	return slices.Compact(res)
+}
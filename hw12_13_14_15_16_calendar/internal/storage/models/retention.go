@@ -0,0 +1,35 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// ExpireEventsReq selects the events a retention rule removes: with Trash the events moved to
+// the trash before Before, otherwise the live events that ended before it.
+type ExpireEventsReq struct {
+	Trash  bool
+	Before time.Time
+	// Users limits the rule to the events of these owners, ExceptUsers leaves theirs alone.
+	Users       []string
+	ExceptUsers []string
+	// Archive keeps a copy of every removed event in the archive.
+	Archive bool
+	// Limit caps the events removed by one call; 0 removes them all.
+	Limit int
+}
+
+// Matches reports whether the request selects ev.
+func (r *ExpireEventsReq) Matches(ev *Event) bool {
+	if r.Trash {
+		if ev.DeletedAt == nil || !ev.DeletedAt.Before(r.Before) {
+			return false
+		}
+	} else if ev.DeletedAt != nil || !ev.EndTime.Before(r.Before) {
+		return false
+	}
+	if len(r.Users) > 0 && !slices.Contains(r.Users, ev.User) {
+		return false
+	}
+	return !slices.Contains(r.ExceptUsers, ev.User)
+}
@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+// Webhook event types.
+const (
+	WebhookEventCreated = "event.created"
+	WebhookEventUpdated = "event.updated"
+	WebhookEventDeleted = "event.deleted"
+	WebhookReminderDue  = "reminder.due"
+)
+
+// Webhook is a subscription of a user to some types of changes, delivered to URL and signed with Secret.
+type Webhook struct {
+	ID        string
+	Owner     string
+	URL       string
+	Secret    string
+	Types     []string
+	CreatedAt time.Time
+}
+
+type CreateWebhookReq struct {
+	Owner  string
+	URL    string
+	Secret string
+	Types  []string
+}
+
+type ListWebhooksReq struct {
+	User string
+}
+
+type WebhookIDReq struct {
+	ID string
+}
+
+// EnqueueWebhookReq queues Payload for every webhook of any of Users subscribed to Type.
+type EnqueueWebhookReq struct {
+	Type    string
+	Users   []string
+	Payload []byte
+	// Key, when set, makes the enqueueing idempotent: a webhook gets one delivery per key.
+	Key string
+}
+
+type DeliveryStatus string
+
+const (
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliveryDelivered DeliveryStatus = "delivered"
+	// DeliveryFailed is final: the delivery ran out of attempts.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// WebhookDelivery is a payload queued for a webhook, and its entry in the delivery log.
+type WebhookDelivery struct {
+	ID        string
+	WebhookID string
+	Type      string
+	Payload   []byte
+	Status    DeliveryStatus
+	Attempts  int
+	CreatedAt time.Time
+	// Key is that of the enqueueing, see EnqueueWebhookReq.
+	Key string
+	// NextAttemptAt is when a pending delivery is due.
+	NextAttemptAt time.Time
+	LastAttemptAt *time.Time
+	// ResponseCode is the HTTP status of the last attempt, 0 when it got no response.
+	ResponseCode int
+	LastError    string
+	DeliveredAt  *time.Time
+	// URL and Secret are those of the webhook, set on claimed deliveries.
+	URL    string
+	Secret string
+}
+
+// ClaimDeliveriesReq takes up to Limit pending deliveries due at Now and postpones them by Lease,
+// so other dispatchers skip them while they are sent.
+type ClaimDeliveriesReq struct {
+	Now   time.Time
+	Lease time.Duration
+	Limit int
+}
+
+// DeliveryAttempt is the outcome of sending a delivery once.
+type DeliveryAttempt struct {
+	DeliveryID   string
+	At           time.Time
+	Status       DeliveryStatus
+	ResponseCode int
+	Error        string
+	// NextAttemptAt is when a delivery left pending is retried.
+	NextAttemptAt time.Time
+}
+
+type ListDeliveriesReq struct {
+	WebhookID string
+	// Limit caps the entries returned, newest first.
+	Limit int
+}
@@ -0,0 +1,202 @@
+package sqlstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const attachmentColumns = `id, event_id, name, content_type, size, sha256, uploaded_by, created_at`
+
+func scanAttachment(row pgx.Row) (*models.Attachment, error) {
+	var a models.Attachment
+	err := row.Scan(&a.ID, &a.EventID, &a.Name, &a.ContentType, &a.Size, &a.SHA256, &a.UploadedBy, &a.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// AttachmentCreate locks the event row, so that concurrent uploads cannot go over MaxPerEvent.
+func (s *DBStorage) AttachmentCreate(
+	ctx context.Context,
+	req *models.CreateAttachmentReq,
+) (*models.Attachment, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var a *models.Attachment
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		if err := s.lockLiveEvent(ctx, tx, req.EventID); err != nil {
+			return err
+		}
+
+		if req.MaxPerEvent > 0 {
+			sql := `SELECT count(*) FROM event_attachments WHERE event_id = $1`
+			s.logger.Debug("SQL: " + sql)
+			var n int
+			if err := tx.QueryRow(ctx, sql, req.EventID).Scan(&n); err != nil {
+				return fmt.Errorf("count attachments: %w", err)
+			}
+			if n >= req.MaxPerEvent {
+				return calendarErrors.ErrTooManyAttachments
+			}
+		}
+
+		sql := `
+			INSERT INTO event_attachments (id, event_id, name, content_type, size, sha256, uploaded_by)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			RETURNING ` + attachmentColumns
+		s.logger.Debug("SQL: " + sql)
+		var err error
+		a, err = scanAttachment(tx.QueryRow(ctx, sql, req.ID, req.EventID, req.Name, req.ContentType, req.Size,
+			req.SHA256, req.UploadedBy))
+		if err != nil {
+			s.logger.Error("insert attachment failed: " + err.Error())
+			return fmt.Errorf("insert attachment: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("attachment create: %w", err)
+	}
+
+	s.logger.Debug("attachment created id=" + a.ID)
+	return a, nil
+}
+
+func (s *DBStorage) AttachmentGet(ctx context.Context, req *models.AttachmentIDReq) (*models.Attachment, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `SELECT ` + attachmentColumns + ` FROM event_attachments WHERE id = $1`
+	s.logger.Debug("SQL: " + sql)
+
+	a, err := scanAttachment(s.DB.QueryRow(ctx, sql, req.ID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, calendarErrors.NotFound(calendarErrors.ErrAttachmentNotFound, req.ID)
+		}
+		s.logger.Error("get attachment failed: " + err.Error())
+		return nil, fmt.Errorf("get attachment: %w", err)
+	}
+	return a, nil
+}
+
+func (s *DBStorage) AttachmentList(ctx context.Context, req *models.ListAttachmentsReq) ([]models.Attachment, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `SELECT EXISTS (SELECT 1 FROM events WHERE id = $1 AND deleted_at IS NULL)`
+	s.logger.Debug("SQL: " + sql)
+	var live bool
+	if err := s.DB.QueryRow(ctx, sql, req.EventID).Scan(&live); err != nil {
+		s.logger.Error("list attachments failed: " + err.Error())
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+	if !live {
+		return nil, fmt.Errorf("list attachments: %w", calendarErrors.NotFound(calendarErrors.ErrEventNotFound, req.EventID))
+	}
+
+	sql = `
+		SELECT ` + attachmentColumns + `
+		FROM event_attachments
+		WHERE event_id = $1
+		ORDER BY created_at, id`
+	s.logger.Debug("SQL: " + sql)
+
+	rows, err := s.DB.Query(ctx, sql, req.EventID)
+	if err != nil {
+		s.logger.Error("list attachments failed: " + err.Error())
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.Attachment, error) {
+		a, err := scanAttachment(row)
+		if err != nil {
+			return models.Attachment{}, err
+		}
+		return *a, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan attachment: %w", err)
+	}
+	return res, nil
+}
+
+func (s *DBStorage) AttachmentDelete(ctx context.Context, req *models.AttachmentIDReq) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `DELETE FROM event_attachments WHERE id = $1`
+	s.logger.Debug("SQL: " + sql)
+
+	tag, err := s.DB.Exec(ctx, sql, req.ID)
+	if err != nil {
+		s.logger.Error("delete attachment failed: " + err.Error())
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete attachment: %w", calendarErrors.NotFound(calendarErrors.ErrAttachmentNotFound, req.ID))
+	}
+
+	s.logger.Debug("attachment deleted id=" + req.ID)
+	return nil
+}
+
+// AttachmentOrphans reads the rows the trigger on event_attachments adds for every removed record,
+// however it was removed: alone, or with its event by a cascade.
+func (s *DBStorage) AttachmentOrphans(ctx context.Context, limit int) ([]models.AttachmentOrphan, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `SELECT id, event_id FROM attachment_orphans ORDER BY removed_at, id LIMIT $1`
+	s.logger.Debug("SQL: " + sql)
+
+	rows, err := s.DB.Query(ctx, sql, limit)
+	if err != nil {
+		s.logger.Error("list attachment orphans failed: " + err.Error())
+		return nil, fmt.Errorf("list attachment orphans: %w", err)
+	}
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.AttachmentOrphan, error) {
+		var o models.AttachmentOrphan
+		err := row.Scan(&o.ID, &o.EventID)
+		return o, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("scan attachment orphan: %w", err)
+	}
+	return res, nil
+}
+
+func (s *DBStorage) AttachmentOrphansForget(ctx context.Context, ids []string) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `DELETE FROM attachment_orphans WHERE id = ANY($1::uuid[])`
+	s.logger.Debug("SQL: " + sql)
+
+	if _, err := s.DB.Exec(ctx, sql, ids); err != nil {
+		s.logger.Error("forget attachment orphans failed: " + err.Error())
+		return fmt.Errorf("forget attachment orphans: %w", err)
+	}
+	return nil
+}
+
+// lockLiveEvent locks the event row and fails with ErrEventNotFound unless the event exists outside the trash.
+func (s *DBStorage) lockLiveEvent(ctx context.Context, tx pgx.Tx, eventID string) error {
+	sql := `SELECT 1 FROM events WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`
+	s.logger.Debug("SQL: " + sql)
+
+	var one int
+	if err := tx.QueryRow(ctx, sql, eventID).Scan(&one); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return calendarErrors.NotFound(calendarErrors.ErrEventNotFound, eventID)
+		}
+		return fmt.Errorf("lock event: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package sqlstorage
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+func (s *DBStorage) FreeBusy(ctx context.Context, req *models.FreeBusyReq) ([]models.UserBusy, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	res, err := s.freeBusy(ctx, req.Users, req.Start, req.End)
+	if err != nil {
+		return nil, fmt.Errorf("free busy: %w", err)
+	}
+	return res, nil
+}
+
+func (s *DBStorage) FindSlot(ctx context.Context, req *models.FindSlotReq) ([]models.Interval, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	users, err := s.freeBusy(ctx, req.Users, req.Start, req.End)
+	if err != nil {
+		return nil, fmt.Errorf("find slot: %w", err)
+	}
+
+	var busy []models.Interval
+	for _, ub := range users {
+		busy = append(busy, ub.Busy...)
+	}
+	return models.FreeSlots(busy, req), nil
+}
+
+// freeBusy loads the events occupying each of users in [start, end): the ones they own and
+// the ones they attend without having declined.
+func (s *DBStorage) freeBusy(ctx context.Context, users []string, start, end time.Time) ([]models.UserBusy, error) {
+	query := `
+		SELECT u.user_id::text, e.start_time, e.end_time
+		FROM events e
+		JOIN LATERAL (
+			SELECT e.user_id
+			UNION
+			SELECT a.user_id FROM event_attendees a WHERE a.event_id = e.id AND a.status <> 'declined'
+		) u(user_id) ON true
+		WHERE u.user_id = ANY($1::uuid[])
+		  AND e.deleted_at IS NULL
+		  AND tstzrange(e.start_time, e.end_time) && tstzrange($2::timestamptz, $3::timestamptz)
+		ORDER BY e.start_time`
+	s.logger.Debug("SQL: " + query)
+
+	rows, err := s.DB.Query(ctx, query, users, start, end)
+	if err != nil {
+		s.logger.Error("free busy query failed: " + err.Error())
+		return nil, err
+	}
+	defer rows.Close()
+
+	busy := make(map[string][]models.Interval, len(users))
+	for rows.Next() {
+		var (
+			user string
+			iv   models.Interval
+		)
+		if err := rows.Scan(&user, &iv.Start, &iv.End); err != nil {
+			return nil, fmt.Errorf("scan busy interval: %w", err)
+		}
+		busy[user] = append(busy[user], iv)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	res := make([]models.UserBusy, 0, len(users))
+	for _, u := range users {
+		res = append(res, models.UserBusy{User: u, Busy: models.MergeIntervals(busy[u], start, end)})
+	}
+	return res, nil
+}
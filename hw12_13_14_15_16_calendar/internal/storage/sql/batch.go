@@ -0,0 +1,79 @@
+package sqlstorage
+
+import (
+	"context"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *DBStorage) EventBatchCreate(
+	ctx context.Context,
+	reqs []models.CreateEventReq,
+	atomic bool,
+) ([]models.BatchResult, error) {
+	return s.runBatch(ctx, len(reqs), atomic, func(ctx context.Context, tx pgx.Tx, i int) (*models.Event, error) {
+		return s.eventCreate(ctx, tx, &reqs[i])
+	})
+}
+
+func (s *DBStorage) EventBatchEdit(
+	ctx context.Context,
+	reqs []models.EditEventReq,
+	atomic bool,
+) ([]models.BatchResult, error) {
+	return s.runBatch(ctx, len(reqs), atomic, func(ctx context.Context, tx pgx.Tx, i int) (*models.Event, error) {
+		return s.eventEdit(ctx, tx, &reqs[i])
+	})
+}
+
+func (s *DBStorage) EventBatchDelete(
+	ctx context.Context,
+	reqs []models.EventIDReq,
+	atomic bool,
+) ([]models.BatchResult, error) {
+	return s.runBatch(ctx, len(reqs), atomic, func(ctx context.Context, tx pgx.Tx, i int) (*models.Event, error) {
+		return s.eventDelete(ctx, tx, reqs[i].ID)
+	})
+}
+
+// runBatch applies n items in a single transaction. In best-effort mode each item runs in its own
+// savepoint, so a failed item is rolled back without aborting the ones around it.
+func (s *DBStorage) runBatch(
+	ctx context.Context,
+	n int,
+	atomic bool,
+	apply func(ctx context.Context, tx pgx.Tx, i int) (*models.Event, error),
+) ([]models.BatchResult, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	res := make([]models.BatchResult, n)
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		for i := 0; i < n; i++ {
+			if atomic {
+				ev, err := apply(ctx, tx, i)
+				if err != nil {
+					return &models.BatchItemError{Index: i, Err: err}
+				}
+				res[i].Event = ev
+				continue
+			}
+
+			err := pgx.BeginFunc(ctx, tx, func(sp pgx.Tx) error {
+				var err error
+				res[i].Event, err = apply(ctx, sp, i)
+				return err
+			})
+			if err != nil {
+				res[i] = models.BatchResult{Err: err}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("batch failed: " + err.Error())
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,213 @@
+package sqlstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/jackc/pgx/v5"
+)
+
+const calendarColumns = `c.id, c.owner_id, c.title, c.description,
+	array(SELECT sh.user_id::text FROM calendar_shares sh WHERE sh.calendar_id = c.id ORDER BY sh.user_id),
+	array(SELECT sh.role FROM calendar_shares sh WHERE sh.calendar_id = c.id ORDER BY sh.user_id)`
+
+func scanCalendar(row pgx.Row) (*models.Calendar, error) {
+	var c models.Calendar
+	var users, roles []string
+	if err := row.Scan(&c.ID, &c.Owner, &c.Title, &c.Description, &users, &roles); err != nil {
+		return nil, err
+	}
+	for i, user := range users {
+		c.Shares = append(c.Shares, models.CalendarShare{User: user, Role: models.CalendarRole(roles[i])})
+	}
+	return &c, nil
+}
+
+func (s *DBStorage) CalendarCreate(ctx context.Context, req *models.CreateCalendarReq) (*models.Calendar, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		INSERT INTO calendars (owner_id, title, description)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+	s.logger.Debug("SQL: " + sql)
+
+	cal := &models.Calendar{Owner: req.Owner, Title: req.Title, Description: req.Description}
+	if err := s.DB.QueryRow(ctx, sql, req.Owner, req.Title, req.Description).Scan(&cal.ID); err != nil {
+		s.logger.Error("insert calendar failed: " + err.Error())
+		return nil, fmt.Errorf("insert calendar: %w", err)
+	}
+
+	s.logger.Debug("calendar created id=" + cal.ID)
+	return cal, nil
+}
+
+func (s *DBStorage) CalendarGet(ctx context.Context, req *models.CalendarIDReq) (*models.Calendar, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	return s.calendarGet(ctx, s.DB, req.ID)
+}
+
+func (s *DBStorage) calendarGet(ctx context.Context, q querier, id string) (*models.Calendar, error) {
+	sql := `SELECT ` + calendarColumns + ` FROM calendars c WHERE c.id = $1`
+	s.logger.Debug("SQL: " + sql)
+
+	cal, err := scanCalendar(q.QueryRow(ctx, sql, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			s.logger.Warn("calendar not found id=" + id)
+			return nil, calendarErrors.NotFound(calendarErrors.ErrCalendarNotFound, id)
+		}
+		s.logger.Error("get calendar failed: " + err.Error())
+		return nil, fmt.Errorf("get calendar: %w", err)
+	}
+	return cal, nil
+}
+
+func (s *DBStorage) CalendarList(ctx context.Context, req *models.ListCalendarsReq) ([]models.Calendar, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		SELECT ` + calendarColumns + `
+		FROM calendars c
+		WHERE c.owner_id = $1
+		   OR EXISTS (SELECT 1 FROM calendar_shares sh WHERE sh.calendar_id = c.id AND sh.user_id = $1)
+		ORDER BY c.title, c.id`
+	s.logger.Debug("SQL: " + sql)
+
+	rows, err := s.DB.Query(ctx, sql, req.User)
+	if err != nil {
+		s.logger.Error("list calendars failed: " + err.Error())
+		return nil, fmt.Errorf("list calendars: %w", err)
+	}
+	defer rows.Close()
+
+	calendars := make([]models.Calendar, 0)
+	for rows.Next() {
+		cal, err := scanCalendar(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan calendar: %w", err)
+		}
+		calendars = append(calendars, *cal)
+	}
+	return calendars, rows.Err()
+}
+
+// CalendarDelete removes the calendar; its events and shares go with it through ON DELETE CASCADE.
+// The calendar is locked first, so no event can be added to it between reading and deleting them.
+func (s *DBStorage) CalendarDelete(ctx context.Context, req *models.CalendarIDReq) ([]models.Event, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var events []models.Event
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		sql := `SELECT id FROM calendars WHERE id = $1 FOR UPDATE`
+		s.logger.Debug("SQL: " + sql)
+		var id string
+		if err := tx.QueryRow(ctx, sql, req.ID).Scan(&id); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return calendarErrors.NotFound(calendarErrors.ErrCalendarNotFound, req.ID)
+			}
+			return err
+		}
+
+		sql = `SELECT ` + eventColumns + ` FROM events e WHERE e.calendar_id = $1 AND e.deleted_at IS NULL`
+		s.logger.Debug("SQL: " + sql)
+		var err error
+		if events, err = s.queryEvents(ctx, tx, sql, req.ID); err != nil {
+			return err
+		}
+
+		sql = `DELETE FROM calendars WHERE id = $1`
+		s.logger.Debug("SQL: " + sql)
+		_, err = tx.Exec(ctx, sql, req.ID)
+		return err
+	})
+	if err != nil {
+		if errors.Is(err, calendarErrors.ErrCalendarNotFound) {
+			return nil, err
+		}
+		s.logger.Error("delete calendar failed: " + err.Error())
+		return nil, fmt.Errorf("delete calendar: %w", err)
+	}
+
+	s.logger.Debug("calendar deleted id=" + req.ID)
+	return events, nil
+}
+
+func (s *DBStorage) CalendarShare(ctx context.Context, req *models.ShareCalendarReq) (*models.Calendar, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var cal *models.Calendar
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		if _, err := s.calendarGet(ctx, tx, req.CalendarID); err != nil {
+			return err
+		}
+
+		sql := `
+			INSERT INTO calendar_shares (calendar_id, user_id, role)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (calendar_id, user_id) DO UPDATE SET role = excluded.role`
+		s.logger.Debug("SQL: " + sql)
+		if _, err := tx.Exec(ctx, sql, req.CalendarID, req.User, req.Role); err != nil {
+			s.logger.Error("share calendar failed: " + err.Error())
+			return fmt.Errorf("share calendar: %w", err)
+		}
+
+		var err error
+		cal, err = s.calendarGet(ctx, tx, req.CalendarID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("calendar id=" + req.CalendarID + " shared with user=" + req.User)
+	return cal, nil
+}
+
+func (s *DBStorage) CalendarUnshare(ctx context.Context, req *models.UnshareCalendarReq) (*models.Calendar, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var cal *models.Calendar
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		sql := `DELETE FROM calendar_shares WHERE calendar_id = $1 AND user_id = $2`
+		s.logger.Debug("SQL: " + sql)
+		if _, err := tx.Exec(ctx, sql, req.CalendarID, req.User); err != nil {
+			s.logger.Error("unshare calendar failed: " + err.Error())
+			return fmt.Errorf("unshare calendar: %w", err)
+		}
+
+		var err error
+		cal, err = s.calendarGet(ctx, tx, req.CalendarID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("calendar id=" + req.CalendarID + " unshared from user=" + req.User)
+	return cal, nil
+}
+
+func (s *DBStorage) checkCalendarWrite(ctx context.Context, q querier, calendarID *string, user string) error {
+	if calendarID == nil {
+		return nil
+	}
+	cal, err := s.calendarGet(ctx, q, *calendarID)
+	if err != nil {
+		return err
+	}
+	if !cal.CanWrite(user) {
+		return calendarErrors.ErrCalendarAccessDenied
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package sqlstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const uniqueViolation = "23505"
+
+func (s *DBStorage) LabelCreate(ctx context.Context, req *models.CreateLabelReq) (*models.Label, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		INSERT INTO labels (owner_id, name, color)
+		VALUES ($1, $2, $3)
+		RETURNING id`
+	s.logger.Debug("SQL: " + sql)
+
+	label := &models.Label{Owner: req.Owner, Name: req.Name, Color: req.Color}
+	if err := s.DB.QueryRow(ctx, sql, req.Owner, req.Name, req.Color).Scan(&label.ID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, fmt.Errorf("insert label: %w", calendarErrors.ErrLabelExists)
+		}
+		s.logger.Error("insert label failed: " + err.Error())
+		return nil, fmt.Errorf("insert label: %w", err)
+	}
+
+	s.logger.Debug("label created id=" + label.ID)
+	return label, nil
+}
+
+func (s *DBStorage) LabelList(ctx context.Context, req *models.ListLabelsReq) ([]models.Label, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `SELECT id, owner_id, name, color FROM labels WHERE owner_id = $1 ORDER BY name`
+	s.logger.Debug("SQL: " + sql)
+
+	rows, err := s.DB.Query(ctx, sql, req.User)
+	if err != nil {
+		s.logger.Error("list labels failed: " + err.Error())
+		return nil, fmt.Errorf("list labels: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]models.Label, 0)
+	for rows.Next() {
+		var l models.Label
+		if err := rows.Scan(&l.ID, &l.Owner, &l.Name, &l.Color); err != nil {
+			return nil, fmt.Errorf("scan label: %w", err)
+		}
+		res = append(res, l)
+	}
+	return res, rows.Err()
+}
+
+func (s *DBStorage) OwnerList(ctx context.Context) ([]string, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `SELECT owner_id::text FROM calendars UNION SELECT owner_id::text FROM labels ORDER BY 1`
+	s.logger.Debug("SQL: " + sql)
+
+	rows, err := s.DB.Query(ctx, sql)
+	if err != nil {
+		s.logger.Error("list owners failed: " + err.Error())
+		return nil, fmt.Errorf("list owners: %w", err)
+	}
+	owners, err := pgx.CollectRows(rows, pgx.RowTo[string])
+	if err != nil {
+		return nil, fmt.Errorf("list owners: %w", err)
+	}
+	return owners, nil
+}
+
+// LabelDelete relies on the cascade of event_labels to take the label off events.
+func (s *DBStorage) LabelDelete(ctx context.Context, req *models.LabelIDReq) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `DELETE FROM labels WHERE id = $1`
+	s.logger.Debug("SQL: " + sql)
+
+	tag, err := s.DB.Exec(ctx, sql, req.ID)
+	if err != nil {
+		s.logger.Error("delete label failed: " + err.Error())
+		return fmt.Errorf("delete label: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete label: %w", calendarErrors.NotFound(calendarErrors.ErrLabelNotFound, req.ID))
+	}
+
+	s.logger.Debug("label deleted id=" + req.ID)
+	return nil
+}
+
+// replaceLabels attaches the owner's labels called names to the event instead of its current ones.
+func (s *DBStorage) replaceLabels(ctx context.Context, q querier, eventID, owner string, names []string) error {
+	if _, err := q.Exec(ctx, `DELETE FROM event_labels WHERE event_id = $1`, eventID); err != nil {
+		return fmt.Errorf("delete event labels: %w", err)
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	sql := `
+		INSERT INTO event_labels (event_id, label_id)
+		SELECT $1, l.id FROM labels l WHERE l.owner_id = $2 AND l.name = ANY($3)`
+	s.logger.Debug("SQL: " + sql)
+
+	tag, err := q.Exec(ctx, sql, eventID, owner, names)
+	if err != nil {
+		return fmt.Errorf("insert event labels: %w", err)
+	}
+	if tag.RowsAffected() != int64(len(names)) {
+		return fmt.Errorf("insert event labels: %w", calendarErrors.ErrLabelNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package sqlstorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AdvisoryLock is a session-level Postgres advisory lock. It keeps a connection of the pool
+// while held, and Postgres drops it as soon as that connection goes away.
+type AdvisoryLock struct {
+	pool *pgxpool.Pool
+	key  string
+	conn *pgxpool.Conn
+}
+
+// LeaderLock returns the advisory lock named name within the configured scheme, so tenants
+// sharing a database elect their leaders independently.
+func (s *DBStorage) LeaderLock(name string) *AdvisoryLock {
+	return &AdvisoryLock{pool: s.DB, key: s.scheme + ":" + name}
+}
+
+func (l *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	conn, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("acquire connection: %w", err)
+	}
+
+	var ok bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, l.key).Scan(&ok); err != nil {
+		conn.Release()
+		return false, fmt.Errorf("try advisory lock: %w", err)
+	}
+	if !ok {
+		conn.Release()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Check pings the connection holding the lock; while it is alive, so is the lock.
+func (l *AdvisoryLock) Check(ctx context.Context) error {
+	if l.conn == nil {
+		return errors.New("lock is not held")
+	}
+	if err := l.conn.Ping(ctx); err != nil {
+		return fmt.Errorf("lock connection: %w", err)
+	}
+	return nil
+}
+
+// Release unlocks and returns the connection. If unlocking fails the connection is closed,
+// which drops the lock on the server as well.
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	if l.conn == nil {
+		return nil
+	}
+	conn := l.conn
+	l.conn = nil
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_unlock(hashtext($1))`, l.key); err != nil {
+		_ = conn.Conn().Close(ctx)
+		return fmt.Errorf("advisory unlock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package sqlstorage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/migrations"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/database"
+	"github.com/pressly/goose/v3/lock"
+)
+
+const (
+	MigrateUp     = "up"
+	MigrateDown   = "down"
+	MigrateStatus = "status"
+)
+
+// Migrate runs a goose command against the embedded migrations. A Postgres advisory lock
+// is held for the whole run, so several replicas starting at once apply each migration once.
+// Apart from the first one, which predates configurable schemes and always creates
+// calendar.events, the migrations are unqualified and land in the configured scheme, which
+// also keeps its own goose version table.
+func (s *DBStorage) Migrate(ctx context.Context, command string, out io.Writer) error {
+	locker, err := lock.NewPostgresSessionLocker()
+	if err != nil {
+		return fmt.Errorf("create migration lock: %w", err)
+	}
+
+	if _, err := s.DB.Exec(ctx, "CREATE SCHEMA IF NOT EXISTS "+pgx.Identifier{s.scheme}.Sanitize()); err != nil {
+		return fmt.Errorf("create schema %s: %w", s.scheme, err)
+	}
+
+	store, err := database.NewStore(database.DialectPostgres, s.scheme+"."+goose.DefaultTablename)
+	if err != nil {
+		return fmt.Errorf("create migration store: %w", err)
+	}
+
+	db := stdlib.OpenDBFromPool(s.DB)
+	defer db.Close()
+
+	provider, err := goose.NewProvider("", db, migrations.FS,
+		goose.WithStore(store), goose.WithSessionLocker(locker))
+	if err != nil {
+		return fmt.Errorf("create migration provider: %w", err)
+	}
+
+	switch command {
+	case MigrateUp:
+		results, err := provider.Up(ctx)
+		for _, res := range results {
+			s.logMigration(res)
+		}
+		if err != nil {
+			return fmt.Errorf("migrate up: %w", err)
+		}
+		if len(results) == 0 {
+			s.logger.Info("migrate up: schema is up to date")
+		}
+	case MigrateDown:
+		res, err := provider.Down(ctx)
+		if res != nil {
+			s.logMigration(res)
+		}
+		if err != nil {
+			return fmt.Errorf("migrate down: %w", err)
+		}
+	case MigrateStatus:
+		statuses, err := provider.Status(ctx)
+		if err != nil {
+			return fmt.Errorf("migrate status: %w", err)
+		}
+		return printMigrationStatus(out, statuses)
+	default:
+		return fmt.Errorf("unknown migrate command %q, want %s, %s or %s",
+			command, MigrateUp, MigrateDown, MigrateStatus)
+	}
+	return nil
+}
+
+func (s *DBStorage) logMigration(res *goose.MigrationResult) {
+	if res.Error != nil {
+		s.logger.Error(fmt.Sprintf("migration %s %s failed: %v", res.Direction, res.Source.Path, res.Error))
+		return
+	}
+	s.logger.Info(fmt.Sprintf("migration %s %s applied in %s", res.Direction, res.Source.Path, res.Duration))
+}
+
+func printMigrationStatus(out io.Writer, statuses []*goose.MigrationStatus) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tMIGRATION\tSTATE\tAPPLIED AT")
+	for _, st := range statuses {
+		appliedAt := "-"
+		if !st.AppliedAt.IsZero() {
+			appliedAt = st.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", st.Source.Version, st.Source.Path, st.State, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,28 @@
+package sqlstorage
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/migrations"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/pressly/goose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbeddedMigrations(t *testing.T) {
+	// sql.Open does not connect, so the provider only parses the embedded files here.
+	db, err := sql.Open("pgx", "postgres://localhost/unused")
+	require.NoError(t, err)
+	defer db.Close()
+
+	provider, err := goose.NewProvider(goose.DialectPostgres, db, migrations.FS)
+	require.NoError(t, err)
+
+	sources := provider.ListSources()
+	require.NotEmpty(t, sources)
+	for i := 1; i < len(sources); i++ {
+		assert.Less(t, sources[i-1].Version, sources[i].Version)
+	}
+}
@@ -0,0 +1,95 @@
+package sqlstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+// expiredEvents selects the events of an ExpireEventsReq, see expiredArgs for the parameters.
+const expiredEvents = `
+	FROM events e
+	WHERE %s < $1
+	  AND (cardinality($2::uuid[]) = 0 OR e.user_id = ANY($2::uuid[]))
+	  AND NOT (e.user_id = ANY($3::uuid[]))`
+
+// archiveEvents copies the doomed events, with their attendees and label names, to the archive.
+const archiveEvents = `,
+	archived AS (
+		INSERT INTO events_archive (id, user_id, end_time, deleted_at, event)
+		SELECT e.id, e.user_id, e.end_time, e.deleted_at,
+		       (to_jsonb(e) - 'search_vector') || jsonb_build_object(
+		           'attendees', (SELECT coalesce(jsonb_agg(jsonb_build_object('user_id', a.user_id, 'status', a.status)),
+		                                         '[]'::jsonb)
+		                         FROM event_attendees a WHERE a.event_id = e.id),
+		           'labels', (SELECT coalesce(jsonb_agg(l.name), '[]'::jsonb)
+		                      FROM event_labels el JOIN labels l ON l.id = el.label_id
+		                      WHERE el.event_id = e.id))
+		FROM events e JOIN doomed d ON d.id = e.id
+		ON CONFLICT (id) DO UPDATE
+		    SET event = excluded.event, deleted_at = excluded.deleted_at, archived_at = excluded.archived_at
+	)`
+
+func expiredFilter(req *models.ExpireEventsReq) string {
+	column := "e.deleted_at IS NULL AND e.end_time"
+	if req.Trash {
+		column = "e.deleted_at"
+	}
+	return fmt.Sprintf(expiredEvents, column)
+}
+
+func expiredArgs(req *models.ExpireEventsReq) []any {
+	// A NULL array would make both user conditions NULL, so missing lists are sent empty.
+	users, except := req.Users, req.ExceptUsers
+	if users == nil {
+		users = []string{}
+	}
+	if except == nil {
+		except = []string{}
+	}
+	return []any{req.Before, users, except}
+}
+
+// ExpireEvents removes one batch in a statement of its own, so the rows stay locked only briefly.
+// Rows locked by other transactions are skipped until the next batch.
+func (s *DBStorage) ExpireEvents(ctx context.Context, req *models.ExpireEventsReq) (int, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var limit *int
+	if req.Limit > 0 {
+		limit = &req.Limit
+	}
+
+	archive := ""
+	if req.Archive {
+		archive = archiveEvents
+	}
+	sql := `
+		WITH doomed AS (
+			SELECT e.id` + expiredFilter(req) + `
+			ORDER BY e.id
+			LIMIT $4
+			FOR UPDATE SKIP LOCKED
+		)` + archive + `
+		DELETE FROM events e USING doomed d WHERE e.id = d.id`
+	s.logger.Debug("SQL: " + sql)
+
+	tag, err := s.DB.Exec(ctx, sql, append(expiredArgs(req), limit)...)
+	if err != nil {
+		return 0, fmt.Errorf("expire events: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func (s *DBStorage) CountExpiredEvents(ctx context.Context, req *models.ExpireEventsReq) (int, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var n int
+	if err := s.DB.QueryRow(ctx, `SELECT count(*)`+expiredFilter(req), expiredArgs(req)...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("count expired events: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,51 @@
+package sqlstorage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+func (s *DBStorage) EventSearch(ctx context.Context, req *models.SearchEventsReq) ([]models.SearchHit, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	// websearch_to_tsquery accepts arbitrary user input: words are ANDed, quotes, "-" and "or" work as in
+	// web search. The memory storage parses the same syntax, see parseQuery.
+	query := `
+		SELECT ` + eventColumns + `, ts_rank(e.search_vector, q) AS rank
+		FROM events e, websearch_to_tsquery('simple', $1) q
+		WHERE e.search_vector @@ q
+		  AND e.deleted_at IS NULL
+		  AND (e.user_id = $2::uuid
+		       OR EXISTS (SELECT 1 FROM event_attendees a WHERE a.event_id = e.id AND a.user_id = $2::uuid))
+		  AND ($3::timestamptz IS NULL OR e.end_time >= $3)
+		  AND ($4::timestamptz IS NULL OR e.start_time <= $4)
+		ORDER BY rank DESC, e.start_time
+		LIMIT $5`
+	s.logger.Debug("SQL: " + query)
+
+	var limit *int
+	if req.Limit > 0 {
+		limit = &req.Limit
+	}
+
+	rows, err := s.DB.Query(ctx, query, req.Query, req.User, req.Start, req.End, limit)
+	if err != nil {
+		s.logger.Error("search query failed: " + err.Error())
+		return nil, fmt.Errorf("search events: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]models.SearchHit, 0)
+	for rows.Next() {
+		var rank float32
+		e, err := scanEvent(rows, &rank)
+		if err != nil {
+			return nil, fmt.Errorf("scan search hit: %w", err)
+		}
+		res = append(res, models.SearchHit{Event: *e, Rank: float64(rank)})
+	}
+	return res, rows.Err()
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
@@ -11,6 +14,7 @@ import (
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
@@ -25,11 +29,54 @@ type DBConfig struct {
 }
 
 type DBStorage struct {
-	DB     *pgxpool.Pool
-	logger logger.Logger
+	DB      *pgxpool.Pool
+	logger  logger.Logger
+	scheme  string
+	timeout time.Duration
 }
 
 func NewStorage(ctx context.Context, cfg *configuration.DatabaseConf, logger logger.Logger) (*DBStorage, error) {
+	poolCfg, err := poolConfig(cfg)
+	if err != nil {
+		logger.Error("invalid db config: " + err.Error())
+		return nil, fmt.Errorf("parse db config: %w", err)
+	}
+
+	dbPool, err := pgxpool.NewWithConfig(ctx, poolCfg)
+	if err != nil {
+		logger.Error("failed to create db pool: " + err.Error())
+		return nil, fmt.Errorf("create db pool: %w", err)
+	}
+
+	storage := &DBStorage{
+		DB:      dbPool,
+		logger:  logger,
+		scheme:  cfg.Scheme,
+		timeout: time.Duration(cfg.Timeout) * time.Second,
+	}
+
+	pingCtx, cancel := storage.withTimeout(ctx)
+	defer cancel()
+	if err := dbPool.Ping(pingCtx); err != nil {
+		dbPool.Close()
+		logger.Error("failed to ping db: " + err.Error())
+		return nil, fmt.Errorf("ping db: %w", err)
+	}
+
+	logger.Debug("connected to database, schema " + cfg.Scheme)
+
+	if cfg.AutoMigrate {
+		if err := storage.Migrate(ctx, MigrateUp, io.Discard); err != nil {
+			dbPool.Close()
+			return nil, err
+		}
+	}
+	return storage, nil
+}
+
+// poolConfig builds the pool settings. Every connection gets search_path set to the configured
+// scheme, so the queries below stay unqualified and several schemas can live in one database.
+func poolConfig(cfg *configuration.DatabaseConf) (*pgxpool.Config, error) {
 	connStr := fmt.Sprintf(
 		"user=%s password=%s host=%s port=%d dbname=%s sslmode=%s",
 		cfg.User,
@@ -40,110 +87,209 @@ func NewStorage(ctx context.Context, cfg *configuration.DatabaseConf, logger log
 		cfg.SSLMode,
 	)
 
-	dbPool, err := pgxpool.New(ctx, connStr)
+	poolCfg, err := pgxpool.ParseConfig(connStr)
 	if err != nil {
-		logger.Error("failed to create db pool: " + err.Error())
-		return nil, fmt.Errorf("create db pool: %w", err)
+		return nil, err
 	}
 
-	if err := dbPool.Ping(ctx); err != nil {
-		dbPool.Close()
-		logger.Error("failed to ping db: " + err.Error())
-		return nil, fmt.Errorf("ping db: %w", err)
+	poolCfg.ConnConfig.RuntimeParams["search_path"] = pgx.Identifier{cfg.Scheme}.Sanitize() + ", public"
+	if cfg.Timeout > 0 {
+		poolCfg.ConnConfig.ConnectTimeout = time.Duration(cfg.Timeout) * time.Second
+	}
+	if cfg.Pool.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.Pool.MaxConns
+	}
+	if cfg.Pool.MinConns > 0 {
+		poolCfg.MinConns = cfg.Pool.MinConns
+	}
+	if cfg.Pool.MaxConnIdleTime > 0 {
+		poolCfg.MaxConnIdleTime = cfg.Pool.MaxConnIdleTime
+	}
+	if cfg.Pool.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.Pool.MaxConnLifetime
+	}
+	if cfg.Pool.HealthCheckPeriod > 0 {
+		poolCfg.HealthCheckPeriod = cfg.Pool.HealthCheckPeriod
 	}
+	return poolCfg, nil
+}
+
+// withTimeout bounds a single query by the configured timeout; zero means no limit.
+func (s *DBStorage) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if s.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, s.timeout)
+}
 
-	logger.Debug("connected to database")
-	return &DBStorage{DB: dbPool, logger: logger}, nil
+// querier is satisfied by both the pool and a transaction, so helpers can run either way.
+type querier interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// eventColumns selects an event aliased as e together with its attendees and labels.
+const eventColumns = `e.id, e.title, e.start_time, e.end_time, e.description, e.user_id, e.notify_before, e.calendar_id,
+	e.deleted_at, e.time_zone, e.all_day, e.location, e.meeting_url,
+	array(SELECT a.user_id::text FROM event_attendees a WHERE a.event_id = e.id ORDER BY a.user_id),
+	array(SELECT a.status FROM event_attendees a WHERE a.event_id = e.id ORDER BY a.user_id),
+	array(SELECT l.name FROM event_labels el JOIN labels l ON l.id = el.label_id
+	      WHERE el.event_id = e.id ORDER BY l.name)`
+
+// scanEvent reads the eventColumns of row followed by any extra selected columns.
+func scanEvent(row pgx.Row, extra ...any) (*models.Event, error) {
+	var e models.Event
+	var notify pgtype.Interval
+	var attendees, statuses []string
+	dest := append([]any{
+		&e.ID, &e.Title, &e.Date, &e.EndTime, &e.Description, &e.User, &notify, &e.CalendarID, &e.DeletedAt,
+		&e.TimeZone, &e.AllDay, &e.Location, &e.MeetingURL, &attendees, &statuses, &e.Labels,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	if len(e.Labels) == 0 {
+		e.Labels = nil
+	}
+	e.NotifyBefore = IntervalToDurationString(notify)
+	for i, user := range attendees {
+		e.Attendees = append(e.Attendees, models.Attendee{User: user, Status: models.AttendeeStatus(statuses[i])})
+	}
+	return &e, nil
 }
 
 func (s *DBStorage) EventCreate(ctx context.Context, req *models.CreateEventReq) (*models.Event, error) {
-	checkSQL := `
-		SELECT EXISTS (
-			SELECT 1 FROM calendar.events
-			WHERE user_id = $1
-			  AND tstzrange(start_time, end_time) && tstzrange($2::timestamptz, $3::timestamptz)
-		)`
-	s.logger.Debug("SQL: " + checkSQL)
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var event *models.Event
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		var err error
+		event, err = s.eventCreate(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	var exists bool
-	if err := s.DB.QueryRow(ctx, checkSQL, req.User, req.Date, req.EndTime).Scan(&exists); err != nil {
-		s.logger.Error("check overlap failed: " + err.Error())
-		return nil, fmt.Errorf("check overlap: %w", err)
+	s.logger.Debug("event created id=" + event.ID)
+	return event, nil
+}
+
+func (s *DBStorage) eventCreate(ctx context.Context, tx pgx.Tx, req *models.CreateEventReq) (*models.Event, error) {
+	event := &models.Event{
+		ID:           req.ID,
+		Title:        req.Title,
+		Date:         req.Date,
+		EndTime:      req.EndTime,
+		Description:  req.Description,
+		User:         req.User,
+		NotifyBefore: req.NotifyBefore,
+		CalendarID:   req.CalendarID,
+		Attendees:    models.MergeAttendees(nil, req.Attendees),
+		TimeZone:     req.TimeZone,
+		AllDay:       req.AllDay,
+		Labels:       models.NormalizeLabels(req.Labels),
+		Location:     req.Location,
+		MeetingURL:   req.MeetingURL,
 	}
-	if exists {
-		s.logger.Error("conflict: user=" + req.User + " date=" +
-			req.Date.Format(time.RFC822Z) + " end=" + req.EndTime.Format(time.RFC822Z))
-		return nil, fmt.Errorf("event conflict: %w", calendarErrors.ErrDateBusy)
+	if err := event.Normalize(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkCalendarWrite(ctx, tx, event.CalendarID, event.User); err != nil {
+		return nil, err
+	}
+	if err := s.checkBusy(ctx, tx, event.BusyUsers(), event.Date, event.EndTime, nil); err != nil {
+		return nil, err
 	}
 
 	insertSQL := `
-		INSERT INTO calendar.events (title, start_time, end_time, description, user_id, notify_before)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO events (id, title, start_time, end_time, description, user_id, notify_before, calendar_id,
+		                    time_zone, all_day, location, meeting_url)
+		VALUES (COALESCE(NULLIF($1, '')::uuid, gen_random_uuid()), $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
 		RETURNING id`
 	s.logger.Debug("SQL: " + insertSQL)
 
-	var id string
-	if err := s.DB.QueryRow(
+	if err := tx.QueryRow(
 		ctx,
 		insertSQL,
-		req.Title,
-		req.Date,
-		req.EndTime,
-		req.Description,
-		req.User,
-		req.NotifyBefore,
-	).Scan(&id); err != nil {
+		event.ID,
+		event.Title,
+		event.Date,
+		event.EndTime,
+		event.Description,
+		event.User,
+		event.NotifyBefore,
+		event.CalendarID,
+		event.TimeZone,
+		event.AllDay,
+		event.Location,
+		event.MeetingURL,
+	).Scan(&event.ID); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+			return nil, fmt.Errorf("insert event: %w", calendarErrors.ErrEventExists)
+		}
 		s.logger.Error("insert failed: " + err.Error())
 		return nil, fmt.Errorf("insert event: %w", err)
 	}
 
-	s.logger.Debug("event created id=" + id)
-	return &models.Event{
-		ID:           id,
-		Title:        req.Title,
-		Date:         req.Date,
-		EndTime:      req.EndTime,
-		Description:  req.Description,
-		User:         req.User,
-		NotifyBefore: req.NotifyBefore,
-	}, nil
+	if err := s.replaceAttendees(ctx, tx, event.ID, event.Attendees); err != nil {
+		return nil, err
+	}
+	if err := s.replaceLabels(ctx, tx, event.ID, event.User, event.Labels); err != nil {
+		return nil, err
+	}
+	return event, nil
 }
 
 func (s *DBStorage) EventEdit(ctx context.Context, req *models.EditEventReq) (*models.Event, error) {
-	event, err := s.EventGet(ctx, &models.EventIDReq{ID: req.ID})
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var event *models.Event
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		var err error
+		event, err = s.eventEdit(ctx, tx, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("event edited id=" + event.ID)
+	return event, nil
+}
+
+func (s *DBStorage) eventEdit(ctx context.Context, tx pgx.Tx, req *models.EditEventReq) (*models.Event, error) {
+	event, err := s.eventGet(ctx, tx, req.ID)
 	if err != nil {
 		s.logger.Error("edit get failed: " + err.Error())
 		return nil, fmt.Errorf("get event for edit: %w", err)
 	}
 
 	event = checkRequest(req, event)
+	if err := event.Normalize(); err != nil {
+		return nil, err
+	}
 
-	checkSQL := `
-		SELECT EXISTS (
-			SELECT 1 FROM calendar.events
-			WHERE user_id = $1
-			  AND tstzrange(start_time, end_time) && tstzrange($2::timestamptz, $3::timestamptz)
-			  AND id <> $4
-		)`
-	s.logger.Debug("SQL: " + checkSQL)
-
-	var exists bool
-	if err := s.DB.QueryRow(ctx, checkSQL, event.User, event.Date, event.EndTime, event.ID).Scan(&exists); err != nil {
-		s.logger.Error("edit overlap check failed: " + err.Error())
-		return nil, fmt.Errorf("check overlap: %w", err)
+	if err := s.checkCalendarWrite(ctx, tx, event.CalendarID, event.User); err != nil {
+		return nil, err
 	}
-	if exists {
-		s.logger.Error("conflict on edit: id=" + event.ID)
-		return nil, fmt.Errorf("event conflict: %w", calendarErrors.ErrDateBusy)
+	if err := s.checkBusy(ctx, tx, event.BusyUsers(), event.Date, event.EndTime, &event.ID); err != nil {
+		return nil, err
 	}
 
 	updateSQL := `
-		UPDATE calendar.events
-		SET title = $1, start_time = $2, end_time = $3, description = $4, user_id = $5, notify_before = $6
-		WHERE id = $7`
+		UPDATE events
+		SET title = $1, start_time = $2, end_time = $3, description = $4, user_id = $5, notify_before = $6,
+		    calendar_id = $7, time_zone = $8, all_day = $9, location = $10, meeting_url = $11
+		WHERE id = $12`
 	s.logger.Debug("SQL: " + updateSQL)
 
-	if _, err := s.DB.Exec(
+	if _, err := tx.Exec(
 		ctx,
 		updateSQL,
 		event.Title,
@@ -152,13 +298,28 @@ func (s *DBStorage) EventEdit(ctx context.Context, req *models.EditEventReq) (*m
 		event.Description,
 		event.User,
 		event.NotifyBefore,
+		event.CalendarID,
+		event.TimeZone,
+		event.AllDay,
+		event.Location,
+		event.MeetingURL,
 		event.ID,
 	); err != nil {
 		s.logger.Error("edit update failed: " + err.Error())
 		return nil, fmt.Errorf("update event: %w", err)
 	}
 
-	s.logger.Debug("event edited id=" + event.ID)
+	if req.Attendees != nil {
+		if err := s.replaceAttendees(ctx, tx, event.ID, event.Attendees); err != nil {
+			return nil, err
+		}
+	}
+	// Labels belong to the owner, so a new owner has to have them in their catalogue too.
+	if req.Labels != nil || req.User != nil {
+		if err := s.replaceLabels(ctx, tx, event.ID, event.User, event.Labels); err != nil {
+			return nil, err
+		}
+	}
 	return event, nil
 }
 
@@ -181,100 +342,292 @@ func checkRequest(req *models.EditEventReq, event *models.Event) *models.Event {
 	if req.NotifyBefore != nil {
 		event.NotifyBefore = req.NotifyBefore
 	}
+	if req.CalendarID != nil {
+		event.CalendarID = req.CalendarID
+	}
+	if req.Location != nil {
+		event.Location = req.Location
+	}
+	if req.MeetingURL != nil {
+		event.MeetingURL = req.MeetingURL
+	}
+	if req.Attendees != nil {
+		event.Attendees = models.MergeAttendees(event.Attendees, *req.Attendees)
+	}
+	if req.TimeZone != nil {
+		event.TimeZone = *req.TimeZone
+	}
+	if req.AllDay != nil {
+		event.AllDay = *req.AllDay
+	}
+	if req.Labels != nil {
+		event.Labels = models.NormalizeLabels(*req.Labels)
+	}
 	return event
 }
 
 func (s *DBStorage) EventDelete(ctx context.Context, req *models.EventIDReq) error {
-	sql := `DELETE FROM calendar.events WHERE id = $1`
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if _, err := s.eventDelete(ctx, s.DB, req.ID); err != nil {
+		return err
+	}
+
+	s.logger.Debug("event moved to trash id=" + req.ID)
+	return nil
+}
+
+// eventDelete moves the event to the trash and returns it as it is there.
+func (s *DBStorage) eventDelete(ctx context.Context, q querier, id string) (*models.Event, error) {
+	sql := `UPDATE events e SET deleted_at = now() WHERE e.id = $1 AND e.deleted_at IS NULL RETURNING ` + eventColumns
 	s.logger.Debug("SQL: " + sql)
 
-	if _, err := s.DB.Exec(ctx, sql, req.ID); err != nil {
+	event, err := scanEvent(q.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("delete event: %w", calendarErrors.NotFound(calendarErrors.ErrEventNotFound, id))
+	}
+	if err != nil {
 		s.logger.Error("delete failed: " + err.Error())
-		return fmt.Errorf("delete event: %w", err)
+		return nil, fmt.Errorf("delete event: %w", err)
+	}
+	return event, nil
+}
+
+func (s *DBStorage) EventListDeleted(ctx context.Context, req *models.ListDeletedEventsReq) ([]models.Event, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.deleted_at IS NOT NULL AND ($1::uuid IS NULL OR e.user_id = $1::uuid)
+		ORDER BY e.deleted_at DESC`
+	s.logger.Debug("SQL: " + query)
+
+	events, err := s.queryEvents(ctx, s.DB, query, req.User)
+	if err != nil {
+		s.logger.Error("deleted list query failed: " + err.Error())
+		return nil, fmt.Errorf("list deleted events: %w", err)
 	}
+	return events, nil
+}
 
-	s.logger.Debug("event deleted id=" + req.ID)
-	return nil
+func (s *DBStorage) EventRestore(ctx context.Context, req *models.EventIDReq) (*models.Event, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var event *models.Event
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		sql := `SELECT ` + eventColumns + ` FROM events e WHERE e.id = $1 AND e.deleted_at IS NOT NULL FOR UPDATE`
+		s.logger.Debug("SQL: " + sql)
+
+		var err error
+		event, err = scanEvent(tx.QueryRow(ctx, sql, req.ID))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return fmt.Errorf("restore event: %w", calendarErrors.NotFound(calendarErrors.ErrEventNotFound, req.ID))
+			}
+			return fmt.Errorf("get deleted event: %w", err)
+		}
+
+		if err := s.checkBusy(ctx, tx, event.BusyUsers(), event.Date, event.EndTime, &event.ID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE events SET deleted_at = NULL WHERE id = $1`, event.ID); err != nil {
+			return fmt.Errorf("restore event: %w", err)
+		}
+		event.DeletedAt = nil
+		return nil
+	})
+	if err != nil {
+		s.logger.Error("restore failed: " + err.Error())
+		return nil, err
+	}
+
+	s.logger.Debug("event restored id=" + event.ID)
+	return event, nil
 }
 
 func (s *DBStorage) EventGet(ctx context.Context, req *models.EventIDReq) (*models.Event, error) {
-	sql := `
-		SELECT id, title, start_time, end_time, description, user_id, notify_before
-		FROM calendar.events
-		WHERE id = $1`
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	e, err := s.eventGet(ctx, s.DB, req.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Debug("event fetched id=" + req.ID)
+	return e, nil
+}
+
+func (s *DBStorage) eventGet(ctx context.Context, q querier, id string) (*models.Event, error) {
+	sql := `SELECT ` + eventColumns + ` FROM events e WHERE e.id = $1 AND e.deleted_at IS NULL`
 	s.logger.Debug("SQL: " + sql)
 
-	var e models.Event
-	var notify pgtype.Interval
-	if err := s.DB.QueryRow(ctx, sql, req.ID).Scan(
-		&e.ID, &e.Title, &e.Date, &e.EndTime, &e.Description, &e.User, &notify,
-	); err != nil {
+	e, err := scanEvent(q.QueryRow(ctx, sql, id))
+	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			s.logger.Warn("event not found id=" + req.ID)
-			return nil, calendarErrors.ErrEventNotFound
+			s.logger.Warn("event not found id=" + id)
+			return nil, calendarErrors.NotFound(calendarErrors.ErrEventNotFound, id)
 		}
 		s.logger.Error("get failed: " + err.Error())
 		return nil, fmt.Errorf("get event: %w", err)
 	}
-	e.NotifyBefore = IntervalToDurationString(notify)
-	s.logger.Debug("event fetched id=" + req.ID)
-	return &e, nil
+	return e, nil
 }
 
 func (s *DBStorage) EventGetList(ctx context.Context, req *models.GetEventListReq) (*models.GetEventListResp, error) {
-	query := `SELECT id, title, start_time, end_time, description, user_id, notify_before
-              FROM calendar.events WHERE 1=1`
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT ` + eventColumns + `
+              FROM events e WHERE e.deleted_at IS NULL`
 	args := []interface{}{}
 	argID := 1
 
 	if req.Start != nil {
-		query += fmt.Sprintf(" AND end_time >= $%d", argID)
+		query += fmt.Sprintf(" AND e.end_time >= $%d", argID)
 		args = append(args, *req.Start)
 		argID++
 	}
 
 	if req.End != nil {
-		query += fmt.Sprintf(" AND start_time <= $%d", argID)
+		query += fmt.Sprintf(" AND e.start_time <= $%d", argID)
 		args = append(args, *req.End)
+		argID++
+	}
+
+	if len(req.Labels) > 0 {
+		query += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM event_labels el JOIN labels l ON l.id = el.label_id
+			WHERE el.event_id = e.id AND l.name = ANY($%d))`, argID)
+		args = append(args, req.Labels)
+		argID++
+	}
+
+	if req.User != nil {
+		query += fmt.Sprintf(` AND (e.user_id = $%[1]d::uuid OR EXISTS (
+			SELECT 1 FROM event_attendees a WHERE a.event_id = e.id AND a.user_id = $%[1]d::uuid))`, argID)
+		args = append(args, *req.User)
 	}
 
-	query += " ORDER BY start_time"
+	query += " ORDER BY e.start_time"
 	s.logger.Debug("SQL: " + query)
 
-	rows, err := s.DB.Query(ctx, query, args...)
+	events, err := s.queryEvents(ctx, s.DB, query, args...)
 	if err != nil {
 		s.logger.Error("list query failed: " + err.Error())
 		return nil, fmt.Errorf("get event list: %w", err)
 	}
-	defer rows.Close()
 
-	var events []models.Event
-	for rows.Next() {
-		var e models.Event
-		var notify pgtype.Interval
-		err := rows.Scan(&e.ID, &e.Title, &e.Date, &e.EndTime, &e.Description, &e.User, &notify)
+	return &models.GetEventListResp{Data: events}, nil
+}
+
+// scanPageSize is how many events EventScan reads per query.
+const scanPageSize = 500
+
+// EventScan pages through the events by ID, so no query or transaction stays open while fn runs.
+func (s *DBStorage) EventScan(ctx context.Context, fn func(models.Event) error) error {
+	query := `SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.deleted_at IS NULL AND ($1::uuid IS NULL OR e.id > $1::uuid)
+		ORDER BY e.id
+		LIMIT $2`
+	s.logger.Debug("SQL: " + query)
+
+	var after *string
+	for {
+		page, err := s.scanPage(ctx, query, after)
 		if err != nil {
-			s.logger.Error("scan failed: " + err.Error())
-			return nil, fmt.Errorf("scan event: %w", err)
+			s.logger.Error("scan query failed: " + err.Error())
+			return fmt.Errorf("scan events: %w", err)
 		}
-		e.NotifyBefore = IntervalToDurationString(notify)
-		events = append(events, e)
+		for _, e := range page {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		if len(page) < scanPageSize {
+			return nil
+		}
+		after = &page[len(page)-1].ID
 	}
+}
 
-	return &models.GetEventListResp{Data: events}, nil
+func (s *DBStorage) scanPage(ctx context.Context, query string, after *string) ([]models.Event, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+	return s.queryEvents(ctx, s.DB, query, after, scanPageSize)
 }
 
+// notifyAt is the reminder time of event e, computed on the wall clock of its zone like models.Event.NotifyAt.
+const notifyAt = `((e.start_time AT TIME ZONE e.time_zone) - e.notify_before) AT TIME ZONE e.time_zone`
+
 func (s *DBStorage) EventsToNotify(ctx context.Context) ([]models.Event, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
 	query := `
-		SELECT id, title, start_time, end_time, description, user_id, notify_before
-		FROM calendar.events
-		WHERE notify_before IS NOT NULL
-		AND start_time - notify_before <= $1
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.notify_before IS NOT NULL
+		AND e.deleted_at IS NULL
+		AND e.start_time > $1
+		AND ` + notifyAt + ` <= $1
 	`
 
 	now := time.Now()
 	s.logger.Debug(fmt.Sprintf("SQL: %v%v", query, now))
-	rows, err := s.DB.Query(ctx, query, now)
+	events, err := s.queryEvents(ctx, s.DB, query, now)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.Debug(fmt.Sprintf("events: %v", events))
+	return events, nil
+}
+
+func (s *DBStorage) UpcomingReminders(ctx context.Context, now, until time.Time) ([]models.Event, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+		SELECT ` + eventColumns + `
+		FROM events e
+		WHERE e.notify_before IS NOT NULL
+		AND e.deleted_at IS NULL
+		AND e.start_time > $1
+		AND ` + notifyAt + ` < $2
+		AND NOT EXISTS (
+			SELECT 1 FROM sent_reminders r WHERE r.event_id = e.id AND r.notify_at = ` + notifyAt + `
+		)
+		ORDER BY ` + notifyAt
+	s.logger.Debug("SQL: " + query)
+
+	events, err := s.queryEvents(ctx, s.DB, query, now, until)
+	if err != nil {
+		s.logger.Error("reminders query failed: " + err.Error())
+		return nil, fmt.Errorf("upcoming reminders: %w", err)
+	}
+	return events, nil
+}
+
+func (s *DBStorage) MarkReminderSent(ctx context.Context, eventID string, notifyAt time.Time) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	_, err := s.DB.Exec(ctx, `
+		INSERT INTO sent_reminders (event_id, notify_at) VALUES ($1, $2)
+		ON CONFLICT (event_id) DO UPDATE SET notify_at = excluded.notify_at`,
+		eventID, notifyAt)
+	if err != nil {
+		return fmt.Errorf("mark reminder sent: %w", err)
+	}
+	return nil
+}
+
+func (s *DBStorage) queryEvents(ctx context.Context, q querier, query string, args ...any) ([]models.Event, error) {
+	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -282,25 +635,130 @@ func (s *DBStorage) EventsToNotify(ctx context.Context) ([]models.Event, error)
 
 	var events []models.Event
 	for rows.Next() {
-		var ev models.Event
-		var notify pgtype.Interval
-		err := rows.Scan(&ev.ID, &ev.Title, &ev.Date, &ev.EndTime, &ev.Description, &ev.User, &notify)
+		e, err := scanEvent(rows)
 		if err != nil {
-			return nil, err
+			s.logger.Error("scan failed: " + err.Error())
+			return nil, fmt.Errorf("scan event: %w", err)
 		}
-		ev.NotifyBefore = IntervalToDurationString(notify)
-		events = append(events, ev)
+		events = append(events, *e)
 	}
-	s.logger.Debug(fmt.Sprintf("events: %v", events))
 	return events, rows.Err()
 }
 
-func (s *DBStorage) DeleteOldEvents(ctx context.Context, cutoff time.Time) error {
-	_, err := s.DB.Exec(ctx, `
-		DELETE FROM calendar.events
-		WHERE end_time < $1
-	`, cutoff)
-	return err
+func (s *DBStorage) EventRespond(ctx context.Context, req *models.RespondEventReq) (*models.Event, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var event *models.Event
+	err := pgx.BeginFunc(ctx, s.DB, func(tx pgx.Tx) error {
+		var err error
+		event, err = s.eventGet(ctx, tx, req.EventID)
+		if err != nil {
+			return err
+		}
+
+		idx := slices.IndexFunc(event.Attendees, func(a models.Attendee) bool { return a.User == req.User })
+		if idx < 0 {
+			return calendarErrors.NotFound(calendarErrors.ErrAttendeeNotFound, req.User)
+		}
+
+		wasDeclined := event.Attendees[idx].Status == models.AttendeeDeclined
+		event.Attendees[idx].Status = req.Status
+		if wasDeclined && req.Status != models.AttendeeDeclined {
+			if err := s.checkBusy(ctx, tx, []string{req.User}, event.Date, event.EndTime, &event.ID); err != nil {
+				return err
+			}
+		}
+
+		sql := `UPDATE event_attendees SET status = $3 WHERE event_id = $1 AND user_id = $2`
+		s.logger.Debug("SQL: " + sql)
+		if _, err := tx.Exec(ctx, sql, req.EventID, req.User, req.Status); err != nil {
+			s.logger.Error("respond update failed: " + err.Error())
+			return fmt.Errorf("update attendee: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Debug("event response saved id=" + req.EventID + " user=" + req.User)
+	return event, nil
+}
+
+// checkBusy fails with a ConflictError naming the first event that any of users owns or attends
+// (without declining) and that overlaps [start, end). The users are locked for the rest of the
+// transaction first, so two concurrent writers cannot both pass the check.
+func (s *DBStorage) checkBusy(
+	ctx context.Context,
+	tx pgx.Tx,
+	users []string,
+	start, end time.Time,
+	excludeID *string,
+) error {
+	sorted := slices.Clone(users)
+	slices.Sort(sorted)
+	for _, u := range sorted {
+		if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, u); err != nil {
+			return fmt.Errorf("lock user: %w", err)
+		}
+	}
+
+	checkSQL := `
+		SELECT e.id FROM events e
+		WHERE tstzrange(e.start_time, e.end_time) && tstzrange($2::timestamptz, $3::timestamptz)
+		  AND e.deleted_at IS NULL
+		  AND ($4::uuid IS NULL OR e.id <> $4::uuid)
+		  AND (e.user_id = ANY($1::uuid[])
+		       OR EXISTS (SELECT 1 FROM event_attendees a
+		                  WHERE a.event_id = e.id AND a.user_id = ANY($1::uuid[]) AND a.status <> 'declined'))
+		ORDER BY e.start_time
+		LIMIT 1`
+	s.logger.Debug("SQL: " + checkSQL)
+
+	var conflictID string
+	err := tx.QueryRow(ctx, checkSQL, users, start, end, excludeID).Scan(&conflictID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		s.logger.Error("check overlap failed: " + err.Error())
+		return fmt.Errorf("check overlap: %w", err)
+	}
+	s.logger.Error("conflict: users=" + strings.Join(users, ",") + " date=" +
+		start.Format(time.RFC822Z) + " end=" + end.Format(time.RFC822Z) + " event=" + conflictID)
+	return fmt.Errorf("event conflict: %w", &calendarErrors.ConflictError{EventID: conflictID})
+}
+
+func (s *DBStorage) replaceAttendees(
+	ctx context.Context,
+	q querier,
+	eventID string,
+	attendees []models.Attendee,
+) error {
+	if _, err := q.Exec(ctx, `DELETE FROM event_attendees WHERE event_id = $1`, eventID); err != nil {
+		return fmt.Errorf("delete attendees: %w", err)
+	}
+	if len(attendees) == 0 {
+		return nil
+	}
+
+	users := make([]string, 0, len(attendees))
+	statuses := make([]string, 0, len(attendees))
+	for _, a := range attendees {
+		users = append(users, a.User)
+		statuses = append(statuses, string(a.Status))
+	}
+
+	sql := `
+		INSERT INTO event_attendees (event_id, user_id, status)
+		SELECT $1, u, st FROM unnest($2::uuid[], $3::text[]) AS t(u, st)`
+	s.logger.Debug("SQL: " + sql)
+	if _, err := q.Exec(ctx, sql, eventID, users, statuses); err != nil {
+		s.logger.Error("insert attendees failed: " + err.Error())
+		return fmt.Errorf("insert attendees: %w", err)
+	}
+	return nil
 }
 
 func IntervalToDurationString(iv pgtype.Interval) *string {
@@ -0,0 +1,40 @@
+package sqlstorage
+
+import (
+	"context"
+	"fmt"
+)
+
+// eventsChannel is notified by a trigger on every statement changing events, with the schema as payload.
+const eventsChannel = "calendar_events"
+
+// WatchEvents calls changed after every committed change to the events of the configured scheme.
+// It holds a connection of the pool until ctx is done or the connection fails.
+func (s *DBStorage) WatchEvents(ctx context.Context, changed func()) error {
+	conn, err := s.DB.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("watch events: %w", err)
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, "LISTEN "+eventsChannel); err != nil {
+		return fmt.Errorf("watch events: %w", err)
+	}
+	// The connection goes back to the pool, so it must not keep listening.
+	defer func() {
+		_, _ = conn.Exec(context.Background(), "UNLISTEN "+eventsChannel)
+	}()
+
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("watch events: %w", err)
+		}
+		if n.Payload == s.scheme {
+			changed()
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package sqlstorage
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/jackc/pgx/v5"
+)
+
+func (s *DBStorage) WebhookCreate(ctx context.Context, req *models.CreateWebhookReq) (*models.Webhook, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		INSERT INTO webhooks (owner_id, url, secret, types)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`
+	s.logger.Debug("SQL: " + sql)
+
+	hook := &models.Webhook{Owner: req.Owner, URL: req.URL, Secret: req.Secret, Types: req.Types}
+	err := s.DB.QueryRow(ctx, sql, req.Owner, req.URL, req.Secret, req.Types).Scan(&hook.ID, &hook.CreatedAt)
+	if err != nil {
+		s.logger.Error("insert webhook failed: " + err.Error())
+		return nil, fmt.Errorf("insert webhook: %w", err)
+	}
+
+	s.logger.Debug("webhook created id=" + hook.ID)
+	return hook, nil
+}
+
+func (s *DBStorage) WebhookList(ctx context.Context, req *models.ListWebhooksReq) ([]models.Webhook, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		SELECT id, owner_id, url, secret, types, created_at
+		FROM webhooks
+		WHERE owner_id = $1
+		ORDER BY created_at, id`
+	s.logger.Debug("SQL: " + sql)
+
+	rows, err := s.DB.Query(ctx, sql, req.User)
+	if err != nil {
+		s.logger.Error("list webhooks failed: " + err.Error())
+		return nil, fmt.Errorf("list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]models.Webhook, 0)
+	for rows.Next() {
+		var h models.Webhook
+		if err := rows.Scan(&h.ID, &h.Owner, &h.URL, &h.Secret, &h.Types, &h.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan webhook: %w", err)
+		}
+		res = append(res, h)
+	}
+	return res, rows.Err()
+}
+
+// WebhookDelete relies on the cascade of webhook_deliveries to drop the deliveries.
+func (s *DBStorage) WebhookDelete(ctx context.Context, req *models.WebhookIDReq) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `DELETE FROM webhooks WHERE id = $1`
+	s.logger.Debug("SQL: " + sql)
+
+	tag, err := s.DB.Exec(ctx, sql, req.ID)
+	if err != nil {
+		s.logger.Error("delete webhook failed: " + err.Error())
+		return fmt.Errorf("delete webhook: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("delete webhook: %w", calendarErrors.NotFound(calendarErrors.ErrWebhookNotFound, req.ID))
+	}
+
+	s.logger.Debug("webhook deleted id=" + req.ID)
+	return nil
+}
+
+// WebhookEnqueue skips the webhooks that already have a delivery with the key of req. An empty
+// key is stored as NULL, which never conflicts.
+func (s *DBStorage) WebhookEnqueue(ctx context.Context, req *models.EnqueueWebhookReq) (int, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		INSERT INTO webhook_deliveries (webhook_id, type, payload, dedupe_key)
+		SELECT id, $1::text, $3::jsonb, NULLIF($4::text, '') FROM webhooks
+		WHERE owner_id = ANY($2::uuid[]) AND $1::text = ANY(types)
+		ON CONFLICT (webhook_id, dedupe_key) DO NOTHING`
+	s.logger.Debug("SQL: " + sql)
+
+	tag, err := s.DB.Exec(ctx, sql, req.Type, req.Users, req.Payload, req.Key)
+	if err != nil {
+		s.logger.Error("enqueue webhook deliveries failed: " + err.Error())
+		return 0, fmt.Errorf("enqueue webhook deliveries: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// WebhookClaimDeliveries leases the due rows in one statement; rows another dispatcher is claiming
+// at the same time are skipped.
+func (s *DBStorage) WebhookClaimDeliveries(
+	ctx context.Context,
+	req *models.ClaimDeliveriesReq,
+) ([]models.WebhookDelivery, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at, id
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = $2
+		FROM due, webhooks w
+		WHERE d.id = due.id AND w.id = d.webhook_id
+		RETURNING d.id, d.webhook_id, d.type, d.payload, d.status, d.attempts, d.created_at, d.next_attempt_at,
+		          d.last_attempt_at, d.response_code, d.last_error, d.delivered_at, w.url, w.secret`
+	s.logger.Debug("SQL: " + sql)
+
+	rows, err := s.DB.Query(ctx, sql, req.Now, req.Now.Add(req.Lease), req.Limit)
+	if err != nil {
+		s.logger.Error("claim webhook deliveries failed: " + err.Error())
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WebhookDelivery, error) {
+		var d models.WebhookDelivery
+		err := row.Scan(&d.ID, &d.WebhookID, &d.Type, &d.Payload, &d.Status, &d.Attempts, &d.CreatedAt,
+			&d.NextAttemptAt, &d.LastAttemptAt, &d.ResponseCode, &d.LastError, &d.DeliveredAt, &d.URL, &d.Secret)
+		return d, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	// RETURNING does not keep the order of the CTE.
+	slices.SortFunc(res, func(a, b models.WebhookDelivery) int {
+		return cmp.Or(a.NextAttemptAt.Compare(b.NextAttemptAt), cmp.Compare(a.ID, b.ID))
+	})
+	return res, nil
+}
+
+func (s *DBStorage) WebhookRecordAttempt(ctx context.Context, req *models.DeliveryAttempt) error {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	sql := `
+		UPDATE webhook_deliveries
+		SET attempts = attempts + 1,
+		    status = $2,
+		    last_attempt_at = $3,
+		    response_code = $4,
+		    last_error = $5,
+		    next_attempt_at = $6,
+		    delivered_at = CASE WHEN $2 = 'delivered' THEN $3 END
+		WHERE id = $1`
+	s.logger.Debug("SQL: " + sql)
+
+	// A delivery deleted with its webhook meanwhile matches no row, which is fine.
+	_, err := s.DB.Exec(ctx, sql, req.DeliveryID, string(req.Status), req.At, req.ResponseCode, req.Error,
+		req.NextAttemptAt)
+	if err != nil {
+		s.logger.Error("record webhook attempt failed: " + err.Error())
+		return fmt.Errorf("record webhook attempt: %w", err)
+	}
+	return nil
+}
+
+func (s *DBStorage) WebhookDeliveries(
+	ctx context.Context,
+	req *models.ListDeliveriesReq,
+) ([]models.WebhookDelivery, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	var exists bool
+	if err := s.DB.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM webhooks WHERE id = $1)`, req.WebhookID).
+		Scan(&exists); err != nil {
+		return nil, fmt.Errorf("webhook deliveries: %w", err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("webhook deliveries: %w",
+			calendarErrors.NotFound(calendarErrors.ErrWebhookNotFound, req.WebhookID))
+	}
+
+	var limit *int
+	if req.Limit > 0 {
+		limit = &req.Limit
+	}
+	sql := `
+		SELECT id, webhook_id, type, payload, status, attempts, created_at, next_attempt_at,
+		       last_attempt_at, response_code, last_error, delivered_at
+		FROM webhook_deliveries
+		WHERE webhook_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2`
+	s.logger.Debug("SQL: " + sql)
+
+	rows, err := s.DB.Query(ctx, sql, req.WebhookID, limit)
+	if err != nil {
+		s.logger.Error("list webhook deliveries failed: " + err.Error())
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	res, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (models.WebhookDelivery, error) {
+		var d models.WebhookDelivery
+		err := row.Scan(&d.ID, &d.WebhookID, &d.Type, &d.Payload, &d.Status, &d.Attempts, &d.CreatedAt,
+			&d.NextAttemptAt, &d.LastAttemptAt, &d.ResponseCode, &d.LastError, &d.DeliveredAt)
+		return d, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %w", err)
+	}
+	return res, nil
+}
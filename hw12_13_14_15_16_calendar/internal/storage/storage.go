@@ -2,23 +2,128 @@ package storage
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
 )
 
+// ErrNoArchive is returned when asked to archive events by a storage that has nowhere to keep them.
+var ErrNoArchive = errors.New("the storage has no archive")
+
 type Storage interface {
 	EventCreate(ctx context.Context, req *models.CreateEventReq) (*models.Event, error)
 
 	EventEdit(ctx context.Context, req *models.EditEventReq) (*models.Event, error)
 
+	// EventDelete moves the event to the trash; EventRestore brings it back.
 	EventDelete(ctx context.Context, req *models.EventIDReq) error
 
+	EventListDeleted(ctx context.Context, req *models.ListDeletedEventsReq) ([]models.Event, error)
+
+	EventRestore(ctx context.Context, req *models.EventIDReq) (*models.Event, error)
+
 	EventGet(ctx context.Context, req *models.EventIDReq) (*models.Event, error)
 
 	EventGetList(ctx context.Context, req *models.GetEventListReq) (*models.GetEventListResp, error)
 
+	// EventSearch finds the events of a user matching every word of the query, most relevant first.
+	EventSearch(ctx context.Context, req *models.SearchEventsReq) ([]models.SearchHit, error)
+
+	// EventScan calls fn for every event outside the trash in ID order and stops at the first error fn returns.
+	EventScan(ctx context.Context, fn func(models.Event) error) error
+
 	EventsToNotify(ctx context.Context) ([]models.Event, error)
 
-	DeleteOldEvents(ctx context.Context, cutoff time.Time) error
+	// UpcomingReminders returns the events with a reminder that have not started by now and whose
+	// reminder is due before until, earliest reminder first. Reminders marked sent are left out.
+	UpcomingReminders(ctx context.Context, now, until time.Time) ([]models.Event, error)
+
+	// MarkReminderSent records that the reminder of the event due at notifyAt went out. Moving the
+	// event to another reminder time makes it upcoming again.
+	MarkReminderSent(ctx context.Context, eventID string, notifyAt time.Time) error
+
+	// ExpireEvents permanently removes, or archives, up to req.Limit of the events req selects,
+	// lowest IDs first, and returns how many it removed.
+	ExpireEvents(ctx context.Context, req *models.ExpireEventsReq) (int, error)
+
+	// CountExpiredEvents returns how many events ExpireEvents would remove without a limit.
+	CountExpiredEvents(ctx context.Context, req *models.ExpireEventsReq) (int, error)
+
+	// The batch methods stop at the first failing item and roll everything back when atomic is set,
+	// otherwise they return one result per item.
+
+	EventBatchCreate(ctx context.Context, reqs []models.CreateEventReq, atomic bool) ([]models.BatchResult, error)
+
+	EventBatchEdit(ctx context.Context, reqs []models.EditEventReq, atomic bool) ([]models.BatchResult, error)
+
+	// EventBatchDelete returns the events as they were moved to the trash.
+	EventBatchDelete(ctx context.Context, reqs []models.EventIDReq, atomic bool) ([]models.BatchResult, error)
+
+	EventRespond(ctx context.Context, req *models.RespondEventReq) (*models.Event, error)
+
+	CalendarCreate(ctx context.Context, req *models.CreateCalendarReq) (*models.Calendar, error)
+
+	CalendarGet(ctx context.Context, req *models.CalendarIDReq) (*models.Calendar, error)
+
+	CalendarList(ctx context.Context, req *models.ListCalendarsReq) ([]models.Calendar, error)
+
+	// CalendarDelete removes the calendar with all of its events and returns the ones that were
+	// outside the trash.
+	CalendarDelete(ctx context.Context, req *models.CalendarIDReq) ([]models.Event, error)
+
+	CalendarShare(ctx context.Context, req *models.ShareCalendarReq) (*models.Calendar, error)
+
+	CalendarUnshare(ctx context.Context, req *models.UnshareCalendarReq) (*models.Calendar, error)
+
+	FreeBusy(ctx context.Context, req *models.FreeBusyReq) ([]models.UserBusy, error)
+
+	FindSlot(ctx context.Context, req *models.FindSlotReq) ([]models.Interval, error)
+
+	LabelCreate(ctx context.Context, req *models.CreateLabelReq) (*models.Label, error)
+
+	LabelList(ctx context.Context, req *models.ListLabelsReq) ([]models.Label, error)
+
+	// OwnerList returns every user owning a calendar or a label, sorted.
+	OwnerList(ctx context.Context) ([]string, error)
+
+	// LabelDelete also takes the label off every event carrying it.
+	LabelDelete(ctx context.Context, req *models.LabelIDReq) error
+
+	WebhookCreate(ctx context.Context, req *models.CreateWebhookReq) (*models.Webhook, error)
+
+	WebhookList(ctx context.Context, req *models.ListWebhooksReq) ([]models.Webhook, error)
+
+	// WebhookDelete also drops the deliveries of the webhook.
+	WebhookDelete(ctx context.Context, req *models.WebhookIDReq) error
+
+	// WebhookEnqueue queues a pending delivery for every matching webhook and returns how many it queued.
+	WebhookEnqueue(ctx context.Context, req *models.EnqueueWebhookReq) (int, error)
+
+	// WebhookClaimDeliveries returns the due pending deliveries, earliest first, with the URL and
+	// secret of their webhooks.
+	WebhookClaimDeliveries(ctx context.Context, req *models.ClaimDeliveriesReq) ([]models.WebhookDelivery, error)
+
+	// WebhookRecordAttempt counts an attempt of a delivery and stores its outcome.
+	WebhookRecordAttempt(ctx context.Context, req *models.DeliveryAttempt) error
+
+	WebhookDeliveries(ctx context.Context, req *models.ListDeliveriesReq) ([]models.WebhookDelivery, error)
+
+	// AttachmentCreate records a file already put in the attachment store.
+	AttachmentCreate(ctx context.Context, req *models.CreateAttachmentReq) (*models.Attachment, error)
+
+	AttachmentGet(ctx context.Context, req *models.AttachmentIDReq) (*models.Attachment, error)
+
+	// AttachmentList returns the attachments of an event outside the trash, oldest first.
+	AttachmentList(ctx context.Context, req *models.ListAttachmentsReq) ([]models.Attachment, error)
+
+	// AttachmentDelete drops the record. Like the removal of an event, it leaves the content to
+	// delete from the store, see AttachmentOrphans.
+	AttachmentDelete(ctx context.Context, req *models.AttachmentIDReq) error
+
+	// AttachmentOrphans returns up to limit attachments whose records are gone, however they were
+	// removed, until AttachmentOrphansForget is told their content is deleted.
+	AttachmentOrphans(ctx context.Context, limit int) ([]models.AttachmentOrphan, error)
+
+	AttachmentOrphansForget(ctx context.Context, ids []string) error
 }
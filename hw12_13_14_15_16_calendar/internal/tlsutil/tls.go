@@ -0,0 +1,161 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+)
+
+type Logger interface {
+	Info(msg string)
+	Error(msg string)
+}
+
+// Reloader serves a certificate loaded from disk and picks up a rotated pair without a restart.
+// The files are checked at most once per interval, on the next handshake.
+type Reloader struct {
+	certFile string
+	keyFile  string
+	interval time.Duration
+	logger   Logger
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	modTime   time.Time
+	checkedAt time.Time
+	now       func() time.Time
+}
+
+// NewReloader loads the pair once; an interval of zero never looks at the files again.
+func NewReloader(certFile, keyFile string, interval time.Duration, logger Logger) (*Reloader, error) {
+	r := &Reloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+		interval: interval,
+		logger:   logger,
+		now:      time.Now,
+	}
+	modTime, err := r.lastModified()
+	if err != nil {
+		return nil, err
+	}
+	if err := r.load(modTime); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.current(), nil
+}
+
+func (r *Reloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return r.current(), nil
+}
+
+func (r *Reloader) current() *tls.Certificate {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	if r.interval <= 0 || now.Sub(r.checkedAt) < r.interval {
+		return r.cert
+	}
+	r.checkedAt = now
+
+	// A failed reload keeps serving the previous certificate, so a half-written rotation does no harm.
+	modTime, err := r.lastModified()
+	if err != nil {
+		r.logger.Error("tls: " + err.Error())
+		return r.cert
+	}
+	if modTime.After(r.modTime) {
+		if err := r.load(modTime); err != nil {
+			r.logger.Error("tls: " + err.Error())
+		} else {
+			r.logger.Info("tls: certificate reloaded from " + r.certFile)
+		}
+	}
+	return r.cert
+}
+
+func (r *Reloader) load(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("load key pair: %w", err)
+	}
+	r.cert = &cert
+	r.modTime = modTime
+	r.checkedAt = r.now()
+	return nil
+}
+
+func (r *Reloader) lastModified() (time.Time, error) {
+	var latest time.Time
+	for _, name := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("stat certificate: %w", err)
+		}
+		if info.ModTime().After(latest) {
+			latest = info.ModTime()
+		}
+	}
+	return latest, nil
+}
+
+// ServerConfig is used by both listeners. Client certificates are required only when
+// requireClientCert is set and the configuration names a client CA.
+func ServerConfig(cfg configuration.TLSConf, reloader *Reloader, requireClientCert bool) (*tls.Config, error) {
+	tc := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}
+	if requireClientCert && cfg.ClientCAFile != "" {
+		pool, err := loadPool(cfg.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tc.ClientCAs = pool
+		tc.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tc, nil
+}
+
+// ClientConfig is used by the gateway to reach the gRPC port. Under mTLS it presents the
+// server's own certificate, which therefore has to allow client authentication as well.
+func ClientConfig(cfg configuration.TLSConf, reloader *Reloader) (*tls.Config, error) {
+	tc := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+	}
+	if cfg.CAFile != "" {
+		pool, err := loadPool(cfg.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tc.RootCAs = pool
+	}
+	if cfg.ClientCAFile != "" {
+		tc.GetClientCertificate = reloader.GetClientCertificate
+	}
+	return tc, nil
+}
+
+func loadPool(file string) (*x509.CertPool, error) {
+	pemData, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("read CA file: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, errors.New("no certificates found in " + file)
+	}
+	return pool, nil
+}
@@ -0,0 +1,197 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testLogger struct{}
+
+func (testLogger) Info(string)  {}
+func (testLogger) Error(string) {}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "calendar test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue writes a certificate for localhost signed by the CA and returns the cert and key paths.
+func (ca *testCA) issue(t *testing.T, dir, name string, serial int64) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".crt")
+	keyFile := filepath.Join(dir, name+".key")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func (ca *testCA) write(t *testing.T, dir string) string {
+	t.Helper()
+	file := filepath.Join(dir, "ca.crt")
+	writePEM(t, file, "CERTIFICATE", ca.cert.Raw)
+	return file
+}
+
+func writePEM(t *testing.T, file, kind string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0o600))
+}
+
+// handshake runs one TLS handshake between server and client and returns the client's error.
+func handshake(t *testing.T, server, client *tls.Config) error {
+	t.Helper()
+	ln, err := tls.Listen("tcp", "127.0.0.1:0", server)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.(*tls.Conn).Handshake()
+		_, _ = conn.Read(make([]byte, 1))
+	}()
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), client)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	// With TLS 1.3 a rejected client certificate only shows up on the first read.
+	_ = conn.SetReadDeadline(time.Now().Add(200 * time.Millisecond))
+	if _, err := conn.Read(make([]byte, 1)); err != nil {
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := ca.write(t, dir)
+	certFile, keyFile := ca.issue(t, dir, "server", 2)
+
+	cfg := configuration.TLSConf{
+		Enable:       true,
+		CertFile:     certFile,
+		KeyFile:      keyFile,
+		CAFile:       caFile,
+		ClientCAFile: caFile,
+		ServerName:   "localhost",
+	}
+	reloader, err := NewReloader(certFile, keyFile, 0, testLogger{})
+	require.NoError(t, err)
+
+	server, err := ServerConfig(cfg, reloader, true)
+	require.NoError(t, err)
+	client, err := ClientConfig(cfg, reloader)
+	require.NoError(t, err)
+
+	assert.NoError(t, handshake(t, server, client))
+
+	anonymous := client.Clone()
+	anonymous.GetClientCertificate = nil
+	assert.Error(t, handshake(t, server, anonymous), "the gRPC port must reject clients without a certificate")
+
+	untrusted := client.Clone()
+	untrusted.RootCAs = x509.NewCertPool()
+	assert.Error(t, handshake(t, server, untrusted))
+
+	public, err := ServerConfig(cfg, reloader, false)
+	require.NoError(t, err)
+	assert.NoError(t, handshake(t, public, anonymous), "the HTTP listener does not ask for client certificates")
+}
+
+func TestReloaderPicksUpRotation(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	certFile, keyFile := ca.issue(t, dir, "server", 2)
+
+	now := time.Now()
+	reloader, err := NewReloader(certFile, keyFile, time.Minute, testLogger{})
+	require.NoError(t, err)
+	reloader.now = func() time.Time { return now }
+
+	first, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+
+	ca.issue(t, dir, "server", 3)
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+
+	same, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Same(t, first, same, "files are not checked again before the interval passes")
+
+	now = now.Add(2 * time.Minute)
+	rotated, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(rotated.Certificate[0])
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), leaf.SerialNumber.Int64())
+
+	require.NoError(t, os.WriteFile(certFile, []byte("garbage"), 0o600))
+	require.NoError(t, os.Chtimes(certFile, later.Add(time.Hour), later.Add(time.Hour)))
+	now = now.Add(2 * time.Minute)
+	kept, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	assert.Same(t, rotated, kept, "a broken rotation keeps the previous certificate")
+}
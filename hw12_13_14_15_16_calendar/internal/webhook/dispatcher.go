@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+)
+
+// Queue is the part of the storage holding the deliveries.
+type Queue interface {
+	WebhookClaimDeliveries(ctx context.Context, req *models.ClaimDeliveriesReq) ([]models.WebhookDelivery, error)
+	WebhookRecordAttempt(ctx context.Context, req *models.DeliveryAttempt) error
+}
+
+// maxResponseBody is how much of a response is read before the connection is let go.
+const maxResponseBody = 64 << 10
+
+// Dispatcher sends the due deliveries of the queue. A delivery is done once the webhook answers
+// 2xx; otherwise it is retried with exponential backoff until it runs out of attempts. Claimed
+// deliveries are leased for twice the request timeout, so one interrupted by a crash is retried
+// after that by whichever dispatcher runs then.
+type Dispatcher struct {
+	queue       Queue
+	client      *http.Client
+	interval    time.Duration
+	lease       time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	maxBackoff  time.Duration
+	batchSize   int
+	logger      logger.Logger
+}
+
+func NewDispatcher(queue Queue, cfg configuration.WebhooksConf, logg logger.Logger) *Dispatcher {
+	return &Dispatcher{
+		queue:       queue,
+		client:      newClient(cfg.Timeout, checkDial),
+		interval:    cfg.Interval,
+		lease:       2 * cfg.Timeout,
+		maxAttempts: cfg.MaxAttempts,
+		backoff:     cfg.Backoff,
+		maxBackoff:  cfg.MaxBackoff,
+		batchSize:   cfg.BatchSize,
+		logger:      logg,
+	}
+}
+
+// Run dispatches the due deliveries every interval until ctx is done.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// Full batches mean more deliveries are waiting.
+		for {
+			n, err := d.Dispatch(ctx, time.Now())
+			if err != nil {
+				if ctx.Err() == nil {
+					d.logger.Error(fmt.Sprintf("dispatch webhooks: %v", err))
+				}
+				break
+			}
+			if n < d.batchSize {
+				break
+			}
+		}
+	}
+}
+
+// Dispatch sends one batch of the deliveries due at now, concurrently, and returns how many it sent.
+func (d *Dispatcher) Dispatch(ctx context.Context, now time.Time) (int, error) {
+	deliveries, err := d.queue.WebhookClaimDeliveries(ctx, &models.ClaimDeliveriesReq{
+		Now:   now,
+		Lease: d.lease,
+		Limit: d.batchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for i := range deliveries {
+		wg.Add(1)
+		go func(delivery *models.WebhookDelivery) {
+			defer wg.Done()
+			d.deliver(ctx, delivery, now)
+		}(&deliveries[i])
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) {
+	code, err := d.send(ctx, delivery, now)
+	if ctx.Err() != nil {
+		// Shutting down: the lease runs out and the delivery is tried again later.
+		return
+	}
+
+	attempt := &models.DeliveryAttempt{
+		DeliveryID:   delivery.ID,
+		At:           now,
+		Status:       models.DeliveryDelivered,
+		ResponseCode: code,
+	}
+	attempts := delivery.Attempts + 1
+	switch {
+	case err == nil:
+		d.logger.Debug(fmt.Sprintf("webhook delivery %s sent to %s", delivery.ID, delivery.URL))
+	case attempts >= d.maxAttempts:
+		attempt.Status, attempt.Error = models.DeliveryFailed, err.Error()
+		d.logger.Error(fmt.Sprintf("webhook delivery %s to %s failed after %d attempts: %v",
+			delivery.ID, delivery.URL, attempts, err))
+	default:
+		attempt.Status, attempt.Error = models.DeliveryPending, err.Error()
+		attempt.NextAttemptAt = now.Add(d.delay(attempts))
+		d.logger.Warn(fmt.Sprintf("webhook delivery %s to %s, attempt %d: %v, retrying at %s",
+			delivery.ID, delivery.URL, attempts, err, attempt.NextAttemptAt.Format(time.RFC3339)))
+	}
+
+	if err := d.queue.WebhookRecordAttempt(ctx, attempt); err != nil {
+		d.logger.Error(fmt.Sprintf("record webhook delivery %s: %v", delivery.ID, err))
+	}
+}
+
+// send posts the delivery and returns the response status, or 0 when there was no response.
+func (d *Dispatcher) send(ctx context.Context, delivery *models.WebhookDelivery, now time.Time) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	ts := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "calendar-webhooks")
+	req.Header.Set(HeaderEvent, delivery.Type)
+	req.Header.Set(HeaderDelivery, delivery.ID)
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(ts, 10))
+	req.Header.Set(HeaderSignature, "sha256="+Sign(delivery.Secret, ts, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Reading the body lets the connection be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// delay is the wait after the given number of failed attempts: backoff, doubled for every
+// attempt after the first, up to maxBackoff.
+func (d *Dispatcher) delay(attempts int) time.Duration {
+	delay := d.backoff
+	for i := 1; i < attempts && delay < d.maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, d.maxBackoff)
+}
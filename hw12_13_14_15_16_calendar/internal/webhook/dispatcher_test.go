@@ -0,0 +1,271 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/configuration"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/logger"
+	memorystorage "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/memory"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/storage/models"
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef"
+
+func testConfig() configuration.WebhooksConf {
+	return configuration.WebhooksConf{
+		Enable:      true,
+		Interval:    time.Second,
+		Timeout:     time.Second,
+		MaxAttempts: 3,
+		Backoff:     10 * time.Second,
+		MaxBackoff:  15 * time.Second,
+		BatchSize:   10,
+	}
+}
+
+// subscribe registers a webhook of owner to url and enqueues one event.created delivery for it.
+func subscribe(t *testing.T, store *memorystorage.LocalStorage, url string) *models.Webhook {
+	t.Helper()
+	ctx := context.Background()
+	hook, err := store.WebhookCreate(ctx, &models.CreateWebhookReq{
+		Owner: "owner", URL: url, Secret: testSecret, Types: []string{models.WebhookEventCreated},
+	})
+	require.NoError(t, err)
+
+	payload, err := NewPayload(models.WebhookEventCreated, &proto.Event{Id: "ev-1", Title: "Standup"}, time.Now())
+	require.NoError(t, err)
+	n, err := store.WebhookEnqueue(ctx, &models.EnqueueWebhookReq{
+		Type: models.WebhookEventCreated, Users: []string{"owner"}, Payload: payload,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	return hook
+}
+
+// newDispatcher lets deliveries reach the local test servers.
+func newDispatcher(store *memorystorage.LocalStorage) *Dispatcher {
+	d := NewDispatcher(store, testConfig(), *logger.NewLogger("scheduler", "test", "fatal"))
+	d.client = newClient(time.Second, nil)
+	return d
+}
+
+func deliveries(t *testing.T, store *memorystorage.LocalStorage, hook *models.Webhook) []models.WebhookDelivery {
+	t.Helper()
+	log, err := store.WebhookDeliveries(context.Background(), &models.ListDeliveriesReq{WebhookID: hook.ID})
+	require.NoError(t, err)
+	return log
+}
+
+func TestDispatcherSignsDeliveries(t *testing.T) {
+	type request struct {
+		method string
+		header http.Header
+		body   []byte
+	}
+	got := make(chan request, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		got <- request{method: r.Method, header: r.Header.Clone(), body: body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	store := memorystorage.NewLocalStorage(*logger.NewLogger("calendar", "test", "error"))
+	hook := subscribe(t, store, srv.URL)
+
+	now := time.Now().Add(time.Second)
+	n, err := newDispatcher(store).Dispatch(context.Background(), now)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	req := <-got
+	body := req.body
+	assert.Equal(t, http.MethodPost, req.method)
+	assert.Equal(t, "application/json", req.header.Get("Content-Type"))
+	assert.Equal(t, models.WebhookEventCreated, req.header.Get(HeaderEvent))
+	ts, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, now.Unix(), ts)
+	assert.Equal(t, "sha256="+Sign(testSecret, ts, body), req.header.Get(HeaderSignature))
+	assert.NotEqual(t, "sha256="+Sign("another secret!!", ts, body), req.header.Get(HeaderSignature))
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(body, &payload))
+	assert.Equal(t, models.WebhookEventCreated, payload.Type)
+	assert.JSONEq(t, `"ev-1"`, string(mustField(t, payload.Event, "id")))
+
+	log := deliveries(t, store, hook)
+	require.Len(t, log, 1)
+	assert.Equal(t, req.header.Get(HeaderDelivery), log[0].ID)
+	assert.Equal(t, models.DeliveryDelivered, log[0].Status)
+	assert.Equal(t, 1, log[0].Attempts)
+	assert.Equal(t, http.StatusNoContent, log[0].ResponseCode)
+}
+
+func TestDispatcherRetriesWithBackoff(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	store := memorystorage.NewLocalStorage(*logger.NewLogger("calendar", "test", "error"))
+	hook := subscribe(t, store, srv.URL)
+	d := newDispatcher(store)
+	ctx := context.Background()
+
+	now := time.Now().Add(time.Second)
+	n, err := d.Dispatch(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	log := deliveries(t, store, hook)
+	require.Len(t, log, 1)
+	assert.Equal(t, models.DeliveryPending, log[0].Status)
+	assert.Equal(t, http.StatusServiceUnavailable, log[0].ResponseCode)
+	assert.Contains(t, log[0].LastError, "503")
+	assert.Equal(t, now.Add(10*time.Second), log[0].NextAttemptAt)
+
+	// Nothing is sent before the backoff is over.
+	n, err = d.Dispatch(ctx, now.Add(9*time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	n, err = d.Dispatch(ctx, now.Add(10*time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+
+	log = deliveries(t, store, hook)
+	assert.Equal(t, models.DeliveryDelivered, log[0].Status)
+	assert.Equal(t, 2, log[0].Attempts)
+	assert.Empty(t, log[0].LastError)
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestDispatcherGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	store := memorystorage.NewLocalStorage(*logger.NewLogger("calendar", "test", "error"))
+	hook := subscribe(t, store, srv.URL)
+	d := newDispatcher(store)
+	ctx := context.Background()
+
+	at := time.Now().Add(time.Second)
+	for range 3 {
+		n, err := d.Dispatch(ctx, at)
+		require.NoError(t, err)
+		require.Equal(t, 1, n)
+		at = at.Add(time.Hour)
+	}
+	n, err := d.Dispatch(ctx, at)
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	log := deliveries(t, store, hook)
+	require.Len(t, log, 1)
+	assert.Equal(t, models.DeliveryFailed, log[0].Status)
+	assert.Equal(t, 3, log[0].Attempts)
+	assert.Equal(t, http.StatusInternalServerError, log[0].ResponseCode)
+	assert.EqualValues(t, 3, calls.Load())
+}
+
+func TestDispatcherUnreachable(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	store := memorystorage.NewLocalStorage(*logger.NewLogger("calendar", "test", "error"))
+	hook := subscribe(t, store, url)
+
+	_, err := newDispatcher(store).Dispatch(context.Background(), time.Now().Add(time.Second))
+	require.NoError(t, err)
+
+	log := deliveries(t, store, hook)
+	require.Len(t, log, 1)
+	assert.Equal(t, models.DeliveryPending, log[0].Status)
+	assert.Zero(t, log[0].ResponseCode)
+	assert.NotEmpty(t, log[0].LastError)
+}
+
+func TestDispatcherRefusesInternalAddresses(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	store := memorystorage.NewLocalStorage(*logger.NewLogger("calendar", "test", "error"))
+	hook := subscribe(t, store, srv.URL)
+
+	d := NewDispatcher(store, testConfig(), *logger.NewLogger("scheduler", "test", "fatal"))
+	_, err := d.Dispatch(context.Background(), time.Now().Add(time.Second))
+	require.NoError(t, err)
+
+	assert.Zero(t, calls.Load())
+	log := deliveries(t, store, hook)
+	require.Len(t, log, 1)
+	assert.Equal(t, models.DeliveryPending, log[0].Status)
+	assert.Contains(t, log[0].LastError, "not a public address")
+}
+
+func TestDispatcherDoesNotFollowRedirects(t *testing.T) {
+	var followed atomic.Bool
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		followed.Store(true)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer target.Close()
+	srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer srv.Close()
+
+	store := memorystorage.NewLocalStorage(*logger.NewLogger("calendar", "test", "error"))
+	hook := subscribe(t, store, srv.URL)
+
+	_, err := newDispatcher(store).Dispatch(context.Background(), time.Now().Add(time.Second))
+	require.NoError(t, err)
+
+	assert.False(t, followed.Load())
+	log := deliveries(t, store, hook)
+	require.Len(t, log, 1)
+	assert.Equal(t, models.DeliveryPending, log[0].Status)
+	assert.Equal(t, http.StatusTemporaryRedirect, log[0].ResponseCode)
+}
+
+func TestDelay(t *testing.T) {
+	d := newDispatcher(nil)
+	d.backoff, d.maxBackoff = time.Second, time.Minute
+
+	assert.Equal(t, time.Second, d.delay(1))
+	assert.Equal(t, 2*time.Second, d.delay(2))
+	assert.Equal(t, 32*time.Second, d.delay(6))
+	assert.Equal(t, time.Minute, d.delay(7))
+	assert.Equal(t, time.Minute, d.delay(100))
+}
+
+func mustField(t *testing.T, obj json.RawMessage, name string) json.RawMessage {
+	t.Helper()
+	var fields map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(obj, &fields))
+	return fields[name]
+}
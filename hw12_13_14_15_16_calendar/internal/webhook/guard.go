@@ -0,0 +1,73 @@
+package webhook
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+)
+
+// Webhook URLs are given by users, so deliveries must not become a way to reach the hosts next to
+// the scheduler. CheckURL refuses internal targets when a webhook is created, and the delivery
+// client checks the address it connects to again once the name is resolved.
+
+// sharedAddressSpace is the carrier-grade NAT range, internal like the private ones.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// CheckURL accepts https URLs whose host is a name or a public address.
+func CheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return calendarErrors.ErrWebhookURLNotAllowed
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return calendarErrors.ErrWebhookURLNotAllowed
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !publicAddr(addr) {
+		return calendarErrors.ErrWebhookURLNotAllowed
+	}
+	return nil
+}
+
+// publicAddr reports whether deliveries may connect to addr.
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !sharedAddressSpace.Contains(addr)
+}
+
+// checkDial is the net.Dialer Control hook of deliveries. It runs on the resolved address, so a
+// name pointing at an internal host is refused as well.
+func checkDial(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if addr, err := netip.ParseAddr(host); err != nil || !publicAddr(addr) {
+		return fmt.Errorf("webhook target %s is not a public address", host)
+	}
+	return nil
+}
+
+// newClient returns the HTTP client of deliveries; control, when set, vets every connection.
+// Proxies are not used, as one would connect to the target on the client's behalf.
+func newClient(timeout time.Duration, control func(network, address string, c syscall.RawConn) error) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout, Control: control}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// A redirect could point anywhere, so its 3xx answer fails the attempt instead.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
@@ -0,0 +1,43 @@
+package webhook
+
+import (
+	"testing"
+
+	calendarErrors "github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/internal/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckURL(t *testing.T) {
+	for _, raw := range []string{
+		"https://hooks.example.com/calendar",
+		"https://203.0.113.10:8443/hook",
+		"https://[2001:db8::1]/hook",
+	} {
+		assert.NoError(t, CheckURL(raw), raw)
+	}
+
+	for _, raw := range []string{
+		"http://hooks.example.com/calendar",
+		"https://localhost/hook",
+		"https://api.localhost./hook",
+		"https://127.0.0.1/hook",
+		"https://10.1.2.3/hook",
+		"https://192.168.0.5/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://100.64.0.1/hook",
+		"https://0.0.0.0/hook",
+		"https://[::1]/hook",
+		"https://[fd00::1]/hook",
+		"https://[fe80::1]/hook",
+		"https://[::ffff:127.0.0.1]/hook",
+		"https:///hook",
+	} {
+		assert.ErrorIs(t, CheckURL(raw), calendarErrors.ErrWebhookURLNotAllowed, raw)
+	}
+}
+
+func TestCheckDial(t *testing.T) {
+	assert.NoError(t, checkDial("tcp", "203.0.113.10:443", nil))
+	assert.Error(t, checkDial("tcp", "127.0.0.1:443", nil))
+	assert.Error(t, checkDial("tcp6", "[fe80::1%eth0]:443", nil))
+}
@@ -0,0 +1,53 @@
+// Package webhook builds, signs and delivers the payloads sent to webhook subscriptions.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/IvanovAndrey/hw/hw12_13_14_15_calendar/proto"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// Request headers of every delivery.
+const (
+	HeaderEvent     = "X-Calendar-Event"
+	HeaderDelivery  = "X-Calendar-Delivery"
+	HeaderTimestamp = "X-Calendar-Timestamp"
+	HeaderSignature = "X-Calendar-Signature"
+)
+
+// eventMarshaler writes events the way the REST API does.
+var eventMarshaler = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// Payload is the JSON body of a delivery.
+type Payload struct {
+	Type       string          `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Event      json.RawMessage `json:"event"`
+}
+
+// NewPayload encodes the payload of a change of type typ to ev.
+func NewPayload(typ string, ev *proto.Event, at time.Time) ([]byte, error) {
+	event, err := eventMarshaler.Marshal(ev)
+	if err != nil {
+		return nil, fmt.Errorf("marshal event %s: %w", ev.Id, err)
+	}
+	return json.Marshal(Payload{Type: typ, OccurredAt: at.UTC(), Event: event})
+}
+
+// Sign returns the hex HMAC-SHA256 of timestamp, a dot and body keyed with secret. The receiver
+// recomputes it from the X-Calendar-Timestamp header and the raw body to check a delivery, and
+// rejects old timestamps to stop replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The first migration always creates calendar.events. The ones since are unqualified and
+-- resolve through the search_path (system.database.scheme), so any other scheme gets its
+-- own events table here; for calendar this is a no-op.
+create table if not exists events
+(
+    id            uuid primary key default gen_random_uuid(),
+    title         varchar(64) not null,
+    start_time    timestamp with time zone not null,
+    end_time      timestamp with time zone not null,
+    description   varchar(1000),
+    user_id       uuid not null,
+    notify_before interval
+    );
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+do $$
+begin
+    if current_schema() <> 'calendar' then
+        drop table if exists events;
+    end if;
+end $$;
+-- +goose StatementEnd
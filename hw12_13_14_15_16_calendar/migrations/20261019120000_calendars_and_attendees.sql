@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists calendars
+(
+    id          uuid primary key default gen_random_uuid(),
+    owner_id    uuid not null,
+    title       varchar(64) not null,
+    description varchar(1000)
+);
+
+create index if not exists calendars_owner_id_idx on calendars (owner_id);
+
+create table if not exists calendar_shares
+(
+    calendar_id uuid not null references calendars (id) on delete cascade,
+    user_id     uuid not null,
+    role        varchar(8) not null check (role in ('read', 'write')),
+    primary key (calendar_id, user_id)
+);
+
+create index if not exists calendar_shares_user_id_idx on calendar_shares (user_id);
+
+alter table events add column if not exists calendar_id uuid references calendars (id) on delete cascade;
+
+create table if not exists event_attendees
+(
+    event_id uuid not null references events (id) on delete cascade,
+    user_id  uuid not null,
+    status   varchar(16) not null default 'needs_action'
+        check (status in ('needs_action', 'accepted', 'declined')),
+    primary key (event_id, user_id)
+);
+
+create index if not exists event_attendees_user_id_idx on event_attendees (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists event_attendees;
+alter table events drop column if exists calendar_id;
+drop table if exists calendar_shares;
+drop table if exists calendars;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table events add column if not exists deleted_at timestamp with time zone;
+
+create index if not exists events_deleted_at_idx on events (deleted_at) where deleted_at is not null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists events_deleted_at_idx;
+alter table events drop column if exists deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The 'simple' configuration does no stemming, so titles in any language are matched word by word.
+alter table events add column if not exists search_vector tsvector
+    generated always as (
+        setweight(to_tsvector('simple'::regconfig, coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('simple'::regconfig, coalesce(description, '')), 'B')
+    ) stored;
+
+create index if not exists events_search_vector_idx on events using gin (search_vector);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists events_search_vector_idx;
+alter table events drop column if exists search_vector;
+-- +goose StatementEnd
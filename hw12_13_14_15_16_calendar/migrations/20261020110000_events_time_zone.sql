@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- start_time and end_time stay absolute instants; time_zone only tells how to show the event,
+-- where its days begin for all_day events and how to apply notify_before on the local clock.
+alter table events add column if not exists time_zone text not null default 'UTC';
+alter table events add column if not exists all_day boolean not null default false;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+alter table events drop column if exists all_day;
+alter table events drop column if exists time_zone;
+-- +goose StatementEnd
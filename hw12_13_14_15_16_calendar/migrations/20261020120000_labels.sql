@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists labels
+(
+    id       uuid primary key default gen_random_uuid(),
+    owner_id uuid not null,
+    name     varchar(32) not null,
+    color    varchar(7),
+    unique (owner_id, name)
+);
+
+create table if not exists event_labels
+(
+    event_id uuid not null references events (id) on delete cascade,
+    label_id uuid not null references labels (id) on delete cascade,
+    primary key (event_id, label_id)
+);
+
+create index if not exists event_labels_label_id_idx on event_labels (label_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists event_labels;
+drop table if exists labels;
+-- +goose StatementEnd
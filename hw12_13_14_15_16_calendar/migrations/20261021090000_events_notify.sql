@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+create or replace function notify_events_changed() returns trigger as
+$$
+begin
+    perform pg_notify('calendar_events', current_schema());
+    return null;
+end;
+$$ language plpgsql;
+
+create trigger events_changed
+    after insert or update or delete or truncate
+    on events
+    for each statement
+execute function notify_events_changed();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists events_changed on events;
+drop function if exists notify_events_changed();
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The retention job moves expired events here when archiving is on. The whole event, with its
+-- attendees and label names, is kept as JSON so the archive survives later changes to events.
+create table if not exists events_archive
+(
+    id          uuid primary key,
+    user_id     uuid not null,
+    end_time    timestamp with time zone not null,
+    deleted_at  timestamp with time zone,
+    archived_at timestamp with time zone not null default now(),
+    event       jsonb not null
+);
+
+create index if not exists events_archive_user_id_idx on events_archive (user_id);
+create index if not exists events_end_time_idx on events (end_time) where deleted_at is null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists events_end_time_idx;
+drop table if exists events_archive;
+-- +goose StatementEnd
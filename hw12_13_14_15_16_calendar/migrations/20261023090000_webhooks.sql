@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+create table if not exists webhooks
+(
+    id         uuid primary key default gen_random_uuid(),
+    owner_id   uuid not null,
+    url        text not null,
+    secret     text not null,
+    types      text[] not null,
+    created_at timestamp with time zone not null default now()
+);
+
+create index if not exists webhooks_owner_id_idx on webhooks (owner_id);
+
+-- Pending rows are the queue of the dispatcher; delivered and failed ones stay as the delivery log.
+create table if not exists webhook_deliveries
+(
+    id              uuid primary key default gen_random_uuid(),
+    webhook_id      uuid not null references webhooks (id) on delete cascade,
+    type            text not null,
+    payload         jsonb not null,
+    status          text not null default 'pending',
+    attempts        integer not null default 0,
+    created_at      timestamp with time zone not null default now(),
+    next_attempt_at timestamp with time zone not null default now(),
+    last_attempt_at timestamp with time zone,
+    response_code   integer not null default 0,
+    last_error      text not null default '',
+    delivered_at    timestamp with time zone
+);
+
+create index if not exists webhook_deliveries_due_idx on webhook_deliveries (next_attempt_at) where status = 'pending';
+create index if not exists webhook_deliveries_webhook_id_idx on webhook_deliveries (webhook_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists webhook_deliveries;
+drop table if exists webhooks;
+-- +goose StatementEnd
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+alter table events add column if not exists location text;
+alter table events add column if not exists meeting_url text;
+
+-- The files themselves are in the attachment store, under event_id/id.
+create table if not exists event_attachments
+(
+    id           uuid primary key,
+    event_id     uuid not null references events (id) on delete cascade,
+    name         text not null,
+    content_type text not null,
+    size         bigint not null,
+    sha256       text not null,
+    uploaded_by  uuid not null,
+    created_at   timestamp with time zone not null default now()
+);
+
+create index if not exists event_attachments_event_id_idx on event_attachments (event_id, created_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists event_attachments;
+alter table events drop column if exists meeting_url;
+alter table events drop column if exists location;
+-- +goose StatementEnd
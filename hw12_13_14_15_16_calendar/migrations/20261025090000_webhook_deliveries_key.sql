@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A delivery enqueued with a key is queued once per webhook; NULL keys never conflict.
+alter table webhook_deliveries add column if not exists dedupe_key text;
+
+create unique index if not exists webhook_deliveries_dedupe_key_idx on webhook_deliveries (webhook_id, dedupe_key);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop index if exists webhook_deliveries_dedupe_key_idx;
+alter table webhook_deliveries drop column if exists dedupe_key;
+-- +goose StatementEnd
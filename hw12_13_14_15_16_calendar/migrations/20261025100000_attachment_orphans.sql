@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Every removed attachment record, deleted alone or with its event by the retention, a trash
+-- purge or the deletion of a calendar, lands here until the calendar server has deleted its
+-- content from the attachment store.
+create table if not exists attachment_orphans
+(
+    id         uuid primary key,
+    event_id   uuid not null,
+    removed_at timestamp with time zone not null default now()
+);
+
+create or replace function record_attachment_orphans() returns trigger as
+$$
+begin
+    insert into attachment_orphans (id, event_id)
+    select id, event_id from removed
+    on conflict (id) do nothing;
+    return null;
+end;
+$$ language plpgsql;
+
+create trigger event_attachments_removed
+    after delete
+    on event_attachments
+    referencing old table as removed
+    for each statement
+execute function record_attachment_orphans();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop trigger if exists event_attachments_removed on event_attachments;
+drop function if exists record_attachment_orphans();
+drop table if exists attachment_orphans;
+-- +goose StatementEnd
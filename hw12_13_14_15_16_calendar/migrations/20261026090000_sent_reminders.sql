@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The reminders the scheduler has sent, so a restarted or newly elected scheduler does not send
+-- them again. notify_at tells a sent reminder from the one of an event moved since.
+create table if not exists sent_reminders
+(
+    event_id  uuid primary key references events (id) on delete cascade,
+    notify_at timestamp with time zone not null
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+drop table if exists sent_reminders;
+-- +goose StatementEnd
@@ -0,0 +1,8 @@
+package migrations
+
+import "embed"
+
+// FS holds the goose SQL migrations, so the binaries can apply them without the source tree.
+//
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,768 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: attachment.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Attachment with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Attachment) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Attachment with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AttachmentMultiError, or
+// nil if none found.
+func (m *Attachment) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Attachment) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for EventId
+
+	// no validation rules for Name
+
+	// no validation rules for ContentType
+
+	// no validation rules for Size
+
+	// no validation rules for Sha256
+
+	// no validation rules for UploadedBy
+
+	if all {
+		switch v := interface{}(m.GetCreatedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, AttachmentValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, AttachmentValidationError{
+					field:  "CreatedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetCreatedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return AttachmentValidationError{
+				field:  "CreatedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return AttachmentMultiError(errors)
+	}
+
+	return nil
+}
+
+// AttachmentMultiError is an error wrapping multiple validation errors
+// returned by Attachment.ValidateAll() if the designated constraints aren't met.
+type AttachmentMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AttachmentMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AttachmentMultiError) AllErrors() []error { return m }
+
+// AttachmentValidationError is the validation error returned by
+// Attachment.Validate if the designated constraints aren't met.
+type AttachmentValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AttachmentValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AttachmentValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AttachmentValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AttachmentValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AttachmentValidationError) ErrorName() string { return "AttachmentValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AttachmentValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAttachment.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AttachmentValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AttachmentValidationError{}
+
+// Validate checks the field values on UploadAttachmentInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UploadAttachmentInfo) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UploadAttachmentInfo with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UploadAttachmentInfoMultiError, or nil if none found.
+func (m *UploadAttachmentInfo) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UploadAttachmentInfo) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetEventId()) < 1 {
+		err := UploadAttachmentInfoValidationError{
+			field:  "EventId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetUser()) < 1 {
+		err := UploadAttachmentInfoValidationError{
+			field:  "User",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 255 {
+		err := UploadAttachmentInfoValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 255 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if strings.Contains(m.GetName(), "/") {
+		err := UploadAttachmentInfoValidationError{
+			field:  "Name",
+			reason: "value contains substring \"/\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetContentType()) > 255 {
+		err := UploadAttachmentInfoValidationError{
+			field:  "ContentType",
+			reason: "value length must be at most 255 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UploadAttachmentInfoMultiError(errors)
+	}
+
+	return nil
+}
+
+// UploadAttachmentInfoMultiError is an error wrapping multiple validation
+// errors returned by UploadAttachmentInfo.ValidateAll() if the designated
+// constraints aren't met.
+type UploadAttachmentInfoMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UploadAttachmentInfoMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UploadAttachmentInfoMultiError) AllErrors() []error { return m }
+
+// UploadAttachmentInfoValidationError is the validation error returned by
+// UploadAttachmentInfo.Validate if the designated constraints aren't met.
+type UploadAttachmentInfoValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadAttachmentInfoValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadAttachmentInfoValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadAttachmentInfoValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadAttachmentInfoValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadAttachmentInfoValidationError) ErrorName() string {
+	return "UploadAttachmentInfoValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UploadAttachmentInfoValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUploadAttachmentInfo.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadAttachmentInfoValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadAttachmentInfoValidationError{}
+
+// Validate checks the field values on UploadAttachmentReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UploadAttachmentReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UploadAttachmentReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UploadAttachmentReqMultiError, or nil if none found.
+func (m *UploadAttachmentReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UploadAttachmentReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	oneofPartPresent := false
+	switch v := m.Part.(type) {
+	case *UploadAttachmentReq_Info:
+		if v == nil {
+			err := UploadAttachmentReqValidationError{
+				field:  "Part",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofPartPresent = true
+
+		if all {
+			switch v := interface{}(m.GetInfo()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UploadAttachmentReqValidationError{
+						field:  "Info",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UploadAttachmentReqValidationError{
+						field:  "Info",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetInfo()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UploadAttachmentReqValidationError{
+					field:  "Info",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	case *UploadAttachmentReq_Chunk:
+		if v == nil {
+			err := UploadAttachmentReqValidationError{
+				field:  "Part",
+				reason: "oneof value cannot be a typed-nil",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+		oneofPartPresent = true
+
+		if len(m.GetChunk()) > 1048576 {
+			err := UploadAttachmentReqValidationError{
+				field:  "Chunk",
+				reason: "value length must be at most 1048576 bytes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	default:
+		_ = v // ensures v is used
+	}
+	if !oneofPartPresent {
+		err := UploadAttachmentReqValidationError{
+			field:  "Part",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UploadAttachmentReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// UploadAttachmentReqMultiError is an error wrapping multiple validation
+// errors returned by UploadAttachmentReq.ValidateAll() if the designated
+// constraints aren't met.
+type UploadAttachmentReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UploadAttachmentReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UploadAttachmentReqMultiError) AllErrors() []error { return m }
+
+// UploadAttachmentReqValidationError is the validation error returned by
+// UploadAttachmentReq.Validate if the designated constraints aren't met.
+type UploadAttachmentReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UploadAttachmentReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UploadAttachmentReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UploadAttachmentReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UploadAttachmentReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UploadAttachmentReqValidationError) ErrorName() string {
+	return "UploadAttachmentReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UploadAttachmentReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUploadAttachmentReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UploadAttachmentReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UploadAttachmentReqValidationError{}
+
+// Validate checks the field values on AttachmentByIdReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *AttachmentByIdReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AttachmentByIdReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// AttachmentByIdReqMultiError, or nil if none found.
+func (m *AttachmentByIdReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AttachmentByIdReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetAttachmentId()) < 1 {
+		err := AttachmentByIdReqValidationError{
+			field:  "AttachmentId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return AttachmentByIdReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// AttachmentByIdReqMultiError is an error wrapping multiple validation errors
+// returned by AttachmentByIdReq.ValidateAll() if the designated constraints
+// aren't met.
+type AttachmentByIdReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AttachmentByIdReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AttachmentByIdReqMultiError) AllErrors() []error { return m }
+
+// AttachmentByIdReqValidationError is the validation error returned by
+// AttachmentByIdReq.Validate if the designated constraints aren't met.
+type AttachmentByIdReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AttachmentByIdReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AttachmentByIdReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AttachmentByIdReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AttachmentByIdReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AttachmentByIdReqValidationError) ErrorName() string {
+	return "AttachmentByIdReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e AttachmentByIdReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAttachmentByIdReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AttachmentByIdReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AttachmentByIdReqValidationError{}
+
+// Validate checks the field values on ListAttachmentsRes with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListAttachmentsRes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListAttachmentsRes with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListAttachmentsResMultiError, or nil if none found.
+func (m *ListAttachmentsRes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListAttachmentsRes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListAttachmentsResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListAttachmentsResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListAttachmentsResValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListAttachmentsResMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListAttachmentsResMultiError is an error wrapping multiple validation errors
+// returned by ListAttachmentsRes.ValidateAll() if the designated constraints
+// aren't met.
+type ListAttachmentsResMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListAttachmentsResMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListAttachmentsResMultiError) AllErrors() []error { return m }
+
+// ListAttachmentsResValidationError is the validation error returned by
+// ListAttachmentsRes.Validate if the designated constraints aren't met.
+type ListAttachmentsResValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListAttachmentsResValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListAttachmentsResValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListAttachmentsResValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListAttachmentsResValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListAttachmentsResValidationError) ErrorName() string {
+	return "ListAttachmentsResValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ListAttachmentsResValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListAttachmentsRes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListAttachmentsResValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListAttachmentsResValidationError{}
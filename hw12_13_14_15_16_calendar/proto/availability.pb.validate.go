@@ -0,0 +1,1131 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: availability.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on TimeRange with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *TimeRange) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on TimeRange with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in TimeRangeMultiError, or nil
+// if none found.
+func (m *TimeRange) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *TimeRange) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetStart()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TimeRangeValidationError{
+					field:  "Start",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TimeRangeValidationError{
+					field:  "Start",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStart()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TimeRangeValidationError{
+				field:  "Start",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEnd()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, TimeRangeValidationError{
+					field:  "End",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, TimeRangeValidationError{
+					field:  "End",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEnd()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return TimeRangeValidationError{
+				field:  "End",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return TimeRangeMultiError(errors)
+	}
+
+	return nil
+}
+
+// TimeRangeMultiError is an error wrapping multiple validation errors returned
+// by TimeRange.ValidateAll() if the designated constraints aren't met.
+type TimeRangeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m TimeRangeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m TimeRangeMultiError) AllErrors() []error { return m }
+
+// TimeRangeValidationError is the validation error returned by
+// TimeRange.Validate if the designated constraints aren't met.
+type TimeRangeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e TimeRangeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e TimeRangeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e TimeRangeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e TimeRangeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e TimeRangeValidationError) ErrorName() string { return "TimeRangeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e TimeRangeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sTimeRange.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = TimeRangeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = TimeRangeValidationError{}
+
+// Validate checks the field values on FreeBusyReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FreeBusyReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FreeBusyReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FreeBusyReqMultiError, or
+// nil if none found.
+func (m *FreeBusyReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FreeBusyReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetUsers()); l < 1 || l > 50 {
+		err := FreeBusyReqValidationError{
+			field:  "Users",
+			reason: "value must contain between 1 and 50 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_FreeBusyReq_Users_Unique := make(map[string]struct{}, len(m.GetUsers()))
+
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
+
+		if _, exists := _FreeBusyReq_Users_Unique[item]; exists {
+			err := FreeBusyReqValidationError{
+				field:  fmt.Sprintf("Users[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_FreeBusyReq_Users_Unique[item] = struct{}{}
+		}
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := FreeBusyReqValidationError{
+				field:  fmt.Sprintf("Users[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetStart() == nil {
+		err := FreeBusyReqValidationError{
+			field:  "Start",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEnd() == nil {
+		err := FreeBusyReqValidationError{
+			field:  "End",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return FreeBusyReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// FreeBusyReqMultiError is an error wrapping multiple validation errors
+// returned by FreeBusyReq.ValidateAll() if the designated constraints aren't met.
+type FreeBusyReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FreeBusyReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FreeBusyReqMultiError) AllErrors() []error { return m }
+
+// FreeBusyReqValidationError is the validation error returned by
+// FreeBusyReq.Validate if the designated constraints aren't met.
+type FreeBusyReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FreeBusyReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FreeBusyReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FreeBusyReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FreeBusyReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FreeBusyReqValidationError) ErrorName() string { return "FreeBusyReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FreeBusyReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFreeBusyReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FreeBusyReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FreeBusyReqValidationError{}
+
+// Validate checks the field values on UserBusy with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserBusy) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserBusy with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserBusyMultiError, or nil
+// if none found.
+func (m *UserBusy) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserBusy) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for User
+
+	for idx, item := range m.GetBusy() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserBusyValidationError{
+						field:  fmt.Sprintf("Busy[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserBusyValidationError{
+						field:  fmt.Sprintf("Busy[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserBusyValidationError{
+					field:  fmt.Sprintf("Busy[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return UserBusyMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserBusyMultiError is an error wrapping multiple validation errors returned
+// by UserBusy.ValidateAll() if the designated constraints aren't met.
+type UserBusyMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserBusyMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserBusyMultiError) AllErrors() []error { return m }
+
+// UserBusyValidationError is the validation error returned by
+// UserBusy.Validate if the designated constraints aren't met.
+type UserBusyValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserBusyValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserBusyValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserBusyValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserBusyValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserBusyValidationError) ErrorName() string { return "UserBusyValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserBusyValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserBusy.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserBusyValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserBusyValidationError{}
+
+// Validate checks the field values on FreeBusyRes with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FreeBusyRes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FreeBusyRes with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FreeBusyResMultiError, or
+// nil if none found.
+func (m *FreeBusyRes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FreeBusyRes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FreeBusyResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FreeBusyResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FreeBusyResValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return FreeBusyResMultiError(errors)
+	}
+
+	return nil
+}
+
+// FreeBusyResMultiError is an error wrapping multiple validation errors
+// returned by FreeBusyRes.ValidateAll() if the designated constraints aren't met.
+type FreeBusyResMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FreeBusyResMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FreeBusyResMultiError) AllErrors() []error { return m }
+
+// FreeBusyResValidationError is the validation error returned by
+// FreeBusyRes.Validate if the designated constraints aren't met.
+type FreeBusyResValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FreeBusyResValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FreeBusyResValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FreeBusyResValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FreeBusyResValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FreeBusyResValidationError) ErrorName() string { return "FreeBusyResValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FreeBusyResValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFreeBusyRes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FreeBusyResValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FreeBusyResValidationError{}
+
+// Validate checks the field values on WorkingHours with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *WorkingHours) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on WorkingHours with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in WorkingHoursMultiError, or
+// nil if none found.
+func (m *WorkingHours) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *WorkingHours) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if !_WorkingHours_From_Pattern.MatchString(m.GetFrom()) {
+		err := WorkingHoursValidationError{
+			field:  "From",
+			reason: "value does not match regex pattern \"^([01][0-9]|2[0-3]):[0-5][0-9]$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if !_WorkingHours_To_Pattern.MatchString(m.GetTo()) {
+		err := WorkingHoursValidationError{
+			field:  "To",
+			reason: "value does not match regex pattern \"^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$\"",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_WorkingHours_Weekdays_Unique := make(map[int32]struct{}, len(m.GetWeekdays()))
+
+	for idx, item := range m.GetWeekdays() {
+		_, _ = idx, item
+
+		if _, exists := _WorkingHours_Weekdays_Unique[item]; exists {
+			err := WorkingHoursValidationError{
+				field:  fmt.Sprintf("Weekdays[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_WorkingHours_Weekdays_Unique[item] = struct{}{}
+		}
+
+		if val := item; val < 0 || val > 6 {
+			err := WorkingHoursValidationError{
+				field:  fmt.Sprintf("Weekdays[%v]", idx),
+				reason: "value must be inside range [0, 6]",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.TimeZone != nil {
+		// no validation rules for TimeZone
+	}
+
+	if len(errors) > 0 {
+		return WorkingHoursMultiError(errors)
+	}
+
+	return nil
+}
+
+// WorkingHoursMultiError is an error wrapping multiple validation errors
+// returned by WorkingHours.ValidateAll() if the designated constraints aren't met.
+type WorkingHoursMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m WorkingHoursMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m WorkingHoursMultiError) AllErrors() []error { return m }
+
+// WorkingHoursValidationError is the validation error returned by
+// WorkingHours.Validate if the designated constraints aren't met.
+type WorkingHoursValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e WorkingHoursValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e WorkingHoursValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e WorkingHoursValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e WorkingHoursValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e WorkingHoursValidationError) ErrorName() string { return "WorkingHoursValidationError" }
+
+// Error satisfies the builtin error interface
+func (e WorkingHoursValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sWorkingHours.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = WorkingHoursValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = WorkingHoursValidationError{}
+
+var _WorkingHours_From_Pattern = regexp.MustCompile("^([01][0-9]|2[0-3]):[0-5][0-9]$")
+
+var _WorkingHours_To_Pattern = regexp.MustCompile("^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$")
+
+// Validate checks the field values on FindSlotReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FindSlotReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FindSlotReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FindSlotReqMultiError, or
+// nil if none found.
+func (m *FindSlotReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FindSlotReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetUsers()); l < 1 || l > 50 {
+		err := FindSlotReqValidationError{
+			field:  "Users",
+			reason: "value must contain between 1 and 50 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	_FindSlotReq_Users_Unique := make(map[string]struct{}, len(m.GetUsers()))
+
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
+
+		if _, exists := _FindSlotReq_Users_Unique[item]; exists {
+			err := FindSlotReqValidationError{
+				field:  fmt.Sprintf("Users[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_FindSlotReq_Users_Unique[item] = struct{}{}
+		}
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := FindSlotReqValidationError{
+				field:  fmt.Sprintf("Users[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetStart() == nil {
+		err := FindSlotReqValidationError{
+			field:  "Start",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetEnd() == nil {
+		err := FindSlotReqValidationError{
+			field:  "End",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetDuration()) < 1 {
+		err := FindSlotReqValidationError{
+			field:  "Duration",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.GetCount() > 100 {
+		err := FindSlotReqValidationError{
+			field:  "Count",
+			reason: "value must be less than or equal to 100",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetWorkingHours()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, FindSlotReqValidationError{
+					field:  "WorkingHours",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, FindSlotReqValidationError{
+					field:  "WorkingHours",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWorkingHours()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return FindSlotReqValidationError{
+				field:  "WorkingHours",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return FindSlotReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// FindSlotReqMultiError is an error wrapping multiple validation errors
+// returned by FindSlotReq.ValidateAll() if the designated constraints aren't met.
+type FindSlotReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FindSlotReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FindSlotReqMultiError) AllErrors() []error { return m }
+
+// FindSlotReqValidationError is the validation error returned by
+// FindSlotReq.Validate if the designated constraints aren't met.
+type FindSlotReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FindSlotReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FindSlotReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FindSlotReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FindSlotReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FindSlotReqValidationError) ErrorName() string { return "FindSlotReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FindSlotReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFindSlotReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FindSlotReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FindSlotReqValidationError{}
+
+// Validate checks the field values on FindSlotRes with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *FindSlotRes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on FindSlotRes with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in FindSlotResMultiError, or
+// nil if none found.
+func (m *FindSlotRes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *FindSlotRes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetSlots() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, FindSlotResValidationError{
+						field:  fmt.Sprintf("Slots[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, FindSlotResValidationError{
+						field:  fmt.Sprintf("Slots[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return FindSlotResValidationError{
+					field:  fmt.Sprintf("Slots[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return FindSlotResMultiError(errors)
+	}
+
+	return nil
+}
+
+// FindSlotResMultiError is an error wrapping multiple validation errors
+// returned by FindSlotRes.ValidateAll() if the designated constraints aren't met.
+type FindSlotResMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m FindSlotResMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m FindSlotResMultiError) AllErrors() []error { return m }
+
+// FindSlotResValidationError is the validation error returned by
+// FindSlotRes.Validate if the designated constraints aren't met.
+type FindSlotResValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e FindSlotResValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e FindSlotResValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e FindSlotResValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e FindSlotResValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e FindSlotResValidationError) ErrorName() string { return "FindSlotResValidationError" }
+
+// Error satisfies the builtin error interface
+func (e FindSlotResValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sFindSlotRes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = FindSlotResValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = FindSlotResValidationError{}
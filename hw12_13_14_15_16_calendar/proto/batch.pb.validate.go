@@ -0,0 +1,730 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: batch.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on BatchCreateEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchCreateEventsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchCreateEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchCreateEventsReqMultiError, or nil if none found.
+func (m *BatchCreateEventsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchCreateEventsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetItems()); l < 1 || l > 100 {
+		err := BatchCreateEventsReqValidationError{
+			field:  "Items",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		// skipping validation for items
+
+	}
+
+	if _, ok := BatchMode_name[int32(m.GetMode())]; !ok {
+		err := BatchCreateEventsReqValidationError{
+			field:  "Mode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return BatchCreateEventsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchCreateEventsReqMultiError is an error wrapping multiple validation
+// errors returned by BatchCreateEventsReq.ValidateAll() if the designated
+// constraints aren't met.
+type BatchCreateEventsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchCreateEventsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchCreateEventsReqMultiError) AllErrors() []error { return m }
+
+// BatchCreateEventsReqValidationError is the validation error returned by
+// BatchCreateEventsReq.Validate if the designated constraints aren't met.
+type BatchCreateEventsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchCreateEventsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchCreateEventsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchCreateEventsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchCreateEventsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchCreateEventsReqValidationError) ErrorName() string {
+	return "BatchCreateEventsReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchCreateEventsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchCreateEventsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchCreateEventsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchCreateEventsReqValidationError{}
+
+// Validate checks the field values on BatchEditEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchEditEventsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchEditEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchEditEventsReqMultiError, or nil if none found.
+func (m *BatchEditEventsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchEditEventsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetItems()); l < 1 || l > 100 {
+		err := BatchEditEventsReqValidationError{
+			field:  "Items",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetItems() {
+		_, _ = idx, item
+
+		// skipping validation for items
+
+	}
+
+	if _, ok := BatchMode_name[int32(m.GetMode())]; !ok {
+		err := BatchEditEventsReqValidationError{
+			field:  "Mode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return BatchEditEventsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchEditEventsReqMultiError is an error wrapping multiple validation errors
+// returned by BatchEditEventsReq.ValidateAll() if the designated constraints
+// aren't met.
+type BatchEditEventsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchEditEventsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchEditEventsReqMultiError) AllErrors() []error { return m }
+
+// BatchEditEventsReqValidationError is the validation error returned by
+// BatchEditEventsReq.Validate if the designated constraints aren't met.
+type BatchEditEventsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchEditEventsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchEditEventsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchEditEventsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchEditEventsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchEditEventsReqValidationError) ErrorName() string {
+	return "BatchEditEventsReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchEditEventsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchEditEventsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchEditEventsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchEditEventsReqValidationError{}
+
+// Validate checks the field values on BatchDeleteEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *BatchDeleteEventsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchDeleteEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchDeleteEventsReqMultiError, or nil if none found.
+func (m *BatchDeleteEventsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchDeleteEventsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if l := len(m.GetEventIds()); l < 1 || l > 100 {
+		err := BatchDeleteEventsReqValidationError{
+			field:  "EventIds",
+			reason: "value must contain between 1 and 100 items, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	for idx, item := range m.GetEventIds() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := BatchDeleteEventsReqValidationError{
+				field:  fmt.Sprintf("EventIds[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if _, ok := BatchMode_name[int32(m.GetMode())]; !ok {
+		err := BatchDeleteEventsReqValidationError{
+			field:  "Mode",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return BatchDeleteEventsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchDeleteEventsReqMultiError is an error wrapping multiple validation
+// errors returned by BatchDeleteEventsReq.ValidateAll() if the designated
+// constraints aren't met.
+type BatchDeleteEventsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchDeleteEventsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchDeleteEventsReqMultiError) AllErrors() []error { return m }
+
+// BatchDeleteEventsReqValidationError is the validation error returned by
+// BatchDeleteEventsReq.Validate if the designated constraints aren't met.
+type BatchDeleteEventsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchDeleteEventsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchDeleteEventsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchDeleteEventsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchDeleteEventsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchDeleteEventsReqValidationError) ErrorName() string {
+	return "BatchDeleteEventsReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e BatchDeleteEventsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchDeleteEventsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchDeleteEventsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchDeleteEventsReqValidationError{}
+
+// Validate checks the field values on BatchEventResult with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *BatchEventResult) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchEventResult with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// BatchEventResultMultiError, or nil if none found.
+func (m *BatchEventResult) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchEventResult) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetStatus()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BatchEventResultValidationError{
+					field:  "Status",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BatchEventResultValidationError{
+					field:  "Status",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStatus()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchEventResultValidationError{
+				field:  "Status",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEvent()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, BatchEventResultValidationError{
+					field:  "Event",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, BatchEventResultValidationError{
+					field:  "Event",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEvent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return BatchEventResultValidationError{
+				field:  "Event",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return BatchEventResultMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchEventResultMultiError is an error wrapping multiple validation errors
+// returned by BatchEventResult.ValidateAll() if the designated constraints
+// aren't met.
+type BatchEventResultMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchEventResultMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchEventResultMultiError) AllErrors() []error { return m }
+
+// BatchEventResultValidationError is the validation error returned by
+// BatchEventResult.Validate if the designated constraints aren't met.
+type BatchEventResultValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchEventResultValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchEventResultValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchEventResultValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchEventResultValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchEventResultValidationError) ErrorName() string { return "BatchEventResultValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BatchEventResultValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchEventResult.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchEventResultValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchEventResultValidationError{}
+
+// Validate checks the field values on BatchEventsRes with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *BatchEventsRes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on BatchEventsRes with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in BatchEventsResMultiError,
+// or nil if none found.
+func (m *BatchEventsRes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *BatchEventsRes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetResults() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, BatchEventsResValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, BatchEventsResValidationError{
+						field:  fmt.Sprintf("Results[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return BatchEventsResValidationError{
+					field:  fmt.Sprintf("Results[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return BatchEventsResMultiError(errors)
+	}
+
+	return nil
+}
+
+// BatchEventsResMultiError is an error wrapping multiple validation errors
+// returned by BatchEventsRes.ValidateAll() if the designated constraints
+// aren't met.
+type BatchEventsResMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m BatchEventsResMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m BatchEventsResMultiError) AllErrors() []error { return m }
+
+// BatchEventsResValidationError is the validation error returned by
+// BatchEventsRes.Validate if the designated constraints aren't met.
+type BatchEventsResValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e BatchEventsResValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e BatchEventsResValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e BatchEventsResValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e BatchEventsResValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e BatchEventsResValidationError) ErrorName() string { return "BatchEventsResValidationError" }
+
+// Error satisfies the builtin error interface
+func (e BatchEventsResValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sBatchEventsRes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = BatchEventsResValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = BatchEventsResValidationError{}
@@ -0,0 +1,1050 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: calendar.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on CalendarShare with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CalendarShare) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CalendarShare with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CalendarShareMultiError, or
+// nil if none found.
+func (m *CalendarShare) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CalendarShare) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for User
+
+	// no validation rules for Role
+
+	if len(errors) > 0 {
+		return CalendarShareMultiError(errors)
+	}
+
+	return nil
+}
+
+// CalendarShareMultiError is an error wrapping multiple validation errors
+// returned by CalendarShare.ValidateAll() if the designated constraints
+// aren't met.
+type CalendarShareMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarShareMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarShareMultiError) AllErrors() []error { return m }
+
+// CalendarShareValidationError is the validation error returned by
+// CalendarShare.Validate if the designated constraints aren't met.
+type CalendarShareValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarShareValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarShareValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarShareValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarShareValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarShareValidationError) ErrorName() string { return "CalendarShareValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CalendarShareValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendarShare.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarShareValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarShareValidationError{}
+
+// Validate checks the field values on UserCalendar with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *UserCalendar) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UserCalendar with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in UserCalendarMultiError, or
+// nil if none found.
+func (m *UserCalendar) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UserCalendar) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Owner
+
+	// no validation rules for Title
+
+	for idx, item := range m.GetShares() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, UserCalendarValidationError{
+						field:  fmt.Sprintf("Shares[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, UserCalendarValidationError{
+						field:  fmt.Sprintf("Shares[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return UserCalendarValidationError{
+					field:  fmt.Sprintf("Shares[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.Description != nil {
+		// no validation rules for Description
+	}
+
+	if len(errors) > 0 {
+		return UserCalendarMultiError(errors)
+	}
+
+	return nil
+}
+
+// UserCalendarMultiError is an error wrapping multiple validation errors
+// returned by UserCalendar.ValidateAll() if the designated constraints aren't met.
+type UserCalendarMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UserCalendarMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UserCalendarMultiError) AllErrors() []error { return m }
+
+// UserCalendarValidationError is the validation error returned by
+// UserCalendar.Validate if the designated constraints aren't met.
+type UserCalendarValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UserCalendarValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UserCalendarValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UserCalendarValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UserCalendarValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UserCalendarValidationError) ErrorName() string { return "UserCalendarValidationError" }
+
+// Error satisfies the builtin error interface
+func (e UserCalendarValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUserCalendar.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UserCalendarValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UserCalendarValidationError{}
+
+// Validate checks the field values on CreateCalendarReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CreateCalendarReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateCalendarReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CreateCalendarReqMultiError, or nil if none found.
+func (m *CreateCalendarReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateCalendarReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetOwner()) < 1 {
+		err := CreateCalendarReqValidationError{
+			field:  "Owner",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetTitle()); l < 1 || l > 64 {
+		err := CreateCalendarReqValidationError{
+			field:  "Title",
+			reason: "value length must be between 1 and 64 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.Description != nil {
+
+		if utf8.RuneCountInString(m.GetDescription()) > 1000 {
+			err := CreateCalendarReqValidationError{
+				field:  "Description",
+				reason: "value length must be at most 1000 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateCalendarReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateCalendarReqMultiError is an error wrapping multiple validation errors
+// returned by CreateCalendarReq.ValidateAll() if the designated constraints
+// aren't met.
+type CreateCalendarReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateCalendarReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateCalendarReqMultiError) AllErrors() []error { return m }
+
+// CreateCalendarReqValidationError is the validation error returned by
+// CreateCalendarReq.Validate if the designated constraints aren't met.
+type CreateCalendarReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateCalendarReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateCalendarReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateCalendarReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateCalendarReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateCalendarReqValidationError) ErrorName() string {
+	return "CreateCalendarReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e CreateCalendarReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateCalendarReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateCalendarReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateCalendarReqValidationError{}
+
+// Validate checks the field values on CalendarByIdReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *CalendarByIdReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CalendarByIdReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// CalendarByIdReqMultiError, or nil if none found.
+func (m *CalendarByIdReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CalendarByIdReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCalendarId()) < 1 {
+		err := CalendarByIdReqValidationError{
+			field:  "CalendarId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return CalendarByIdReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CalendarByIdReqMultiError is an error wrapping multiple validation errors
+// returned by CalendarByIdReq.ValidateAll() if the designated constraints
+// aren't met.
+type CalendarByIdReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CalendarByIdReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CalendarByIdReqMultiError) AllErrors() []error { return m }
+
+// CalendarByIdReqValidationError is the validation error returned by
+// CalendarByIdReq.Validate if the designated constraints aren't met.
+type CalendarByIdReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CalendarByIdReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CalendarByIdReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CalendarByIdReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CalendarByIdReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CalendarByIdReqValidationError) ErrorName() string { return "CalendarByIdReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CalendarByIdReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCalendarByIdReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CalendarByIdReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CalendarByIdReqValidationError{}
+
+// Validate checks the field values on ListCalendarsReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListCalendarsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListCalendarsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListCalendarsReqMultiError, or nil if none found.
+func (m *ListCalendarsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListCalendarsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUser()) < 1 {
+		err := ListCalendarsReqValidationError{
+			field:  "User",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListCalendarsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListCalendarsReqMultiError is an error wrapping multiple validation errors
+// returned by ListCalendarsReq.ValidateAll() if the designated constraints
+// aren't met.
+type ListCalendarsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListCalendarsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListCalendarsReqMultiError) AllErrors() []error { return m }
+
+// ListCalendarsReqValidationError is the validation error returned by
+// ListCalendarsReq.Validate if the designated constraints aren't met.
+type ListCalendarsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListCalendarsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListCalendarsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListCalendarsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListCalendarsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListCalendarsReqValidationError) ErrorName() string { return "ListCalendarsReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListCalendarsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListCalendarsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListCalendarsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListCalendarsReqValidationError{}
+
+// Validate checks the field values on ListCalendarsRes with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ListCalendarsRes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListCalendarsRes with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListCalendarsResMultiError, or nil if none found.
+func (m *ListCalendarsRes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListCalendarsRes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListCalendarsResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListCalendarsResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListCalendarsResValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListCalendarsResMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListCalendarsResMultiError is an error wrapping multiple validation errors
+// returned by ListCalendarsRes.ValidateAll() if the designated constraints
+// aren't met.
+type ListCalendarsResMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListCalendarsResMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListCalendarsResMultiError) AllErrors() []error { return m }
+
+// ListCalendarsResValidationError is the validation error returned by
+// ListCalendarsRes.Validate if the designated constraints aren't met.
+type ListCalendarsResValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListCalendarsResValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListCalendarsResValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListCalendarsResValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListCalendarsResValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListCalendarsResValidationError) ErrorName() string { return "ListCalendarsResValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListCalendarsResValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListCalendarsRes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListCalendarsResValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListCalendarsResValidationError{}
+
+// Validate checks the field values on ShareCalendarReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ShareCalendarReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ShareCalendarReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ShareCalendarReqMultiError, or nil if none found.
+func (m *ShareCalendarReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ShareCalendarReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCalendarId()) < 1 {
+		err := ShareCalendarReqValidationError{
+			field:  "CalendarId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetUser()) < 1 {
+		err := ShareCalendarReqValidationError{
+			field:  "User",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _ShareCalendarReq_Role_NotInLookup[m.GetRole()]; ok {
+		err := ShareCalendarReqValidationError{
+			field:  "Role",
+			reason: "value must not be in list [CALENDAR_ROLE_UNSPECIFIED]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := CalendarRole_name[int32(m.GetRole())]; !ok {
+		err := ShareCalendarReqValidationError{
+			field:  "Role",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ShareCalendarReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ShareCalendarReqMultiError is an error wrapping multiple validation errors
+// returned by ShareCalendarReq.ValidateAll() if the designated constraints
+// aren't met.
+type ShareCalendarReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ShareCalendarReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ShareCalendarReqMultiError) AllErrors() []error { return m }
+
+// ShareCalendarReqValidationError is the validation error returned by
+// ShareCalendarReq.Validate if the designated constraints aren't met.
+type ShareCalendarReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ShareCalendarReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ShareCalendarReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ShareCalendarReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ShareCalendarReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ShareCalendarReqValidationError) ErrorName() string { return "ShareCalendarReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ShareCalendarReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sShareCalendarReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ShareCalendarReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ShareCalendarReqValidationError{}
+
+var _ShareCalendarReq_Role_NotInLookup = map[CalendarRole]struct{}{
+	0: {},
+}
+
+// Validate checks the field values on UnshareCalendarReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *UnshareCalendarReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on UnshareCalendarReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// UnshareCalendarReqMultiError, or nil if none found.
+func (m *UnshareCalendarReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *UnshareCalendarReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetCalendarId()) < 1 {
+		err := UnshareCalendarReqValidationError{
+			field:  "CalendarId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetUser()) < 1 {
+		err := UnshareCalendarReqValidationError{
+			field:  "User",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return UnshareCalendarReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// UnshareCalendarReqMultiError is an error wrapping multiple validation errors
+// returned by UnshareCalendarReq.ValidateAll() if the designated constraints
+// aren't met.
+type UnshareCalendarReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m UnshareCalendarReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m UnshareCalendarReqMultiError) AllErrors() []error { return m }
+
+// UnshareCalendarReqValidationError is the validation error returned by
+// UnshareCalendarReq.Validate if the designated constraints aren't met.
+type UnshareCalendarReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e UnshareCalendarReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e UnshareCalendarReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e UnshareCalendarReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e UnshareCalendarReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e UnshareCalendarReqValidationError) ErrorName() string {
+	return "UnshareCalendarReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e UnshareCalendarReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sUnshareCalendarReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = UnshareCalendarReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = UnshareCalendarReqValidationError{}
@@ -8,6 +8,7 @@ package proto
 
 import (
 	context "context"
+	httpbody "google.golang.org/genproto/googleapis/api/httpbody"
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
@@ -20,12 +21,39 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Calendar_GetLiveZ_FullMethodName     = "/calendar_proto.Calendar/GetLiveZ"
-	Calendar_CreateEvent_FullMethodName  = "/calendar_proto.Calendar/CreateEvent"
-	Calendar_EditEvent_FullMethodName    = "/calendar_proto.Calendar/EditEvent"
-	Calendar_GetEvent_FullMethodName     = "/calendar_proto.Calendar/GetEvent"
-	Calendar_DeleteEvent_FullMethodName  = "/calendar_proto.Calendar/DeleteEvent"
-	Calendar_GetEventList_FullMethodName = "/calendar_proto.Calendar/GetEventList"
+	Calendar_GetLiveZ_FullMethodName              = "/calendar_proto.Calendar/GetLiveZ"
+	Calendar_CreateEvent_FullMethodName           = "/calendar_proto.Calendar/CreateEvent"
+	Calendar_EditEvent_FullMethodName             = "/calendar_proto.Calendar/EditEvent"
+	Calendar_GetEvent_FullMethodName              = "/calendar_proto.Calendar/GetEvent"
+	Calendar_DeleteEvent_FullMethodName           = "/calendar_proto.Calendar/DeleteEvent"
+	Calendar_GetEventList_FullMethodName          = "/calendar_proto.Calendar/GetEventList"
+	Calendar_SearchEvents_FullMethodName          = "/calendar_proto.Calendar/SearchEvents"
+	Calendar_ListDeletedEvents_FullMethodName     = "/calendar_proto.Calendar/ListDeletedEvents"
+	Calendar_RestoreEvent_FullMethodName          = "/calendar_proto.Calendar/RestoreEvent"
+	Calendar_BatchCreateEvents_FullMethodName     = "/calendar_proto.Calendar/BatchCreateEvents"
+	Calendar_BatchEditEvents_FullMethodName       = "/calendar_proto.Calendar/BatchEditEvents"
+	Calendar_BatchDeleteEvents_FullMethodName     = "/calendar_proto.Calendar/BatchDeleteEvents"
+	Calendar_RespondToEvent_FullMethodName        = "/calendar_proto.Calendar/RespondToEvent"
+	Calendar_CreateCalendar_FullMethodName        = "/calendar_proto.Calendar/CreateCalendar"
+	Calendar_GetCalendar_FullMethodName           = "/calendar_proto.Calendar/GetCalendar"
+	Calendar_ListCalendars_FullMethodName         = "/calendar_proto.Calendar/ListCalendars"
+	Calendar_DeleteCalendar_FullMethodName        = "/calendar_proto.Calendar/DeleteCalendar"
+	Calendar_ShareCalendar_FullMethodName         = "/calendar_proto.Calendar/ShareCalendar"
+	Calendar_UnshareCalendar_FullMethodName       = "/calendar_proto.Calendar/UnshareCalendar"
+	Calendar_FreeBusy_FullMethodName              = "/calendar_proto.Calendar/FreeBusy"
+	Calendar_FindSlot_FullMethodName              = "/calendar_proto.Calendar/FindSlot"
+	Calendar_CreateLabel_FullMethodName           = "/calendar_proto.Calendar/CreateLabel"
+	Calendar_ListLabels_FullMethodName            = "/calendar_proto.Calendar/ListLabels"
+	Calendar_DeleteLabel_FullMethodName           = "/calendar_proto.Calendar/DeleteLabel"
+	Calendar_CreateWebhook_FullMethodName         = "/calendar_proto.Calendar/CreateWebhook"
+	Calendar_ListWebhooks_FullMethodName          = "/calendar_proto.Calendar/ListWebhooks"
+	Calendar_DeleteWebhook_FullMethodName         = "/calendar_proto.Calendar/DeleteWebhook"
+	Calendar_ListWebhookDeliveries_FullMethodName = "/calendar_proto.Calendar/ListWebhookDeliveries"
+	Calendar_UploadAttachment_FullMethodName      = "/calendar_proto.Calendar/UploadAttachment"
+	Calendar_ListAttachments_FullMethodName       = "/calendar_proto.Calendar/ListAttachments"
+	Calendar_GetAttachment_FullMethodName         = "/calendar_proto.Calendar/GetAttachment"
+	Calendar_DownloadAttachment_FullMethodName    = "/calendar_proto.Calendar/DownloadAttachment"
+	Calendar_DeleteAttachment_FullMethodName      = "/calendar_proto.Calendar/DeleteAttachment"
 )
 
 // CalendarClient is the client API for Calendar service.
@@ -36,8 +64,41 @@ type CalendarClient interface {
 	CreateEvent(ctx context.Context, in *CreateEventReq, opts ...grpc.CallOption) (*Event, error)
 	EditEvent(ctx context.Context, in *EditEventReq, opts ...grpc.CallOption) (*Event, error)
 	GetEvent(ctx context.Context, in *EventByIdReq, opts ...grpc.CallOption) (*Event, error)
+	// Moves the event to the trash, see ListDeletedEvents and RestoreEvent.
 	DeleteEvent(ctx context.Context, in *EventByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetEventList(ctx context.Context, in *GetEventListReq, opts ...grpc.CallOption) (*GetEventListRes, error)
+	SearchEvents(ctx context.Context, in *SearchEventsReq, opts ...grpc.CallOption) (*SearchEventsRes, error)
+	ListDeletedEvents(ctx context.Context, in *ListDeletedEventsReq, opts ...grpc.CallOption) (*GetEventListRes, error)
+	RestoreEvent(ctx context.Context, in *EventByIdReq, opts ...grpc.CallOption) (*Event, error)
+	BatchCreateEvents(ctx context.Context, in *BatchCreateEventsReq, opts ...grpc.CallOption) (*BatchEventsRes, error)
+	BatchEditEvents(ctx context.Context, in *BatchEditEventsReq, opts ...grpc.CallOption) (*BatchEventsRes, error)
+	BatchDeleteEvents(ctx context.Context, in *BatchDeleteEventsReq, opts ...grpc.CallOption) (*BatchEventsRes, error)
+	RespondToEvent(ctx context.Context, in *RespondToEventReq, opts ...grpc.CallOption) (*Event, error)
+	CreateCalendar(ctx context.Context, in *CreateCalendarReq, opts ...grpc.CallOption) (*UserCalendar, error)
+	GetCalendar(ctx context.Context, in *CalendarByIdReq, opts ...grpc.CallOption) (*UserCalendar, error)
+	ListCalendars(ctx context.Context, in *ListCalendarsReq, opts ...grpc.CallOption) (*ListCalendarsRes, error)
+	DeleteCalendar(ctx context.Context, in *CalendarByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ShareCalendar(ctx context.Context, in *ShareCalendarReq, opts ...grpc.CallOption) (*UserCalendar, error)
+	UnshareCalendar(ctx context.Context, in *UnshareCalendarReq, opts ...grpc.CallOption) (*UserCalendar, error)
+	FreeBusy(ctx context.Context, in *FreeBusyReq, opts ...grpc.CallOption) (*FreeBusyRes, error)
+	FindSlot(ctx context.Context, in *FindSlotReq, opts ...grpc.CallOption) (*FindSlotRes, error)
+	CreateLabel(ctx context.Context, in *CreateLabelReq, opts ...grpc.CallOption) (*Label, error)
+	ListLabels(ctx context.Context, in *ListLabelsReq, opts ...grpc.CallOption) (*ListLabelsRes, error)
+	// Deletes the label from the catalogue and from every event carrying it.
+	DeleteLabel(ctx context.Context, in *LabelByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*Webhook, error)
+	ListWebhooks(ctx context.Context, in *ListWebhooksReq, opts ...grpc.CallOption) (*ListWebhooksRes, error)
+	// Deletes the webhook and its delivery log; pending deliveries are dropped.
+	DeleteWebhook(ctx context.Context, in *WebhookByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesReq, opts ...grpc.CallOption) (*ListWebhookDeliveriesRes, error)
+	// Streams a file to attach to an event: an info message, then the content in chunks of up to 1 MiB.
+	// Over HTTP the messages are sent as newline-delimited JSON.
+	UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentReq, Attachment], error)
+	ListAttachments(ctx context.Context, in *EventByIdReq, opts ...grpc.CallOption) (*ListAttachmentsRes, error)
+	GetAttachment(ctx context.Context, in *AttachmentByIdReq, opts ...grpc.CallOption) (*Attachment, error)
+	// Streams the content of a file in chunks; the first one carries its content type.
+	DownloadAttachment(ctx context.Context, in *AttachmentByIdReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error)
+	DeleteAttachment(ctx context.Context, in *AttachmentByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type calendarClient struct {
@@ -108,6 +169,288 @@ func (c *calendarClient) GetEventList(ctx context.Context, in *GetEventListReq,
 	return out, nil
 }
 
+func (c *calendarClient) SearchEvents(ctx context.Context, in *SearchEventsReq, opts ...grpc.CallOption) (*SearchEventsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchEventsRes)
+	err := c.cc.Invoke(ctx, Calendar_SearchEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) ListDeletedEvents(ctx context.Context, in *ListDeletedEventsReq, opts ...grpc.CallOption) (*GetEventListRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetEventListRes)
+	err := c.cc.Invoke(ctx, Calendar_ListDeletedEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) RestoreEvent(ctx context.Context, in *EventByIdReq, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, Calendar_RestoreEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) BatchCreateEvents(ctx context.Context, in *BatchCreateEventsReq, opts ...grpc.CallOption) (*BatchEventsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchEventsRes)
+	err := c.cc.Invoke(ctx, Calendar_BatchCreateEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) BatchEditEvents(ctx context.Context, in *BatchEditEventsReq, opts ...grpc.CallOption) (*BatchEventsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchEventsRes)
+	err := c.cc.Invoke(ctx, Calendar_BatchEditEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) BatchDeleteEvents(ctx context.Context, in *BatchDeleteEventsReq, opts ...grpc.CallOption) (*BatchEventsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchEventsRes)
+	err := c.cc.Invoke(ctx, Calendar_BatchDeleteEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) RespondToEvent(ctx context.Context, in *RespondToEventReq, opts ...grpc.CallOption) (*Event, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Event)
+	err := c.cc.Invoke(ctx, Calendar_RespondToEvent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) CreateCalendar(ctx context.Context, in *CreateCalendarReq, opts ...grpc.CallOption) (*UserCalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserCalendar)
+	err := c.cc.Invoke(ctx, Calendar_CreateCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) GetCalendar(ctx context.Context, in *CalendarByIdReq, opts ...grpc.CallOption) (*UserCalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserCalendar)
+	err := c.cc.Invoke(ctx, Calendar_GetCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) ListCalendars(ctx context.Context, in *ListCalendarsReq, opts ...grpc.CallOption) (*ListCalendarsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCalendarsRes)
+	err := c.cc.Invoke(ctx, Calendar_ListCalendars_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) DeleteCalendar(ctx context.Context, in *CalendarByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Calendar_DeleteCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) ShareCalendar(ctx context.Context, in *ShareCalendarReq, opts ...grpc.CallOption) (*UserCalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserCalendar)
+	err := c.cc.Invoke(ctx, Calendar_ShareCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) UnshareCalendar(ctx context.Context, in *UnshareCalendarReq, opts ...grpc.CallOption) (*UserCalendar, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserCalendar)
+	err := c.cc.Invoke(ctx, Calendar_UnshareCalendar_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) FreeBusy(ctx context.Context, in *FreeBusyReq, opts ...grpc.CallOption) (*FreeBusyRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FreeBusyRes)
+	err := c.cc.Invoke(ctx, Calendar_FreeBusy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) FindSlot(ctx context.Context, in *FindSlotReq, opts ...grpc.CallOption) (*FindSlotRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FindSlotRes)
+	err := c.cc.Invoke(ctx, Calendar_FindSlot_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) CreateLabel(ctx context.Context, in *CreateLabelReq, opts ...grpc.CallOption) (*Label, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Label)
+	err := c.cc.Invoke(ctx, Calendar_CreateLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) ListLabels(ctx context.Context, in *ListLabelsReq, opts ...grpc.CallOption) (*ListLabelsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLabelsRes)
+	err := c.cc.Invoke(ctx, Calendar_ListLabels_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) DeleteLabel(ctx context.Context, in *LabelByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Calendar_DeleteLabel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) CreateWebhook(ctx context.Context, in *CreateWebhookReq, opts ...grpc.CallOption) (*Webhook, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Webhook)
+	err := c.cc.Invoke(ctx, Calendar_CreateWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) ListWebhooks(ctx context.Context, in *ListWebhooksReq, opts ...grpc.CallOption) (*ListWebhooksRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhooksRes)
+	err := c.cc.Invoke(ctx, Calendar_ListWebhooks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) DeleteWebhook(ctx context.Context, in *WebhookByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Calendar_DeleteWebhook_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) ListWebhookDeliveries(ctx context.Context, in *ListWebhookDeliveriesReq, opts ...grpc.CallOption) (*ListWebhookDeliveriesRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListWebhookDeliveriesRes)
+	err := c.cc.Invoke(ctx, Calendar_ListWebhookDeliveries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) UploadAttachment(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadAttachmentReq, Attachment], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Calendar_ServiceDesc.Streams[0], Calendar_UploadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadAttachmentReq, Attachment]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calendar_UploadAttachmentClient = grpc.ClientStreamingClient[UploadAttachmentReq, Attachment]
+
+func (c *calendarClient) ListAttachments(ctx context.Context, in *EventByIdReq, opts ...grpc.CallOption) (*ListAttachmentsRes, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAttachmentsRes)
+	err := c.cc.Invoke(ctx, Calendar_ListAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) GetAttachment(ctx context.Context, in *AttachmentByIdReq, opts ...grpc.CallOption) (*Attachment, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Attachment)
+	err := c.cc.Invoke(ctx, Calendar_GetAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *calendarClient) DownloadAttachment(ctx context.Context, in *AttachmentByIdReq, opts ...grpc.CallOption) (grpc.ServerStreamingClient[httpbody.HttpBody], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Calendar_ServiceDesc.Streams[1], Calendar_DownloadAttachment_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[AttachmentByIdReq, httpbody.HttpBody]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calendar_DownloadAttachmentClient = grpc.ServerStreamingClient[httpbody.HttpBody]
+
+func (c *calendarClient) DeleteAttachment(ctx context.Context, in *AttachmentByIdReq, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, Calendar_DeleteAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // CalendarServer is the server API for Calendar service.
 // All implementations must embed UnimplementedCalendarServer
 // for forward compatibility.
@@ -116,8 +459,41 @@ type CalendarServer interface {
 	CreateEvent(context.Context, *CreateEventReq) (*Event, error)
 	EditEvent(context.Context, *EditEventReq) (*Event, error)
 	GetEvent(context.Context, *EventByIdReq) (*Event, error)
+	// Moves the event to the trash, see ListDeletedEvents and RestoreEvent.
 	DeleteEvent(context.Context, *EventByIdReq) (*emptypb.Empty, error)
 	GetEventList(context.Context, *GetEventListReq) (*GetEventListRes, error)
+	SearchEvents(context.Context, *SearchEventsReq) (*SearchEventsRes, error)
+	ListDeletedEvents(context.Context, *ListDeletedEventsReq) (*GetEventListRes, error)
+	RestoreEvent(context.Context, *EventByIdReq) (*Event, error)
+	BatchCreateEvents(context.Context, *BatchCreateEventsReq) (*BatchEventsRes, error)
+	BatchEditEvents(context.Context, *BatchEditEventsReq) (*BatchEventsRes, error)
+	BatchDeleteEvents(context.Context, *BatchDeleteEventsReq) (*BatchEventsRes, error)
+	RespondToEvent(context.Context, *RespondToEventReq) (*Event, error)
+	CreateCalendar(context.Context, *CreateCalendarReq) (*UserCalendar, error)
+	GetCalendar(context.Context, *CalendarByIdReq) (*UserCalendar, error)
+	ListCalendars(context.Context, *ListCalendarsReq) (*ListCalendarsRes, error)
+	DeleteCalendar(context.Context, *CalendarByIdReq) (*emptypb.Empty, error)
+	ShareCalendar(context.Context, *ShareCalendarReq) (*UserCalendar, error)
+	UnshareCalendar(context.Context, *UnshareCalendarReq) (*UserCalendar, error)
+	FreeBusy(context.Context, *FreeBusyReq) (*FreeBusyRes, error)
+	FindSlot(context.Context, *FindSlotReq) (*FindSlotRes, error)
+	CreateLabel(context.Context, *CreateLabelReq) (*Label, error)
+	ListLabels(context.Context, *ListLabelsReq) (*ListLabelsRes, error)
+	// Deletes the label from the catalogue and from every event carrying it.
+	DeleteLabel(context.Context, *LabelByIdReq) (*emptypb.Empty, error)
+	CreateWebhook(context.Context, *CreateWebhookReq) (*Webhook, error)
+	ListWebhooks(context.Context, *ListWebhooksReq) (*ListWebhooksRes, error)
+	// Deletes the webhook and its delivery log; pending deliveries are dropped.
+	DeleteWebhook(context.Context, *WebhookByIdReq) (*emptypb.Empty, error)
+	ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesReq) (*ListWebhookDeliveriesRes, error)
+	// Streams a file to attach to an event: an info message, then the content in chunks of up to 1 MiB.
+	// Over HTTP the messages are sent as newline-delimited JSON.
+	UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentReq, Attachment]) error
+	ListAttachments(context.Context, *EventByIdReq) (*ListAttachmentsRes, error)
+	GetAttachment(context.Context, *AttachmentByIdReq) (*Attachment, error)
+	// Streams the content of a file in chunks; the first one carries its content type.
+	DownloadAttachment(*AttachmentByIdReq, grpc.ServerStreamingServer[httpbody.HttpBody]) error
+	DeleteAttachment(context.Context, *AttachmentByIdReq) (*emptypb.Empty, error)
 	mustEmbedUnimplementedCalendarServer()
 }
 
@@ -146,6 +522,87 @@ func (UnimplementedCalendarServer) DeleteEvent(context.Context, *EventByIdReq) (
 func (UnimplementedCalendarServer) GetEventList(context.Context, *GetEventListReq) (*GetEventListRes, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetEventList not implemented")
 }
+func (UnimplementedCalendarServer) SearchEvents(context.Context, *SearchEventsReq) (*SearchEventsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchEvents not implemented")
+}
+func (UnimplementedCalendarServer) ListDeletedEvents(context.Context, *ListDeletedEventsReq) (*GetEventListRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeletedEvents not implemented")
+}
+func (UnimplementedCalendarServer) RestoreEvent(context.Context, *EventByIdReq) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreEvent not implemented")
+}
+func (UnimplementedCalendarServer) BatchCreateEvents(context.Context, *BatchCreateEventsReq) (*BatchEventsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateEvents not implemented")
+}
+func (UnimplementedCalendarServer) BatchEditEvents(context.Context, *BatchEditEventsReq) (*BatchEventsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchEditEvents not implemented")
+}
+func (UnimplementedCalendarServer) BatchDeleteEvents(context.Context, *BatchDeleteEventsReq) (*BatchEventsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchDeleteEvents not implemented")
+}
+func (UnimplementedCalendarServer) RespondToEvent(context.Context, *RespondToEventReq) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondToEvent not implemented")
+}
+func (UnimplementedCalendarServer) CreateCalendar(context.Context, *CreateCalendarReq) (*UserCalendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateCalendar not implemented")
+}
+func (UnimplementedCalendarServer) GetCalendar(context.Context, *CalendarByIdReq) (*UserCalendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCalendar not implemented")
+}
+func (UnimplementedCalendarServer) ListCalendars(context.Context, *ListCalendarsReq) (*ListCalendarsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListCalendars not implemented")
+}
+func (UnimplementedCalendarServer) DeleteCalendar(context.Context, *CalendarByIdReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCalendar not implemented")
+}
+func (UnimplementedCalendarServer) ShareCalendar(context.Context, *ShareCalendarReq) (*UserCalendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShareCalendar not implemented")
+}
+func (UnimplementedCalendarServer) UnshareCalendar(context.Context, *UnshareCalendarReq) (*UserCalendar, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnshareCalendar not implemented")
+}
+func (UnimplementedCalendarServer) FreeBusy(context.Context, *FreeBusyReq) (*FreeBusyRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FreeBusy not implemented")
+}
+func (UnimplementedCalendarServer) FindSlot(context.Context, *FindSlotReq) (*FindSlotRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FindSlot not implemented")
+}
+func (UnimplementedCalendarServer) CreateLabel(context.Context, *CreateLabelReq) (*Label, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateLabel not implemented")
+}
+func (UnimplementedCalendarServer) ListLabels(context.Context, *ListLabelsReq) (*ListLabelsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLabels not implemented")
+}
+func (UnimplementedCalendarServer) DeleteLabel(context.Context, *LabelByIdReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLabel not implemented")
+}
+func (UnimplementedCalendarServer) CreateWebhook(context.Context, *CreateWebhookReq) (*Webhook, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateWebhook not implemented")
+}
+func (UnimplementedCalendarServer) ListWebhooks(context.Context, *ListWebhooksReq) (*ListWebhooksRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhooks not implemented")
+}
+func (UnimplementedCalendarServer) DeleteWebhook(context.Context, *WebhookByIdReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteWebhook not implemented")
+}
+func (UnimplementedCalendarServer) ListWebhookDeliveries(context.Context, *ListWebhookDeliveriesReq) (*ListWebhookDeliveriesRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListWebhookDeliveries not implemented")
+}
+func (UnimplementedCalendarServer) UploadAttachment(grpc.ClientStreamingServer[UploadAttachmentReq, Attachment]) error {
+	return status.Errorf(codes.Unimplemented, "method UploadAttachment not implemented")
+}
+func (UnimplementedCalendarServer) ListAttachments(context.Context, *EventByIdReq) (*ListAttachmentsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAttachments not implemented")
+}
+func (UnimplementedCalendarServer) GetAttachment(context.Context, *AttachmentByIdReq) (*Attachment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAttachment not implemented")
+}
+func (UnimplementedCalendarServer) DownloadAttachment(*AttachmentByIdReq, grpc.ServerStreamingServer[httpbody.HttpBody]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadAttachment not implemented")
+}
+func (UnimplementedCalendarServer) DeleteAttachment(context.Context, *AttachmentByIdReq) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAttachment not implemented")
+}
 func (UnimplementedCalendarServer) mustEmbedUnimplementedCalendarServer() {}
 func (UnimplementedCalendarServer) testEmbeddedByValue()                  {}
 
@@ -275,38 +732,617 @@ func _Calendar_GetEventList_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
-// Calendar_ServiceDesc is the grpc.ServiceDesc for Calendar service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var Calendar_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "calendar_proto.Calendar",
-	HandlerType: (*CalendarServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetLiveZ",
-			Handler:    _Calendar_GetLiveZ_Handler,
-		},
-		{
-			MethodName: "CreateEvent",
-			Handler:    _Calendar_CreateEvent_Handler,
-		},
-		{
-			MethodName: "EditEvent",
-			Handler:    _Calendar_EditEvent_Handler,
-		},
-		{
-			MethodName: "GetEvent",
-			Handler:    _Calendar_GetEvent_Handler,
-		},
-		{
-			MethodName: "DeleteEvent",
-			Handler:    _Calendar_DeleteEvent_Handler,
-		},
-		{
-			MethodName: "GetEventList",
+func _Calendar_SearchEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchEventsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).SearchEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_SearchEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).SearchEvents(ctx, req.(*SearchEventsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_ListDeletedEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeletedEventsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).ListDeletedEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_ListDeletedEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).ListDeletedEvents(ctx, req.(*ListDeletedEventsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_RestoreEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).RestoreEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_RestoreEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).RestoreEvent(ctx, req.(*EventByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_BatchCreateEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateEventsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).BatchCreateEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_BatchCreateEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).BatchCreateEvents(ctx, req.(*BatchCreateEventsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_BatchEditEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchEditEventsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).BatchEditEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_BatchEditEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).BatchEditEvents(ctx, req.(*BatchEditEventsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_BatchDeleteEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeleteEventsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).BatchDeleteEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_BatchDeleteEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).BatchDeleteEvents(ctx, req.(*BatchDeleteEventsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_RespondToEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondToEventReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).RespondToEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_RespondToEvent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).RespondToEvent(ctx, req.(*RespondToEventReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_CreateCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateCalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).CreateCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_CreateCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).CreateCalendar(ctx, req.(*CreateCalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_GetCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).GetCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_GetCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).GetCalendar(ctx, req.(*CalendarByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_ListCalendars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCalendarsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).ListCalendars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_ListCalendars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).ListCalendars(ctx, req.(*ListCalendarsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_DeleteCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CalendarByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).DeleteCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_DeleteCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).DeleteCalendar(ctx, req.(*CalendarByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_ShareCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShareCalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).ShareCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_ShareCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).ShareCalendar(ctx, req.(*ShareCalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_UnshareCalendar_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnshareCalendarReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).UnshareCalendar(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_UnshareCalendar_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).UnshareCalendar(ctx, req.(*UnshareCalendarReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_FreeBusy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FreeBusyReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).FreeBusy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_FreeBusy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).FreeBusy(ctx, req.(*FreeBusyReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_FindSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FindSlotReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).FindSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_FindSlot_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).FindSlot(ctx, req.(*FindSlotReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_CreateLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateLabelReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).CreateLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_CreateLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).CreateLabel(ctx, req.(*CreateLabelReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_ListLabels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLabelsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).ListLabels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_ListLabels_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).ListLabels(ctx, req.(*ListLabelsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_DeleteLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).DeleteLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_DeleteLabel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).DeleteLabel(ctx, req.(*LabelByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_CreateWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateWebhookReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).CreateWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_CreateWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).CreateWebhook(ctx, req.(*CreateWebhookReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_ListWebhooks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhooksReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).ListWebhooks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_ListWebhooks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).ListWebhooks(ctx, req.(*ListWebhooksReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_DeleteWebhook_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WebhookByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).DeleteWebhook(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_DeleteWebhook_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).DeleteWebhook(ctx, req.(*WebhookByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_ListWebhookDeliveries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListWebhookDeliveriesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).ListWebhookDeliveries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_ListWebhookDeliveries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).ListWebhookDeliveries(ctx, req.(*ListWebhookDeliveriesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_UploadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(CalendarServer).UploadAttachment(&grpc.GenericServerStream[UploadAttachmentReq, Attachment]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calendar_UploadAttachmentServer = grpc.ClientStreamingServer[UploadAttachmentReq, Attachment]
+
+func _Calendar_ListAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EventByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).ListAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_ListAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).ListAttachments(ctx, req.(*EventByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_GetAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).GetAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_GetAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).GetAttachment(ctx, req.(*AttachmentByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Calendar_DownloadAttachment_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachmentByIdReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CalendarServer).DownloadAttachment(m, &grpc.GenericServerStream[AttachmentByIdReq, httpbody.HttpBody]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Calendar_DownloadAttachmentServer = grpc.ServerStreamingServer[httpbody.HttpBody]
+
+func _Calendar_DeleteAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachmentByIdReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CalendarServer).DeleteAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Calendar_DeleteAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CalendarServer).DeleteAttachment(ctx, req.(*AttachmentByIdReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Calendar_ServiceDesc is the grpc.ServiceDesc for Calendar service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Calendar_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "calendar_proto.Calendar",
+	HandlerType: (*CalendarServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetLiveZ",
+			Handler:    _Calendar_GetLiveZ_Handler,
+		},
+		{
+			MethodName: "CreateEvent",
+			Handler:    _Calendar_CreateEvent_Handler,
+		},
+		{
+			MethodName: "EditEvent",
+			Handler:    _Calendar_EditEvent_Handler,
+		},
+		{
+			MethodName: "GetEvent",
+			Handler:    _Calendar_GetEvent_Handler,
+		},
+		{
+			MethodName: "DeleteEvent",
+			Handler:    _Calendar_DeleteEvent_Handler,
+		},
+		{
+			MethodName: "GetEventList",
 			Handler:    _Calendar_GetEventList_Handler,
 		},
+		{
+			MethodName: "SearchEvents",
+			Handler:    _Calendar_SearchEvents_Handler,
+		},
+		{
+			MethodName: "ListDeletedEvents",
+			Handler:    _Calendar_ListDeletedEvents_Handler,
+		},
+		{
+			MethodName: "RestoreEvent",
+			Handler:    _Calendar_RestoreEvent_Handler,
+		},
+		{
+			MethodName: "BatchCreateEvents",
+			Handler:    _Calendar_BatchCreateEvents_Handler,
+		},
+		{
+			MethodName: "BatchEditEvents",
+			Handler:    _Calendar_BatchEditEvents_Handler,
+		},
+		{
+			MethodName: "BatchDeleteEvents",
+			Handler:    _Calendar_BatchDeleteEvents_Handler,
+		},
+		{
+			MethodName: "RespondToEvent",
+			Handler:    _Calendar_RespondToEvent_Handler,
+		},
+		{
+			MethodName: "CreateCalendar",
+			Handler:    _Calendar_CreateCalendar_Handler,
+		},
+		{
+			MethodName: "GetCalendar",
+			Handler:    _Calendar_GetCalendar_Handler,
+		},
+		{
+			MethodName: "ListCalendars",
+			Handler:    _Calendar_ListCalendars_Handler,
+		},
+		{
+			MethodName: "DeleteCalendar",
+			Handler:    _Calendar_DeleteCalendar_Handler,
+		},
+		{
+			MethodName: "ShareCalendar",
+			Handler:    _Calendar_ShareCalendar_Handler,
+		},
+		{
+			MethodName: "UnshareCalendar",
+			Handler:    _Calendar_UnshareCalendar_Handler,
+		},
+		{
+			MethodName: "FreeBusy",
+			Handler:    _Calendar_FreeBusy_Handler,
+		},
+		{
+			MethodName: "FindSlot",
+			Handler:    _Calendar_FindSlot_Handler,
+		},
+		{
+			MethodName: "CreateLabel",
+			Handler:    _Calendar_CreateLabel_Handler,
+		},
+		{
+			MethodName: "ListLabels",
+			Handler:    _Calendar_ListLabels_Handler,
+		},
+		{
+			MethodName: "DeleteLabel",
+			Handler:    _Calendar_DeleteLabel_Handler,
+		},
+		{
+			MethodName: "CreateWebhook",
+			Handler:    _Calendar_CreateWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhooks",
+			Handler:    _Calendar_ListWebhooks_Handler,
+		},
+		{
+			MethodName: "DeleteWebhook",
+			Handler:    _Calendar_DeleteWebhook_Handler,
+		},
+		{
+			MethodName: "ListWebhookDeliveries",
+			Handler:    _Calendar_ListWebhookDeliveries_Handler,
+		},
+		{
+			MethodName: "ListAttachments",
+			Handler:    _Calendar_ListAttachments_Handler,
+		},
+		{
+			MethodName: "GetAttachment",
+			Handler:    _Calendar_GetAttachment_Handler,
+		},
+		{
+			MethodName: "DeleteAttachment",
+			Handler:    _Calendar_DeleteAttachment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadAttachment",
+			Handler:       _Calendar_UploadAttachment_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadAttachment",
+			Handler:       _Calendar_DownloadAttachment_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "calendar_server.proto",
 }
@@ -35,6 +35,227 @@ var (
 	_ = sort.Sort
 )
 
+// define the regex for a UUID once up-front
+var _event_uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+// Validate checks the field values on Attendee with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Attendee) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Attendee with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AttendeeMultiError, or nil
+// if none found.
+func (m *Attendee) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Attendee) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for User
+
+	// no validation rules for Status
+
+	if len(errors) > 0 {
+		return AttendeeMultiError(errors)
+	}
+
+	return nil
+}
+
+// AttendeeMultiError is an error wrapping multiple validation errors returned
+// by Attendee.ValidateAll() if the designated constraints aren't met.
+type AttendeeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AttendeeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AttendeeMultiError) AllErrors() []error { return m }
+
+// AttendeeValidationError is the validation error returned by
+// Attendee.Validate if the designated constraints aren't met.
+type AttendeeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AttendeeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AttendeeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AttendeeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AttendeeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AttendeeValidationError) ErrorName() string { return "AttendeeValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AttendeeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAttendee.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AttendeeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AttendeeValidationError{}
+
+// Validate checks the field values on AttendeeList with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *AttendeeList) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on AttendeeList with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in AttendeeListMultiError, or
+// nil if none found.
+func (m *AttendeeList) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *AttendeeList) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetUsers() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := AttendeeListValidationError{
+				field:  fmt.Sprintf("Users[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return AttendeeListMultiError(errors)
+	}
+
+	return nil
+}
+
+// AttendeeListMultiError is an error wrapping multiple validation errors
+// returned by AttendeeList.ValidateAll() if the designated constraints aren't met.
+type AttendeeListMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m AttendeeListMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m AttendeeListMultiError) AllErrors() []error { return m }
+
+// AttendeeListValidationError is the validation error returned by
+// AttendeeList.Validate if the designated constraints aren't met.
+type AttendeeListValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e AttendeeListValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e AttendeeListValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e AttendeeListValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e AttendeeListValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e AttendeeListValidationError) ErrorName() string { return "AttendeeListValidationError" }
+
+// Error satisfies the builtin error interface
+func (e AttendeeListValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sAttendeeList.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = AttendeeListValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = AttendeeListValidationError{}
+
 // Validate checks the field values on Event with the rules defined in the
 // proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -120,6 +341,73 @@ func (m *Event) validate(all bool) error {
 
 	// no validation rules for User
 
+	for idx, item := range m.GetAttendees() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  fmt.Sprintf("Attendees[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EventValidationError{
+						field:  fmt.Sprintf("Attendees[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EventValidationError{
+					field:  fmt.Sprintf("Attendees[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetDeletedAt()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EventValidationError{
+					field:  "DeletedAt",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetDeletedAt()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EventValidationError{
+				field:  "DeletedAt",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for TimeZone
+
+	// no validation rules for AllDay
+
 	if m.Description != nil {
 		// no validation rules for Description
 	}
@@ -128,6 +416,18 @@ func (m *Event) validate(all bool) error {
 		// no validation rules for NotifyBefore
 	}
 
+	if m.CalendarId != nil {
+		// no validation rules for CalendarId
+	}
+
+	if m.Location != nil {
+		// no validation rules for Location
+	}
+
+	if m.MeetingUrl != nil {
+		// no validation rules for MeetingUrl
+	}
+
 	if len(errors) > 0 {
 		return EventMultiError(errors)
 	}
@@ -271,6 +571,55 @@ func (m *CreateEventReq) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	for idx, item := range m.GetAttendees() {
+		_, _ = idx, item
+
+		if utf8.RuneCountInString(item) < 1 {
+			err := CreateEventReqValidationError{
+				field:  fmt.Sprintf("Attendees[%v]", idx),
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	// no validation rules for AllDay
+
+	_CreateEventReq_Labels_Unique := make(map[string]struct{}, len(m.GetLabels()))
+
+	for idx, item := range m.GetLabels() {
+		_, _ = idx, item
+
+		if _, exists := _CreateEventReq_Labels_Unique[item]; exists {
+			err := CreateEventReqValidationError{
+				field:  fmt.Sprintf("Labels[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_CreateEventReq_Labels_Unique[item] = struct{}{}
+		}
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 32 {
+			err := CreateEventReqValidationError{
+				field:  fmt.Sprintf("Labels[%v]", idx),
+				reason: "value length must be between 1 and 32 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
 	if m.Description != nil {
 		// no validation rules for Description
 	}
@@ -279,22 +628,127 @@ func (m *CreateEventReq) validate(all bool) error {
 		// no validation rules for NotifyBefore
 	}
 
-	if len(errors) > 0 {
-		return CreateEventReqMultiError(errors)
+	if m.CalendarId != nil {
+		// no validation rules for CalendarId
 	}
 
-	return nil
-}
+	if m.TimeZone != nil {
 
-// CreateEventReqMultiError is an error wrapping multiple validation errors
-// returned by CreateEventReq.ValidateAll() if the designated constraints
-// aren't met.
-type CreateEventReqMultiError []error
+		if l := utf8.RuneCountInString(m.GetTimeZone()); l < 1 || l > 64 {
+			err := CreateEventReqValidationError{
+				field:  "TimeZone",
+				reason: "value length must be between 1 and 64 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
 
-// Error returns a concatenation of all the error messages it wraps.
-func (m CreateEventReqMultiError) Error() string {
-	var msgs []string
-	for _, err := range m {
+	}
+
+	if m.Id != nil {
+
+		if err := m._validateUuid(m.GetId()); err != nil {
+			err = CreateEventReqValidationError{
+				field:  "Id",
+				reason: "value must be a valid UUID",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.Location != nil {
+
+		if utf8.RuneCountInString(m.GetLocation()) > 256 {
+			err := CreateEventReqValidationError{
+				field:  "Location",
+				reason: "value length must be at most 256 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.MeetingUrl != nil {
+
+		if utf8.RuneCountInString(m.GetMeetingUrl()) > 2048 {
+			err := CreateEventReqValidationError{
+				field:  "MeetingUrl",
+				reason: "value length must be at most 2048 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if uri, err := url.Parse(m.GetMeetingUrl()); err != nil {
+			err = CreateEventReqValidationError{
+				field:  "MeetingUrl",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := CreateEventReqValidationError{
+				field:  "MeetingUrl",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+		if !_CreateEventReq_MeetingUrl_Pattern.MatchString(m.GetMeetingUrl()) {
+			err := CreateEventReqValidationError{
+				field:  "MeetingUrl",
+				reason: "value does not match regex pattern \"^https?://\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateEventReqMultiError(errors)
+	}
+
+	return nil
+}
+
+func (m *CreateEventReq) _validateUuid(uuid string) error {
+	if matched := _event_uuidPattern.MatchString(uuid); !matched {
+		return errors.New("invalid uuid format")
+	}
+
+	return nil
+}
+
+// CreateEventReqMultiError is an error wrapping multiple validation errors
+// returned by CreateEventReq.ValidateAll() if the designated constraints
+// aren't met.
+type CreateEventReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateEventReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
 		msgs = append(msgs, err.Error())
 	}
 	return strings.Join(msgs, "; ")
@@ -357,6 +811,8 @@ var _ interface {
 	ErrorName() string
 } = CreateEventReqValidationError{}
 
+var _CreateEventReq_MeetingUrl_Pattern = regexp.MustCompile("^https?://")
+
 // Validate checks the field values on EditEventReq with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -390,6 +846,64 @@ func (m *EditEventReq) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if all {
+		switch v := interface{}(m.GetAttendees()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EditEventReqValidationError{
+					field:  "Attendees",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EditEventReqValidationError{
+					field:  "Attendees",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAttendees()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EditEventReqValidationError{
+				field:  "Attendees",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetLabels()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, EditEventReqValidationError{
+					field:  "Labels",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, EditEventReqValidationError{
+					field:  "Labels",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetLabels()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return EditEventReqValidationError{
+				field:  "Labels",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if m.Title != nil {
 		// no validation rules for Title
 	}
@@ -425,66 +939,789 @@ func (m *EditEventReq) validate(all bool) error {
 			}
 		}
 
-	}
+	}
+
+	if m.EndTime != nil {
+
+		if all {
+			switch v := interface{}(m.GetEndTime()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, EditEventReqValidationError{
+						field:  "EndTime",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, EditEventReqValidationError{
+						field:  "EndTime",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetEndTime()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return EditEventReqValidationError{
+					field:  "EndTime",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.Description != nil {
+		// no validation rules for Description
+	}
+
+	if m.User != nil {
+		// no validation rules for User
+	}
+
+	if m.NotifyBefore != nil {
+		// no validation rules for NotifyBefore
+	}
+
+	if m.CalendarId != nil {
+		// no validation rules for CalendarId
+	}
+
+	if m.TimeZone != nil {
+
+		if l := utf8.RuneCountInString(m.GetTimeZone()); l < 1 || l > 64 {
+			err := EditEventReqValidationError{
+				field:  "TimeZone",
+				reason: "value length must be between 1 and 64 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.AllDay != nil {
+		// no validation rules for AllDay
+	}
+
+	if m.Location != nil {
+
+		if utf8.RuneCountInString(m.GetLocation()) > 256 {
+			err := EditEventReqValidationError{
+				field:  "Location",
+				reason: "value length must be at most 256 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.MeetingUrl != nil {
+
+		if m.GetMeetingUrl() != "" {
+
+			if utf8.RuneCountInString(m.GetMeetingUrl()) > 2048 {
+				err := EditEventReqValidationError{
+					field:  "MeetingUrl",
+					reason: "value length must be at most 2048 runes",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+			if uri, err := url.Parse(m.GetMeetingUrl()); err != nil {
+				err = EditEventReqValidationError{
+					field:  "MeetingUrl",
+					reason: "value must be a valid URI",
+					cause:  err,
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			} else if !uri.IsAbs() {
+				err := EditEventReqValidationError{
+					field:  "MeetingUrl",
+					reason: "value must be absolute",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+			if !_EditEventReq_MeetingUrl_Pattern.MatchString(m.GetMeetingUrl()) {
+				err := EditEventReqValidationError{
+					field:  "MeetingUrl",
+					reason: "value does not match regex pattern \"^https?://\"",
+				}
+				if !all {
+					return err
+				}
+				errors = append(errors, err)
+			}
+
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return EditEventReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// EditEventReqMultiError is an error wrapping multiple validation errors
+// returned by EditEventReq.ValidateAll() if the designated constraints aren't met.
+type EditEventReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EditEventReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EditEventReqMultiError) AllErrors() []error { return m }
+
+// EditEventReqValidationError is the validation error returned by
+// EditEventReq.Validate if the designated constraints aren't met.
+type EditEventReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EditEventReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EditEventReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EditEventReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EditEventReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EditEventReqValidationError) ErrorName() string { return "EditEventReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EditEventReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEditEventReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EditEventReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EditEventReqValidationError{}
+
+var _EditEventReq_MeetingUrl_Pattern = regexp.MustCompile("^https?://")
+
+// Validate checks the field values on EventByIdReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *EventByIdReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on EventByIdReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in EventByIdReqMultiError, or
+// nil if none found.
+func (m *EventByIdReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *EventByIdReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetEventId()) < 1 {
+		err := EventByIdReqValidationError{
+			field:  "EventId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return EventByIdReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// EventByIdReqMultiError is an error wrapping multiple validation errors
+// returned by EventByIdReq.ValidateAll() if the designated constraints aren't met.
+type EventByIdReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m EventByIdReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m EventByIdReqMultiError) AllErrors() []error { return m }
+
+// EventByIdReqValidationError is the validation error returned by
+// EventByIdReq.Validate if the designated constraints aren't met.
+type EventByIdReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e EventByIdReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e EventByIdReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e EventByIdReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e EventByIdReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e EventByIdReqValidationError) ErrorName() string { return "EventByIdReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e EventByIdReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sEventByIdReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = EventByIdReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = EventByIdReqValidationError{}
+
+// Validate checks the field values on GetEventListReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetEventListReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetEventListReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetEventListReqMultiError, or nil if none found.
+func (m *GetEventListReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetEventListReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if m.Start != nil {
+		// no validation rules for Start
+	}
+
+	if m.End != nil {
+		// no validation rules for End
+	}
+
+	if m.User != nil {
+
+		if utf8.RuneCountInString(m.GetUser()) < 1 {
+			err := GetEventListReqValidationError{
+				field:  "User",
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetEventListReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetEventListReqMultiError is an error wrapping multiple validation errors
+// returned by GetEventListReq.ValidateAll() if the designated constraints
+// aren't met.
+type GetEventListReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetEventListReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetEventListReqMultiError) AllErrors() []error { return m }
+
+// GetEventListReqValidationError is the validation error returned by
+// GetEventListReq.Validate if the designated constraints aren't met.
+type GetEventListReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetEventListReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetEventListReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetEventListReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetEventListReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetEventListReqValidationError) ErrorName() string { return "GetEventListReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetEventListReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetEventListReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetEventListReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetEventListReqValidationError{}
+
+// Validate checks the field values on GetEventListRes with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *GetEventListRes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on GetEventListRes with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// GetEventListResMultiError, or nil if none found.
+func (m *GetEventListRes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *GetEventListRes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, GetEventListResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, GetEventListResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return GetEventListResValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return GetEventListResMultiError(errors)
+	}
+
+	return nil
+}
+
+// GetEventListResMultiError is an error wrapping multiple validation errors
+// returned by GetEventListRes.ValidateAll() if the designated constraints
+// aren't met.
+type GetEventListResMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m GetEventListResMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m GetEventListResMultiError) AllErrors() []error { return m }
+
+// GetEventListResValidationError is the validation error returned by
+// GetEventListRes.Validate if the designated constraints aren't met.
+type GetEventListResValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e GetEventListResValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e GetEventListResValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e GetEventListResValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e GetEventListResValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e GetEventListResValidationError) ErrorName() string { return "GetEventListResValidationError" }
+
+// Error satisfies the builtin error interface
+func (e GetEventListResValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sGetEventListRes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = GetEventListResValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = GetEventListResValidationError{}
+
+// Validate checks the field values on RespondToEventReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RespondToEventReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RespondToEventReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RespondToEventReqMultiError, or nil if none found.
+func (m *RespondToEventReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RespondToEventReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetEventId()) < 1 {
+		err := RespondToEventReqValidationError{
+			field:  "EventId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetUser()) < 1 {
+		err := RespondToEventReqValidationError{
+			field:  "User",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := _RespondToEventReq_Status_NotInLookup[m.GetStatus()]; ok {
+		err := RespondToEventReqValidationError{
+			field:  "Status",
+			reason: "value must not be in list [ATTENDEE_STATUS_NEEDS_ACTION]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if _, ok := AttendeeStatus_name[int32(m.GetStatus())]; !ok {
+		err := RespondToEventReqValidationError{
+			field:  "Status",
+			reason: "value must be one of the defined enum values",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return RespondToEventReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// RespondToEventReqMultiError is an error wrapping multiple validation errors
+// returned by RespondToEventReq.ValidateAll() if the designated constraints
+// aren't met.
+type RespondToEventReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RespondToEventReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RespondToEventReqMultiError) AllErrors() []error { return m }
+
+// RespondToEventReqValidationError is the validation error returned by
+// RespondToEventReq.Validate if the designated constraints aren't met.
+type RespondToEventReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RespondToEventReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RespondToEventReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RespondToEventReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RespondToEventReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RespondToEventReqValidationError) ErrorName() string {
+	return "RespondToEventReqValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e RespondToEventReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRespondToEventReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RespondToEventReqValidationError{}
 
-	if m.EndTime != nil {
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RespondToEventReqValidationError{}
 
-		if all {
-			switch v := interface{}(m.GetEndTime()).(type) {
-			case interface{ ValidateAll() error }:
-				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, EditEventReqValidationError{
-						field:  "EndTime",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			case interface{ Validate() error }:
-				if err := v.Validate(); err != nil {
-					errors = append(errors, EditEventReqValidationError{
-						field:  "EndTime",
-						reason: "embedded message failed validation",
-						cause:  err,
-					})
-				}
-			}
-		} else if v, ok := interface{}(m.GetEndTime()).(interface{ Validate() error }); ok {
-			if err := v.Validate(); err != nil {
-				return EditEventReqValidationError{
-					field:  "EndTime",
-					reason: "embedded message failed validation",
-					cause:  err,
-				}
-			}
-		}
+var _RespondToEventReq_Status_NotInLookup = map[AttendeeStatus]struct{}{
+	0: {},
+}
 
-	}
+// Validate checks the field values on ListDeletedEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *ListDeletedEventsReq) Validate() error {
+	return m.validate(false)
+}
 
-	if m.Description != nil {
-		// no validation rules for Description
+// ValidateAll checks the field values on ListDeletedEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ListDeletedEventsReqMultiError, or nil if none found.
+func (m *ListDeletedEventsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListDeletedEventsReq) validate(all bool) error {
+	if m == nil {
+		return nil
 	}
 
+	var errors []error
+
 	if m.User != nil {
-		// no validation rules for User
-	}
 
-	if m.NotifyBefore != nil {
-		// no validation rules for NotifyBefore
+		if utf8.RuneCountInString(m.GetUser()) < 1 {
+			err := ListDeletedEventsReqValidationError{
+				field:  "User",
+				reason: "value length must be at least 1 runes",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
 	}
 
 	if len(errors) > 0 {
-		return EditEventReqMultiError(errors)
+		return ListDeletedEventsReqMultiError(errors)
 	}
 
 	return nil
 }
 
-// EditEventReqMultiError is an error wrapping multiple validation errors
-// returned by EditEventReq.ValidateAll() if the designated constraints aren't met.
-type EditEventReqMultiError []error
+// ListDeletedEventsReqMultiError is an error wrapping multiple validation
+// errors returned by ListDeletedEventsReq.ValidateAll() if the designated
+// constraints aren't met.
+type ListDeletedEventsReqMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m EditEventReqMultiError) Error() string {
+func (m ListDeletedEventsReqMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -493,11 +1730,11 @@ func (m EditEventReqMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m EditEventReqMultiError) AllErrors() []error { return m }
+func (m ListDeletedEventsReqMultiError) AllErrors() []error { return m }
 
-// EditEventReqValidationError is the validation error returned by
-// EditEventReq.Validate if the designated constraints aren't met.
-type EditEventReqValidationError struct {
+// ListDeletedEventsReqValidationError is the validation error returned by
+// ListDeletedEventsReq.Validate if the designated constraints aren't met.
+type ListDeletedEventsReqValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -505,22 +1742,24 @@ type EditEventReqValidationError struct {
 }
 
 // Field function returns field value.
-func (e EditEventReqValidationError) Field() string { return e.field }
+func (e ListDeletedEventsReqValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e EditEventReqValidationError) Reason() string { return e.reason }
+func (e ListDeletedEventsReqValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e EditEventReqValidationError) Cause() error { return e.cause }
+func (e ListDeletedEventsReqValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e EditEventReqValidationError) Key() bool { return e.key }
+func (e ListDeletedEventsReqValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e EditEventReqValidationError) ErrorName() string { return "EditEventReqValidationError" }
+func (e ListDeletedEventsReqValidationError) ErrorName() string {
+	return "ListDeletedEventsReqValidationError"
+}
 
 // Error satisfies the builtin error interface
-func (e EditEventReqValidationError) Error() string {
+func (e ListDeletedEventsReqValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -532,14 +1771,14 @@ func (e EditEventReqValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sEditEventReq.%s: %s%s",
+		"invalid %sListDeletedEventsReq.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = EditEventReqValidationError{}
+var _ error = ListDeletedEventsReqValidationError{}
 
 var _ interface {
 	Field() string
@@ -547,33 +1786,44 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = EditEventReqValidationError{}
+} = ListDeletedEventsReqValidationError{}
 
-// Validate checks the field values on EventByIdReq with the rules defined in
-// the proto definition for this message. If any rules are violated, the first
-// error encountered is returned, or nil if there are no violations.
-func (m *EventByIdReq) Validate() error {
+// Validate checks the field values on SearchEventsReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *SearchEventsReq) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on EventByIdReq with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// result is a list of violation errors wrapped in EventByIdReqMultiError, or
-// nil if none found.
-func (m *EventByIdReq) ValidateAll() error {
+// ValidateAll checks the field values on SearchEventsReq with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// SearchEventsReqMultiError, or nil if none found.
+func (m *SearchEventsReq) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *EventByIdReq) validate(all bool) error {
+func (m *SearchEventsReq) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if utf8.RuneCountInString(m.GetEventId()) < 1 {
-		err := EventByIdReqValidationError{
-			field:  "EventId",
+	if l := utf8.RuneCountInString(m.GetQuery()); l < 1 || l > 256 {
+		err := SearchEventsReqValidationError{
+			field:  "Query",
+			reason: "value length must be between 1 and 256 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if utf8.RuneCountInString(m.GetUser()) < 1 {
+		err := SearchEventsReqValidationError{
+			field:  "User",
 			reason: "value length must be at least 1 runes",
 		}
 		if !all {
@@ -582,19 +1832,89 @@ func (m *EventByIdReq) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
+	if all {
+		switch v := interface{}(m.GetStart()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SearchEventsReqValidationError{
+					field:  "Start",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SearchEventsReqValidationError{
+					field:  "Start",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetStart()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SearchEventsReqValidationError{
+				field:  "Start",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetEnd()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SearchEventsReqValidationError{
+					field:  "End",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SearchEventsReqValidationError{
+					field:  "End",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEnd()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SearchEventsReqValidationError{
+				field:  "End",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if m.GetLimit() > 100 {
+		err := SearchEventsReqValidationError{
+			field:  "Limit",
+			reason: "value must be less than or equal to 100",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if len(errors) > 0 {
-		return EventByIdReqMultiError(errors)
+		return SearchEventsReqMultiError(errors)
 	}
 
 	return nil
 }
 
-// EventByIdReqMultiError is an error wrapping multiple validation errors
-// returned by EventByIdReq.ValidateAll() if the designated constraints aren't met.
-type EventByIdReqMultiError []error
+// SearchEventsReqMultiError is an error wrapping multiple validation errors
+// returned by SearchEventsReq.ValidateAll() if the designated constraints
+// aren't met.
+type SearchEventsReqMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m EventByIdReqMultiError) Error() string {
+func (m SearchEventsReqMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -603,11 +1923,11 @@ func (m EventByIdReqMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m EventByIdReqMultiError) AllErrors() []error { return m }
+func (m SearchEventsReqMultiError) AllErrors() []error { return m }
 
-// EventByIdReqValidationError is the validation error returned by
-// EventByIdReq.Validate if the designated constraints aren't met.
-type EventByIdReqValidationError struct {
+// SearchEventsReqValidationError is the validation error returned by
+// SearchEventsReq.Validate if the designated constraints aren't met.
+type SearchEventsReqValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -615,22 +1935,22 @@ type EventByIdReqValidationError struct {
 }
 
 // Field function returns field value.
-func (e EventByIdReqValidationError) Field() string { return e.field }
+func (e SearchEventsReqValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e EventByIdReqValidationError) Reason() string { return e.reason }
+func (e SearchEventsReqValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e EventByIdReqValidationError) Cause() error { return e.cause }
+func (e SearchEventsReqValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e EventByIdReqValidationError) Key() bool { return e.key }
+func (e SearchEventsReqValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e EventByIdReqValidationError) ErrorName() string { return "EventByIdReqValidationError" }
+func (e SearchEventsReqValidationError) ErrorName() string { return "SearchEventsReqValidationError" }
 
 // Error satisfies the builtin error interface
-func (e EventByIdReqValidationError) Error() string {
+func (e SearchEventsReqValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -642,14 +1962,14 @@ func (e EventByIdReqValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sEventByIdReq.%s: %s%s",
+		"invalid %sSearchEventsReq.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = EventByIdReqValidationError{}
+var _ error = SearchEventsReqValidationError{}
 
 var _ interface {
 	Field() string
@@ -657,52 +1977,74 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = EventByIdReqValidationError{}
+} = SearchEventsReqValidationError{}
 
-// Validate checks the field values on GetEventListReq with the rules defined
-// in the proto definition for this message. If any rules are violated, the
-// first error encountered is returned, or nil if there are no violations.
-func (m *GetEventListReq) Validate() error {
+// Validate checks the field values on SearchHit with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *SearchHit) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetEventListReq with the rules
-// defined in the proto definition for this message. If any rules are
-// violated, the result is a list of violation errors wrapped in
-// GetEventListReqMultiError, or nil if none found.
-func (m *GetEventListReq) ValidateAll() error {
+// ValidateAll checks the field values on SearchHit with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in SearchHitMultiError, or nil
+// if none found.
+func (m *SearchHit) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetEventListReq) validate(all bool) error {
+func (m *SearchHit) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
 
 	var errors []error
 
-	if m.Start != nil {
-		// no validation rules for Start
+	if all {
+		switch v := interface{}(m.GetEvent()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, SearchHitValidationError{
+					field:  "Event",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, SearchHitValidationError{
+					field:  "Event",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetEvent()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return SearchHitValidationError{
+				field:  "Event",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
 	}
 
-	if m.End != nil {
-		// no validation rules for End
-	}
+	// no validation rules for Rank
 
 	if len(errors) > 0 {
-		return GetEventListReqMultiError(errors)
+		return SearchHitMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetEventListReqMultiError is an error wrapping multiple validation errors
-// returned by GetEventListReq.ValidateAll() if the designated constraints
-// aren't met.
-type GetEventListReqMultiError []error
+// SearchHitMultiError is an error wrapping multiple validation errors returned
+// by SearchHit.ValidateAll() if the designated constraints aren't met.
+type SearchHitMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetEventListReqMultiError) Error() string {
+func (m SearchHitMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -711,11 +2053,11 @@ func (m GetEventListReqMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetEventListReqMultiError) AllErrors() []error { return m }
+func (m SearchHitMultiError) AllErrors() []error { return m }
 
-// GetEventListReqValidationError is the validation error returned by
-// GetEventListReq.Validate if the designated constraints aren't met.
-type GetEventListReqValidationError struct {
+// SearchHitValidationError is the validation error returned by
+// SearchHit.Validate if the designated constraints aren't met.
+type SearchHitValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -723,22 +2065,22 @@ type GetEventListReqValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetEventListReqValidationError) Field() string { return e.field }
+func (e SearchHitValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetEventListReqValidationError) Reason() string { return e.reason }
+func (e SearchHitValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetEventListReqValidationError) Cause() error { return e.cause }
+func (e SearchHitValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetEventListReqValidationError) Key() bool { return e.key }
+func (e SearchHitValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetEventListReqValidationError) ErrorName() string { return "GetEventListReqValidationError" }
+func (e SearchHitValidationError) ErrorName() string { return "SearchHitValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetEventListReqValidationError) Error() string {
+func (e SearchHitValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -750,14 +2092,14 @@ func (e GetEventListReqValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetEventListReq.%s: %s%s",
+		"invalid %sSearchHit.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetEventListReqValidationError{}
+var _ error = SearchHitValidationError{}
 
 var _ interface {
 	Field() string
@@ -765,24 +2107,24 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetEventListReqValidationError{}
+} = SearchHitValidationError{}
 
-// Validate checks the field values on GetEventListRes with the rules defined
+// Validate checks the field values on SearchEventsRes with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
-func (m *GetEventListRes) Validate() error {
+func (m *SearchEventsRes) Validate() error {
 	return m.validate(false)
 }
 
-// ValidateAll checks the field values on GetEventListRes with the rules
+// ValidateAll checks the field values on SearchEventsRes with the rules
 // defined in the proto definition for this message. If any rules are
 // violated, the result is a list of violation errors wrapped in
-// GetEventListResMultiError, or nil if none found.
-func (m *GetEventListRes) ValidateAll() error {
+// SearchEventsResMultiError, or nil if none found.
+func (m *SearchEventsRes) ValidateAll() error {
 	return m.validate(true)
 }
 
-func (m *GetEventListRes) validate(all bool) error {
+func (m *SearchEventsRes) validate(all bool) error {
 	if m == nil {
 		return nil
 	}
@@ -796,7 +2138,7 @@ func (m *GetEventListRes) validate(all bool) error {
 			switch v := interface{}(item).(type) {
 			case interface{ ValidateAll() error }:
 				if err := v.ValidateAll(); err != nil {
-					errors = append(errors, GetEventListResValidationError{
+					errors = append(errors, SearchEventsResValidationError{
 						field:  fmt.Sprintf("Data[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
@@ -804,7 +2146,7 @@ func (m *GetEventListRes) validate(all bool) error {
 				}
 			case interface{ Validate() error }:
 				if err := v.Validate(); err != nil {
-					errors = append(errors, GetEventListResValidationError{
+					errors = append(errors, SearchEventsResValidationError{
 						field:  fmt.Sprintf("Data[%v]", idx),
 						reason: "embedded message failed validation",
 						cause:  err,
@@ -813,7 +2155,7 @@ func (m *GetEventListRes) validate(all bool) error {
 			}
 		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
 			if err := v.Validate(); err != nil {
-				return GetEventListResValidationError{
+				return SearchEventsResValidationError{
 					field:  fmt.Sprintf("Data[%v]", idx),
 					reason: "embedded message failed validation",
 					cause:  err,
@@ -824,19 +2166,19 @@ func (m *GetEventListRes) validate(all bool) error {
 	}
 
 	if len(errors) > 0 {
-		return GetEventListResMultiError(errors)
+		return SearchEventsResMultiError(errors)
 	}
 
 	return nil
 }
 
-// GetEventListResMultiError is an error wrapping multiple validation errors
-// returned by GetEventListRes.ValidateAll() if the designated constraints
+// SearchEventsResMultiError is an error wrapping multiple validation errors
+// returned by SearchEventsRes.ValidateAll() if the designated constraints
 // aren't met.
-type GetEventListResMultiError []error
+type SearchEventsResMultiError []error
 
 // Error returns a concatenation of all the error messages it wraps.
-func (m GetEventListResMultiError) Error() string {
+func (m SearchEventsResMultiError) Error() string {
 	var msgs []string
 	for _, err := range m {
 		msgs = append(msgs, err.Error())
@@ -845,11 +2187,11 @@ func (m GetEventListResMultiError) Error() string {
 }
 
 // AllErrors returns a list of validation violation errors.
-func (m GetEventListResMultiError) AllErrors() []error { return m }
+func (m SearchEventsResMultiError) AllErrors() []error { return m }
 
-// GetEventListResValidationError is the validation error returned by
-// GetEventListRes.Validate if the designated constraints aren't met.
-type GetEventListResValidationError struct {
+// SearchEventsResValidationError is the validation error returned by
+// SearchEventsRes.Validate if the designated constraints aren't met.
+type SearchEventsResValidationError struct {
 	field  string
 	reason string
 	cause  error
@@ -857,22 +2199,22 @@ type GetEventListResValidationError struct {
 }
 
 // Field function returns field value.
-func (e GetEventListResValidationError) Field() string { return e.field }
+func (e SearchEventsResValidationError) Field() string { return e.field }
 
 // Reason function returns reason value.
-func (e GetEventListResValidationError) Reason() string { return e.reason }
+func (e SearchEventsResValidationError) Reason() string { return e.reason }
 
 // Cause function returns cause value.
-func (e GetEventListResValidationError) Cause() error { return e.cause }
+func (e SearchEventsResValidationError) Cause() error { return e.cause }
 
 // Key function returns key value.
-func (e GetEventListResValidationError) Key() bool { return e.key }
+func (e SearchEventsResValidationError) Key() bool { return e.key }
 
 // ErrorName returns error name.
-func (e GetEventListResValidationError) ErrorName() string { return "GetEventListResValidationError" }
+func (e SearchEventsResValidationError) ErrorName() string { return "SearchEventsResValidationError" }
 
 // Error satisfies the builtin error interface
-func (e GetEventListResValidationError) Error() string {
+func (e SearchEventsResValidationError) Error() string {
 	cause := ""
 	if e.cause != nil {
 		cause = fmt.Sprintf(" | caused by: %v", e.cause)
@@ -884,14 +2226,14 @@ func (e GetEventListResValidationError) Error() string {
 	}
 
 	return fmt.Sprintf(
-		"invalid %sGetEventListRes.%s: %s%s",
+		"invalid %sSearchEventsRes.%s: %s%s",
 		key,
 		e.field,
 		e.reason,
 		cause)
 }
 
-var _ error = GetEventListResValidationError{}
+var _ error = SearchEventsResValidationError{}
 
 var _ interface {
 	Field() string
@@ -899,4 +2241,4 @@ var _ interface {
 	Key() bool
 	Cause() error
 	ErrorName() string
-} = GetEventListResValidationError{}
+} = SearchEventsResValidationError{}
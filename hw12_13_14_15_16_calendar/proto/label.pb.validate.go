@@ -0,0 +1,768 @@
+// Code generated by protoc-gen-validate. DO NOT EDIT.
+// source: label.proto
+
+package proto
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// ensure the imports are used
+var (
+	_ = bytes.MinRead
+	_ = errors.New("")
+	_ = fmt.Print
+	_ = utf8.UTFMax
+	_ = (*regexp.Regexp)(nil)
+	_ = (*strings.Reader)(nil)
+	_ = net.IPv4len
+	_ = time.Duration(0)
+	_ = (*url.URL)(nil)
+	_ = (*mail.Address)(nil)
+	_ = anypb.Any{}
+	_ = sort.Sort
+)
+
+// Validate checks the field values on Label with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Label) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Label with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in LabelMultiError, or nil if none found.
+func (m *Label) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Label) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for Owner
+
+	// no validation rules for Name
+
+	if m.Color != nil {
+		// no validation rules for Color
+	}
+
+	if len(errors) > 0 {
+		return LabelMultiError(errors)
+	}
+
+	return nil
+}
+
+// LabelMultiError is an error wrapping multiple validation errors returned by
+// Label.ValidateAll() if the designated constraints aren't met.
+type LabelMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LabelMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LabelMultiError) AllErrors() []error { return m }
+
+// LabelValidationError is the validation error returned by Label.Validate if
+// the designated constraints aren't met.
+type LabelValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LabelValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LabelValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LabelValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LabelValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LabelValidationError) ErrorName() string { return "LabelValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LabelValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLabel.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LabelValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LabelValidationError{}
+
+// Validate checks the field values on CreateLabelReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *CreateLabelReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on CreateLabelReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in CreateLabelReqMultiError,
+// or nil if none found.
+func (m *CreateLabelReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *CreateLabelReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUser()) < 1 {
+		err := CreateLabelReqValidationError{
+			field:  "User",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if l := utf8.RuneCountInString(m.GetName()); l < 1 || l > 32 {
+		err := CreateLabelReqValidationError{
+			field:  "Name",
+			reason: "value length must be between 1 and 32 runes, inclusive",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if m.Color != nil {
+
+		if !_CreateLabelReq_Color_Pattern.MatchString(m.GetColor()) {
+			err := CreateLabelReqValidationError{
+				field:  "Color",
+				reason: "value does not match regex pattern \"^#[0-9a-fA-F]{6}$\"",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return CreateLabelReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// CreateLabelReqMultiError is an error wrapping multiple validation errors
+// returned by CreateLabelReq.ValidateAll() if the designated constraints
+// aren't met.
+type CreateLabelReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m CreateLabelReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m CreateLabelReqMultiError) AllErrors() []error { return m }
+
+// CreateLabelReqValidationError is the validation error returned by
+// CreateLabelReq.Validate if the designated constraints aren't met.
+type CreateLabelReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e CreateLabelReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e CreateLabelReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e CreateLabelReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e CreateLabelReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e CreateLabelReqValidationError) ErrorName() string { return "CreateLabelReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e CreateLabelReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sCreateLabelReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = CreateLabelReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = CreateLabelReqValidationError{}
+
+var _CreateLabelReq_Color_Pattern = regexp.MustCompile("^#[0-9a-fA-F]{6}$")
+
+// Validate checks the field values on ListLabelsReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ListLabelsReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListLabelsReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ListLabelsReqMultiError, or
+// nil if none found.
+func (m *ListLabelsReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListLabelsReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetUser()) < 1 {
+		err := ListLabelsReqValidationError{
+			field:  "User",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return ListLabelsReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListLabelsReqMultiError is an error wrapping multiple validation errors
+// returned by ListLabelsReq.ValidateAll() if the designated constraints
+// aren't met.
+type ListLabelsReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListLabelsReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListLabelsReqMultiError) AllErrors() []error { return m }
+
+// ListLabelsReqValidationError is the validation error returned by
+// ListLabelsReq.Validate if the designated constraints aren't met.
+type ListLabelsReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListLabelsReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListLabelsReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListLabelsReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListLabelsReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListLabelsReqValidationError) ErrorName() string { return "ListLabelsReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListLabelsReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListLabelsReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListLabelsReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListLabelsReqValidationError{}
+
+// Validate checks the field values on ListLabelsRes with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *ListLabelsRes) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ListLabelsRes with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in ListLabelsResMultiError, or
+// nil if none found.
+func (m *ListLabelsRes) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ListLabelsRes) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetData() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ListLabelsResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ListLabelsResValidationError{
+						field:  fmt.Sprintf("Data[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ListLabelsResValidationError{
+					field:  fmt.Sprintf("Data[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return ListLabelsResMultiError(errors)
+	}
+
+	return nil
+}
+
+// ListLabelsResMultiError is an error wrapping multiple validation errors
+// returned by ListLabelsRes.ValidateAll() if the designated constraints
+// aren't met.
+type ListLabelsResMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ListLabelsResMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ListLabelsResMultiError) AllErrors() []error { return m }
+
+// ListLabelsResValidationError is the validation error returned by
+// ListLabelsRes.Validate if the designated constraints aren't met.
+type ListLabelsResValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ListLabelsResValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ListLabelsResValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ListLabelsResValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ListLabelsResValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ListLabelsResValidationError) ErrorName() string { return "ListLabelsResValidationError" }
+
+// Error satisfies the builtin error interface
+func (e ListLabelsResValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sListLabelsRes.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ListLabelsResValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ListLabelsResValidationError{}
+
+// Validate checks the field values on LabelByIdReq with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LabelByIdReq) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LabelByIdReq with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LabelByIdReqMultiError, or
+// nil if none found.
+func (m *LabelByIdReq) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LabelByIdReq) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if utf8.RuneCountInString(m.GetLabelId()) < 1 {
+		err := LabelByIdReqValidationError{
+			field:  "LabelId",
+			reason: "value length must be at least 1 runes",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if len(errors) > 0 {
+		return LabelByIdReqMultiError(errors)
+	}
+
+	return nil
+}
+
+// LabelByIdReqMultiError is an error wrapping multiple validation errors
+// returned by LabelByIdReq.ValidateAll() if the designated constraints aren't met.
+type LabelByIdReqMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LabelByIdReqMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LabelByIdReqMultiError) AllErrors() []error { return m }
+
+// LabelByIdReqValidationError is the validation error returned by
+// LabelByIdReq.Validate if the designated constraints aren't met.
+type LabelByIdReqValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LabelByIdReqValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LabelByIdReqValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LabelByIdReqValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LabelByIdReqValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LabelByIdReqValidationError) ErrorName() string { return "LabelByIdReqValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LabelByIdReqValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLabelByIdReq.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LabelByIdReqValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LabelByIdReqValidationError{}
+
+// Validate checks the field values on LabelList with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *LabelList) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on LabelList with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in LabelListMultiError, or nil
+// if none found.
+func (m *LabelList) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *LabelList) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	_LabelList_Names_Unique := make(map[string]struct{}, len(m.GetNames()))
+
+	for idx, item := range m.GetNames() {
+		_, _ = idx, item
+
+		if _, exists := _LabelList_Names_Unique[item]; exists {
+			err := LabelListValidationError{
+				field:  fmt.Sprintf("Names[%v]", idx),
+				reason: "repeated value must contain unique items",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else {
+			_LabelList_Names_Unique[item] = struct{}{}
+		}
+
+		if l := utf8.RuneCountInString(item); l < 1 || l > 32 {
+			err := LabelListValidationError{
+				field:  fmt.Sprintf("Names[%v]", idx),
+				reason: "value length must be between 1 and 32 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return LabelListMultiError(errors)
+	}
+
+	return nil
+}
+
+// LabelListMultiError is an error wrapping multiple validation errors returned
+// by LabelList.ValidateAll() if the designated constraints aren't met.
+type LabelListMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LabelListMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LabelListMultiError) AllErrors() []error { return m }
+
+// LabelListValidationError is the validation error returned by
+// LabelList.Validate if the designated constraints aren't met.
+type LabelListValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LabelListValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LabelListValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LabelListValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LabelListValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LabelListValidationError) ErrorName() string { return "LabelListValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LabelListValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLabelList.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LabelListValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LabelListValidationError{}
@@ -0,0 +1,85 @@
+package integration
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// searchTitles runs SearchEvents for the user and returns the sorted titles of the hits.
+func searchTitles(t *testing.T, user, query string) []string {
+	t.Helper()
+	u, _ := url.Parse(baseURL + "/api/v1/events/search")
+	q := u.Query()
+	q.Set("user", user)
+	q.Set("query", query)
+	u.RawQuery = q.Encode()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		t.Fatalf("failed to create GET request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to send GET request: %v", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("failed to read response body: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status: %d, body: %s", resp.StatusCode, string(respBody))
+	}
+
+	var res struct {
+		Data []struct {
+			Event Event `json:"event"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(respBody, &res); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	titles := make([]string, 0, len(res.Data))
+	for _, hit := range res.Data {
+		titles = append(titles, hit.Event.Title)
+	}
+	slices.Sort(titles)
+	return titles
+}
+
+// TestSearchEvents_Operators mirrors TestEventSearchOperators of the memory storage, so that both
+// backends are held to the same query syntax.
+func TestSearchEvents_Operators(t *testing.T) {
+	user := uuid.NewString()
+	start := time.Now().AddDate(1, 0, 0).UTC().Truncate(time.Hour)
+	for i, title := range []string{"Budget review", "Review of the budget", "Design review", "Offsite planning"} {
+		at := start.Add(time.Duration(i) * time.Hour)
+		createEvent(t, Event{
+			Title:   title,
+			Date:    at.Format(time.RFC3339),
+			EndTime: at.Add(time.Hour).Format(time.RFC3339),
+			User:    user,
+		})
+	}
+
+	assert.Equal(t, []string{"Budget review", "Review of the budget"}, searchTitles(t, user, "budget review"))
+	assert.Equal(t, []string{"Budget review"}, searchTitles(t, user, `"budget review"`))
+	assert.Equal(t, []string{"Design review", "Review of the budget"}, searchTitles(t, user, `review -"budget review"`))
+	assert.Equal(t, []string{"Design review"}, searchTitles(t, user, "review -budget"))
+	assert.Equal(t, []string{"Budget review", "Design review", "Review of the budget"},
+		searchTitles(t, user, "design or budget"))
+	assert.Equal(t, []string{"Design review", "Offsite planning"}, searchTitles(t, user, "offsite OR design review"))
+	assert.Equal(t, []string{"Offsite planning"}, searchTitles(t, user, "-review"))
+}